@@ -9,6 +9,26 @@ type ValidationOptions struct {
 	RegexEngine       jsonschema.RegexpEngine
 	FormatAssertions  bool
 	ContentAssertions bool
+	MaxBodyBytes      int64
+	MaxDecodedBytes   int64
+	AllowEmptyValue   bool
+	StrictQueryParams bool
+	BasePath          string
+	Formats           map[string]func(v any) error
+	MessageTranslator func(key string, args map[string]any) string
+	CollectAllErrors  bool
+	SchemaDraft       *jsonschema.Draft
+	CompilerFactory   func() *jsonschema.Compiler
+	ForwardedPrefix   bool
+	StopOnFirstRecord bool
+	SkipBadPatterns   bool
+	NestedDeepObjects bool
+	Observer          Observer
+	PathAllowlist     []string
+	RejectBodies      bool
+	IgnoredKeywords   []string
+	IgnoreAdditional  bool
+	WarnOptionalQuery bool
 }
 
 // Option Enables an 'Options pattern' approach
@@ -39,6 +59,26 @@ func WithExistingOpts(options *ValidationOptions) Option {
 		o.RegexEngine = options.RegexEngine
 		o.FormatAssertions = options.FormatAssertions
 		o.ContentAssertions = options.ContentAssertions
+		o.MaxBodyBytes = options.MaxBodyBytes
+		o.MaxDecodedBytes = options.MaxDecodedBytes
+		o.AllowEmptyValue = options.AllowEmptyValue
+		o.StrictQueryParams = options.StrictQueryParams
+		o.BasePath = options.BasePath
+		o.Formats = options.Formats
+		o.MessageTranslator = options.MessageTranslator
+		o.CollectAllErrors = options.CollectAllErrors
+		o.SchemaDraft = options.SchemaDraft
+		o.CompilerFactory = options.CompilerFactory
+		o.ForwardedPrefix = options.ForwardedPrefix
+		o.StopOnFirstRecord = options.StopOnFirstRecord
+		o.SkipBadPatterns = options.SkipBadPatterns
+		o.NestedDeepObjects = options.NestedDeepObjects
+		o.Observer = options.Observer
+		o.PathAllowlist = options.PathAllowlist
+		o.RejectBodies = options.RejectBodies
+		o.IgnoredKeywords = options.IgnoredKeywords
+		o.IgnoreAdditional = options.IgnoreAdditional
+		o.WarnOptionalQuery = options.WarnOptionalQuery
 	}
 }
 
@@ -49,7 +89,16 @@ func WithRegexEngine(engine jsonschema.RegexpEngine) Option {
 	}
 }
 
-// WithFormatAssertions enables checks for 'format' assertions (such as date, date-time, uuid, etc)
+// WithSkipBadPatterns stops a 'pattern' that cannot be compiled by the regex engine (like a lookahead, which is not
+// supported by the RE2 engine used by default) from failing validation. The pattern is skipped (it matches any
+// value), and reported as a warning by ValidateHttpRequestWithWarnings.
+func WithSkipBadPatterns() Option {
+	return func(o *ValidationOptions) {
+		o.SkipBadPatterns = true
+	}
+}
+
+// WithFormatAssertions enables checks for 'format' assertions (such as date, date-time, uuid, and the OpenAPI 'byte' format)
 func WithFormatAssertions() Option {
 	return func(o *ValidationOptions) {
 		o.FormatAssertions = true
@@ -62,3 +111,177 @@ func WithContentAssertions() Option {
 		o.ContentAssertions = true
 	}
 }
+
+// WithMaxBodyBytes caps the number of bytes read from a request body. Bodies that are larger than the cap
+// fail validation without being read in full. A value of zero (the default) or less means there is no cap.
+func WithMaxBodyBytes(n int64) Option {
+	return func(o *ValidationOptions) {
+		o.MaxBodyBytes = n
+	}
+}
+
+// WithMaxDecodedBytes caps the size of a compressed request body (one with a 'Content-Encoding' of 'gzip' or
+// 'deflate') once it's decoded, so a small body that decompresses into a huge one (a zip bomb) fails validation
+// without being decoded in full. WithMaxBodyBytes caps the compressed body. A value of zero (the default) or less
+// means there is no cap.
+func WithMaxDecodedBytes(n int64) Option {
+	return func(o *ValidationOptions) {
+		o.MaxDecodedBytes = n
+	}
+}
+
+// WithAllowEmptyValue honors the 'allowEmptyValue' attribute of query parameters. When enabled, an empty value
+// (e.g. '?foo=') for a parameter that allows empty values, is accepted without being checked against the schema.
+func WithAllowEmptyValue() Option {
+	return func(o *ValidationOptions) {
+		o.AllowEmptyValue = true
+	}
+}
+
+// WithStrictQueryParams rejects query parameters that are not defined by the operation (or path item).
+func WithStrictQueryParams() Option {
+	return func(o *ValidationOptions) {
+		o.StrictQueryParams = true
+	}
+}
+
+// WithOptionalQueryWarnings reports a warning for each optional query parameter that is declared by the operation
+// (or path item) and not supplied by the request. Required query parameters that are missing are always errors.
+func WithOptionalQueryWarnings() Option {
+	return func(o *ValidationOptions) {
+		o.WarnOptionalQuery = true
+	}
+}
+
+// WithNestedDeepObjects decodes 'deepObject' query parameters that nest arrays and objects using brackets, like
+// 'filter[0][field]=name&filter[0][op]=eq' (common with JSON:API style filters), so they can be validated against
+// a schema that is an array of objects. Brackets that nest deeper than the schema fail validation.
+func WithNestedDeepObjects() Option {
+	return func(o *ValidationOptions) {
+		o.NestedDeepObjects = true
+	}
+}
+
+// WithBasePath sets a base path (e.g. '/api/v1') that is stripped from request paths before they are matched
+// against the paths in the specification. It is checked before any base paths defined by the 'servers' of the
+// specification, so it's useful when the service is mounted somewhere the specification does not know about.
+func WithBasePath(basePath string) Option {
+	return func(o *ValidationOptions) {
+		o.BasePath = basePath
+	}
+}
+
+// WithFormatValidator registers a validator for a custom 'format' (e.g. 'sku'), the validator returns an error
+// if the value is not valid. Format assertions are enabled, as the validator would not be checked otherwise.
+func WithFormatValidator(name string, validator func(v any) error) Option {
+	return func(o *ValidationOptions) {
+		formats := make(map[string]func(v any) error, len(o.Formats)+1)
+		for k, f := range o.Formats {
+			formats[k] = f
+		}
+		formats[name] = validator
+		o.Formats = formats
+		o.FormatAssertions = true
+	}
+}
+
+// WithMessageTranslator registers a translator for the messages of validation errors. Each error carries a stable
+// message key and the named arguments of its message, the translator renders them in the desired language.
+// If the translator returns an empty string, the default (English) message is kept.
+func WithMessageTranslator(translator func(key string, args map[string]any) string) Option {
+	return func(o *ValidationOptions) {
+		o.MessageTranslator = translator
+	}
+}
+
+// WithCollectAllErrors keeps validating a request when its path (or the operation for its method) cannot be found,
+// so everything that can still be checked is reported along with the routing error. The parameters declared by a
+// matching path item are validated, and the content type of the request is checked against the request bodies
+// declared in the specification. This is intended for diagnosing requests (e.g. in a test harness).
+func WithCollectAllErrors() Option {
+	return func(o *ValidationOptions) {
+		o.CollectAllErrors = true
+	}
+}
+
+// WithSchemaDraft sets the JSON Schema draft (e.g. jsonschema.Draft4) used for schemas that do not declare one
+// with '$schema'. By default, the latest draft (2020-12, as used by OpenAPI 3.1) is used.
+func WithSchemaDraft(draft *jsonschema.Draft) Option {
+	return func(o *ValidationOptions) {
+		o.SchemaDraft = draft
+	}
+}
+
+// WithCompilerFactory supplies the JSON Schema compilers used to compile schemas, instead of the default compiler,
+// for example to register custom vocabularies. A compiler is minted for every schema that is compiled, so a factory
+// is supplied rather than a compiler. The other compiler options (e.g. WithFormatAssertions) are applied on top.
+func WithCompilerFactory(factory func() *jsonschema.Compiler) Option {
+	return func(o *ValidationOptions) {
+		o.CompilerFactory = factory
+	}
+}
+
+// WithForwardedPrefix honors the 'X-Forwarded-Prefix' header set by a reverse proxy (or ingress) that mounts the
+// service under a prefix (e.g. '/service-a'). The prefix is treated as a base path of the request, so it's stripped
+// from the request path if the proxy did not strip it already, and the request is matched either way. Only enable
+// this when the header is set by a trusted proxy, as clients can send it too.
+func WithForwardedPrefix() Option {
+	return func(o *ValidationOptions) {
+		o.ForwardedPrefix = true
+	}
+}
+
+// WithPathAllowlist limits the paths of the specification that requests are matched against, requests to any
+// other path are reported as 'path not found', even if the path is in the specification (for example, when only
+// some paths are exposed through a gateway). Each entry is a path as it's written in the specification (e.g.
+// '/burgers/{burgerId}'), and can be a glob (see path.Match), so '/burgers/*' allows '/burgers/{burgerId}'. An entry
+// that ends with '/**' allows every path below it.
+func WithPathAllowlist(paths []string) Option {
+	return func(o *ValidationOptions) {
+		o.PathAllowlist = paths
+	}
+}
+
+// WithRejectUndeclaredBodies rejects a request that sends a body to an operation that does not declare a
+// 'requestBody' (like a GET with a JSON body). By default, the body is ignored.
+func WithRejectUndeclaredBodies() Option {
+	return func(o *ValidationOptions) {
+		o.RejectBodies = true
+	}
+}
+
+// WithObserver registers an Observer that is notified of the timing and outcome of each phase of validation
+// (routing, parameters, request body and response body). Without an observer, nothing is timed.
+func WithObserver(observer Observer) Option {
+	return func(o *ValidationOptions) {
+		o.Observer = observer
+	}
+}
+
+// WithStopOnFirstRecord stops validating a JSON sequence body (like 'application/x-ndjson') at the first record
+// that fails validation. By default, every record is validated and all errors are collected.
+func WithStopOnFirstRecord() Option {
+	return func(o *ValidationOptions) {
+		o.StopOnFirstRecord = true
+	}
+}
+
+// WithIgnoredKeywords removes the named keywords (e.g. 'maxLength') from schemas before they are compiled, so the
+// constraints they define are not checked. This applies to parameter, request body and response body schemas, but
+// not to the OpenAPI schema used by ValidateDocument. It is a transitional tool, intended for rolling validation out
+// incrementally (e.g. while migrating a service), and should be removed once the service meets the specification.
+func WithIgnoredKeywords(keywords []string) Option {
+	return func(o *ValidationOptions) {
+		o.IgnoredKeywords = keywords
+	}
+}
+
+// WithIgnoreAdditionalProperties relaxes 'additionalProperties: false' in the schemas of request and response bodies,
+// so properties that are not declared are allowed (for example, fields sent by newer clients). This deliberately
+// overrides the specification, by default 'additionalProperties' is respected. Schemas for 'additionalProperties'
+// (rather than false) are still checked, as are the schemas of parameters.
+func WithIgnoreAdditionalProperties(ignore bool) Option {
+	return func(o *ValidationOptions) {
+		o.IgnoreAdditional = ignore
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"net/http"
+	"time"
+)
+
+// The phases of validation that are reported to an Observer.
+const (
+	PhaseRouting      = "routing"
+	PhaseParameters   = "parameters"
+	PhaseRequestBody  = "requestBody"
+	PhaseResponseBody = "responseBody"
+)
+
+// PhaseEvent is the timing and outcome of a phase of validation.
+type PhaseEvent struct {
+	// Phase is the phase of validation, one of PhaseRouting, PhaseParameters, PhaseRequestBody or PhaseResponseBody.
+	Phase string
+
+	// Request is the request being validated (or the request a response is validated for).
+	Request *http.Request
+
+	// PathValue is the path template (e.g. '/users/{id}') the request was matched to, if it was matched.
+	PathValue string
+
+	// Duration is how long the phase took.
+	Duration time.Duration
+
+	// Valid is true if the phase found no errors.
+	Valid bool
+
+	// ErrorCount is the number of validation errors found by the phase.
+	ErrorCount int
+}
+
+// Observer is notified of each phase of validation, for example to export metrics. Phases can run concurrently
+// (the parameters and body of a request are validated in parallel), so an Observer must be safe for concurrent use,
+// and should return quickly as it's called while validating.
+type Observer interface {
+	ObservePhase(event PhaseEvent)
+}
@@ -0,0 +1,111 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package errors
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// ExampleInvalid is returned when an 'example' (or one of the 'examples') in the specification does not validate
+// against its own schema. The location is a JSON pointer to the example within the document.
+func ExampleInvalid(location, specPath string, example *yaml.Node, schemaErrors []*SchemaValidationFailure) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentExample,
+		Message:           fmt.Sprintf("Example '%s' does not match its schema", location),
+		MessageKey:        MessageKeyExampleInvalid,
+		MessageArgs:       map[string]any{"location": location},
+		Reason: fmt.Sprintf("The example at '%s' is not valid according to the schema it describes, "+
+			"the specification is incorrect", location),
+		SpecLine:               example.Line,
+		SpecCol:                example.Column,
+		SpecPath:               specPath,
+		SchemaValidationErrors: schemaErrors,
+		HowToFix:               HowToFixInvalidExample,
+	}
+}
+
+// DefaultInvalid is returned when a 'default' value in the specification does not validate against its own schema.
+// The location is a JSON pointer to the default value within the document.
+func DefaultInvalid(location, specPath string, value *yaml.Node, schemaErrors []*SchemaValidationFailure) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentDefault,
+		Message:           fmt.Sprintf("Default value '%s' does not match its schema", location),
+		MessageKey:        MessageKeyDefaultInvalid,
+		MessageArgs:       map[string]any{"location": location},
+		Reason: fmt.Sprintf("The default value at '%s' is not valid according to its schema, "+
+			"it would be invalid if it was applied", location),
+		SpecLine:               value.Line,
+		SpecCol:                value.Column,
+		SpecPath:               specPath,
+		SchemaValidationErrors: schemaErrors,
+		HowToFix:               HowToFixInvalidDefault,
+	}
+}
+
+// PatternNotCompiled is returned as a warning for a 'pattern' in the specification that could not be compiled by the
+// regex engine, and was skipped because of 'WithSkipBadPatterns'. The pattern matches any value.
+func PatternNotCompiled(pattern string, err error) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.Schema,
+		ValidationSubType: helpers.SchemaPattern,
+		Message:           fmt.Sprintf("Pattern '%s' cannot be compiled by the regex engine, and was skipped", pattern),
+		MessageKey:        MessageKeyPatternNotCompiled,
+		MessageArgs:       map[string]any{"pattern": pattern},
+		Reason:            fmt.Sprintf("The pattern cannot be compiled: %s", err.Error()),
+		SpecLine:          -1,
+		SpecCol:           -1,
+		HowToFix:          HowToFixPatternNotCompiled,
+	}
+}
+
+// SchemaNotFound is returned when a schema is looked up by name, and there is no schema with that name declared in
+// the 'components.schemas' of the specification.
+func SchemaNotFound(name string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.Schema,
+		ValidationSubType: "missing",
+		Message:           fmt.Sprintf("Schema '%s' not found", name),
+		MessageKey:        MessageKeySchemaNotFound,
+		MessageArgs:       map[string]any{"name": name},
+		Reason: fmt.Sprintf("There is no schema named '%s' "+
+			"declared in the 'components.schemas' of the specification", name),
+		SpecLine: -1,
+		SpecCol:  -1,
+		SpecPath: name,
+		HowToFix: HowToFixSchemaName,
+	}
+}
+
+// SchemaCompilationFailed is returned when a schema in the specification cannot be compiled, so nothing can be
+// validated against it. The specification is at fault, not the request or response. The origin is what the schema
+// describes (e.g. 'requestBody' or 'parameter'), and is used as the ValidationSubType. The message includes the
+// error of the compiler, and the rendered schema is the Context.
+func SchemaCompilationFailed(schema *base.Schema, origin string, renderedSchema []byte, err error) *ValidationError {
+	line, col := 1, 0
+	if schema != nil && schema.ParentProxy != nil {
+		if keyNode := schema.ParentProxy.GetSchemaKeyNode(); keyNode != nil {
+			line, col = keyNode.Line, keyNode.Column
+		}
+	}
+	return &ValidationError{
+		ValidationType:    helpers.SchemaCompilationError,
+		ValidationSubType: origin,
+		Message:           fmt.Sprintf("Schema cannot be compiled: %s", err.Error()),
+		MessageKey:        MessageKeySchemaCompilationFailed,
+		MessageArgs:       map[string]any{"origin": origin, "error": err.Error()},
+		Reason: fmt.Sprintf("The '%s' schema in the specification is not valid and cannot be compiled, "+
+			"the specification is at fault, not the request or response", origin),
+		SpecLine: line,
+		SpecCol:  col,
+		HowToFix: HowToFixSchemaNotCompiled,
+		Context:  string(renderedSchema),
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func TestExampleInvalid(t *testing.T) {
+	example := &yaml.Node{Kind: yaml.ScalarNode, Value: "big-mac", Line: 12, Column: 20}
+	failures := []*SchemaValidationFailure{{Reason: "got string, want integer"}}
+
+	err := ExampleInvalid("#/paths/~1burgers/get/parameters/0/example", "/burgers", example, failures)
+
+	require.Equal(t, helpers.DocumentValidation, err.ValidationType)
+	require.Equal(t, helpers.DocumentExample, err.ValidationSubType)
+	require.Equal(t, "Example '#/paths/~1burgers/get/parameters/0/example' does not match its schema", err.Message)
+	require.Equal(t, MessageKeyExampleInvalid, err.MessageKey)
+	require.Equal(t, 12, err.SpecLine)
+	require.Equal(t, 20, err.SpecCol)
+	require.Equal(t, "/burgers", err.SpecPath)
+	require.Equal(t, failures, err.SchemaValidationErrors)
+	require.Equal(t, HowToFixInvalidExample, err.HowToFix)
+}
+
+func TestDefaultInvalid(t *testing.T) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Value: "ten", Line: 8, Column: 22}
+	failures := []*SchemaValidationFailure{{Reason: "got string, want integer"}}
+
+	err := DefaultInvalid("#/components/schemas/Limit/default", "", value, failures)
+
+	require.Equal(t, helpers.DocumentValidation, err.ValidationType)
+	require.Equal(t, helpers.DocumentDefault, err.ValidationSubType)
+	require.Equal(t, "Default value '#/components/schemas/Limit/default' does not match its schema", err.Message)
+	require.Equal(t, MessageKeyDefaultInvalid, err.MessageKey)
+	require.Equal(t, 8, err.SpecLine)
+	require.Equal(t, 22, err.SpecCol)
+	require.Equal(t, failures, err.SchemaValidationErrors)
+	require.Equal(t, HowToFixInvalidDefault, err.HowToFix)
+}
+
+func TestPatternNotCompiled(t *testing.T) {
+	err := PatternNotCompiled("^(?=.*[0-9]).+$", fmt.Errorf("invalid or unsupported Perl syntax: `(?=`"))
+
+	require.Equal(t, helpers.Schema, err.ValidationType)
+	require.Equal(t, helpers.SchemaPattern, err.ValidationSubType)
+	require.Equal(t, "Pattern '^(?=.*[0-9]).+$' cannot be compiled by the regex engine, and was skipped", err.Message)
+	require.Equal(t, "The pattern cannot be compiled: invalid or unsupported Perl syntax: `(?=`", err.Reason)
+	require.Equal(t, MessageKeyPatternNotCompiled, err.MessageKey)
+	require.Equal(t, HowToFixPatternNotCompiled, err.HowToFix)
+}
+
+func TestSchemaNotFound(t *testing.T) {
+	err := SchemaNotFound("Burger")
+
+	require.Equal(t, helpers.Schema, err.ValidationType)
+	require.Equal(t, "missing", err.ValidationSubType)
+	require.Equal(t, "Schema 'Burger' not found", err.Message)
+	require.Equal(t, "There is no schema named 'Burger' declared in the 'components.schemas' of the specification",
+		err.Reason)
+	require.Equal(t, MessageKeySchemaNotFound, err.MessageKey)
+	require.Equal(t, "Burger", err.SpecPath)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixSchemaName, err.HowToFix)
+}
+
+func TestSchemaCompilationFailed(t *testing.T) {
+	err := SchemaCompilationFailed(nil, helpers.RequestBodyValidation, []byte(`{"type":"string"}`),
+		fmt.Errorf("invalid regex pattern"))
+
+	require.Equal(t, helpers.SchemaCompilationError, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationSubType)
+	require.Equal(t, "Schema cannot be compiled: invalid regex pattern", err.Message)
+	require.Equal(t, "The 'requestBody' schema in the specification is not valid and cannot be compiled, "+
+		"the specification is at fault, not the request or response", err.Reason)
+	require.Equal(t, MessageKeySchemaCompilationFailed, err.MessageKey)
+	require.Equal(t, 1, err.SpecLine)
+	require.Equal(t, 0, err.SpecCol)
+	require.Equal(t, `{"type":"string"}`, err.Context)
+	require.Equal(t, HowToFixSchemaNotCompiled, err.HowToFix)
+	require.True(t, err.IsSchemaCompilationError())
+}
@@ -0,0 +1,121 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package errors
+
+// Message keys are stable identifiers for the message of a ValidationError, they do not change when the
+// English message is reworded, so they can be used to look up a translated message. The values used to
+// format a message are held by ValidationError.MessageArgs.
+const (
+	MessageKeyIncorrectFormEncoding            = "incorrect_form_encoding"
+	MessageKeyIncorrectSpaceDelimiting         = "incorrect_space_delimiting"
+	MessageKeyIncorrectPipeDelimiting          = "incorrect_pipe_delimiting"
+	MessageKeyInvalidDeepObject                = "invalid_deep_object"
+	MessageKeyInvalidDeepObjectNesting         = "invalid_deep_object_nesting"
+	MessageKeyQueryParameterMissing            = "query_parameter_missing"
+	MessageKeyQueryParameterNotDefined         = "query_parameter_not_defined"
+	MessageKeyQueryParameterIgnored            = "query_parameter_ignored"
+	MessageKeyQueryParameterOmitted            = "query_parameter_omitted"
+	MessageKeyParameterDeprecated              = "parameter_deprecated"
+	MessageKeyQueryParameterEmpty              = "query_parameter_empty"
+	MessageKeyQueryParameterMultipleValues     = "query_parameter_multiple_values"
+	MessageKeyHeaderParameterMissing           = "header_parameter_missing"
+	MessageKeyCookieParameterMissing           = "cookie_parameter_missing"
+	MessageKeyParameterStyleNotAllowed         = "parameter_style_not_allowed"
+	MessageKeyParameterStyleUnsupported        = "parameter_style_unsupported"
+	MessageKeyPathParamNotInTemplate           = "path_param_not_in_template"
+	MessageKeyPathParamNotDeclared             = "path_param_not_declared"
+	MessageKeyPathParamNotRequired             = "path_param_not_required"
+	MessageKeyHeaderParameterInvalidStyle      = "header_parameter_invalid_style"
+	MessageKeyHeaderParameterCannotBeDecoded   = "header_parameter_cannot_be_decoded"
+	MessageKeyIncorrectHeaderParamEnum         = "incorrect_header_param_enum"
+	MessageKeyIncorrectQueryParamArrayBoolean  = "incorrect_query_param_array_boolean"
+	MessageKeyIncorrectParamArrayMaxNumItems   = "incorrect_param_array_max_num_items"
+	MessageKeyIncorrectParamArrayMinNumItems   = "incorrect_param_array_min_num_items"
+	MessageKeyIncorrectParamArrayUniqueItems   = "incorrect_param_array_unique_items"
+	MessageKeyIncorrectCookieParamArrayBoolean = "incorrect_cookie_param_array_boolean"
+	MessageKeyIncorrectQueryParamArrayNumber   = "incorrect_query_param_array_number"
+	MessageKeyIncorrectCookieParamArrayNumber  = "incorrect_cookie_param_array_number"
+	MessageKeyIncorrectParamEncodingJSON       = "incorrect_param_encoding_json"
+	MessageKeyIncorrectQueryParamBool          = "incorrect_query_param_bool"
+	MessageKeyInvalidQueryParamNumber          = "invalid_query_param_number"
+	MessageKeyIncorrectQueryParamEnum          = "incorrect_query_param_enum"
+	MessageKeyIncorrectQueryParamEnumArray     = "incorrect_query_param_enum_array"
+	MessageKeyIncorrectReservedValues          = "incorrect_reserved_values"
+	MessageKeyInvalidHeaderParamNumber         = "invalid_header_param_number"
+	MessageKeyInvalidCookieParamNumber         = "invalid_cookie_param_number"
+	MessageKeyIncorrectHeaderParamBool         = "incorrect_header_param_bool"
+	MessageKeyIncorrectCookieParamBool         = "incorrect_cookie_param_bool"
+	MessageKeyIncorrectCookieParamEnum         = "incorrect_cookie_param_enum"
+	MessageKeyIncorrectHeaderParamArrayBoolean = "incorrect_header_param_array_boolean"
+	MessageKeyIncorrectHeaderParamArrayNumber  = "incorrect_header_param_array_number"
+	MessageKeyIncorrectPathParamBool           = "incorrect_path_param_bool"
+	MessageKeyIncorrectPathParamEnum           = "incorrect_path_param_enum"
+	MessageKeyIncorrectPathParamNumber         = "incorrect_path_param_number"
+	MessageKeyIncorrectPathParamInteger        = "incorrect_path_param_integer"
+	MessageKeyIncorrectPathParamArrayNumber    = "incorrect_path_param_array_number"
+	MessageKeyIncorrectPathParamMatrixArray    = "incorrect_path_param_matrix_array"
+	MessageKeyIncorrectPathParamArrayBoolean   = "incorrect_path_param_array_boolean"
+	MessageKeyPathParameterMissing             = "path_parameter_missing"
+	MessageKeyRequestContentTypeNotFound       = "request_content_type_not_found"
+	MessageKeyRequestBodyMissing               = "request_body_missing"
+	MessageKeyRequestBodyTooLarge              = "request_body_too_large"
+	MessageKeyRequestBodyNotAccepted           = "request_body_not_accepted"
+	MessageKeyOperationNotFound                = "operation_not_found"
+	MessageKeyOperationIdNotFound              = "operation_id_not_found"
+	MessageKeyResponseContentTypeNotFound      = "response_content_type_not_found"
+	MessageKeyResponseCodeNotFound             = "response_code_not_found"
+	MessageKeyResponseCodeNotDefined           = "response_code_not_defined"
+	MessageKeyWebhookNotFound                  = "webhook_not_found"
+	MessageKeyWebhookOperationNotFound         = "webhook_operation_not_found"
+	MessageKeyCallbackNotFound                 = "callback_not_found"
+	MessageKeyContentTypeNotDeclared           = "content_type_not_declared"
+	MessageKeyPathNotFound                     = "path_not_found"
+	MessageKeyMethodNotAllowed                 = "method_not_allowed"
+	MessageKeyOperationDeprecated              = "operation_deprecated"
+	MessageKeyParameterSchemaInvalid           = "parameter_schema_invalid"
+	MessageKeyParameterCannotBeDecoded         = "parameter_cannot_be_decoded"
+	MessageKeySecuritySchemeMissing            = "security_scheme_missing"
+	MessageKeyAuthorizationHeaderMissing       = "authorization_header_missing"
+	MessageKeyAPIKeyHeaderMissing              = "api_key_header_missing"
+	MessageKeyAPIKeyQueryMissing               = "api_key_query_missing"
+	MessageKeyAPIKeyCookieMissing              = "api_key_cookie_missing"
+	MessageKeyRequestBodySchemaInvalid         = "request_body_schema_invalid"
+	MessageKeyRequestBodyEmpty                 = "request_body_empty"
+	MessageKeyRequestBodyCannotBeDecoded       = "request_body_cannot_be_decoded"
+	MessageKeyRequestBodyInvalidJSON           = "request_body_invalid_json"
+	MessageKeyRequestBodyEncodingInvalid       = "request_body_encoding_invalid"
+	MessageKeyResponseMissing                  = "response_missing"
+	MessageKeyResponseBodyUnreadable           = "response_body_unreadable"
+	MessageKeyResponseBodySchemaInvalid        = "response_body_schema_invalid"
+	MessageKeyResponseHeaderMissing            = "response_header_missing"
+	MessageKeyResponseSchemaRenderFailed       = "response_schema_render_failed"
+	MessageKeySchemaInvalid                    = "schema_invalid"
+	MessageKeyPatternNotCompiled               = "pattern_not_compiled"
+	MessageKeySchemaCompilationFailed          = "schema_compilation_failed"
+	MessageKeySchemaNotFound                   = "schema_not_found"
+	MessageKeyDocumentInvalid                  = "document_invalid"
+	MessageKeyDocumentNotSet                   = "document_not_set"
+	MessageKeyExampleInvalid                   = "example_invalid"
+	MessageKeyDefaultInvalid                   = "default_invalid"
+)
+
+// MessageTranslator renders the message identified by a message key, using the named arguments of the message.
+// Returning an empty string keeps the default (English) message.
+type MessageTranslator func(key string, args map[string]any) string
+
+// TranslateValidationErrors replaces the message of each validation error with the one rendered by the translator.
+// Errors without a message key, and errors the translator has no message for, keep their default message.
+func TranslateValidationErrors(validationErrors []*ValidationError, translate MessageTranslator) {
+	if translate == nil {
+		return
+	}
+	for _, validationError := range validationErrors {
+		if validationError == nil || validationError.MessageKey == "" {
+			continue
+		}
+		if translated := translate(validationError.MessageKey, validationError.MessageArgs); translated != "" {
+			validationError.Message = translated
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateValidationErrors(t *testing.T) {
+	validationErrors := []*ValidationError{
+		HeaderParameterMissing(createMockParameterWithSchema()),
+		{Message: "no key"},
+		{Message: "untranslated", MessageKey: MessageKeyDocumentNotSet},
+		nil,
+	}
+
+	TranslateValidationErrors(validationErrors, func(key string, args map[string]any) string {
+		if key == MessageKeyHeaderParameterMissing {
+			return fmt.Sprintf("Le paramètre d'en-tête '%s' est manquant", args["name"])
+		}
+		return ""
+	})
+
+	require.Equal(t, "Le paramètre d'en-tête 'testParam' est manquant", validationErrors[0].Message)
+	require.Equal(t, "no key", validationErrors[1].Message)
+	require.Equal(t, "untranslated", validationErrors[2].Message)
+}
+
+func TestTranslateValidationErrors_NoTranslator(t *testing.T) {
+	validationErrors := []*ValidationError{HeaderParameterMissing(createMockParameterWithSchema())}
+	TranslateValidationErrors(validationErrors, nil)
+	require.Equal(t, "Header parameter 'testParam' is missing", validationErrors[0].Message)
+}
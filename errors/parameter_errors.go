@@ -8,6 +8,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
 
 	"github.com/pb33f/libopenapi-validator/helpers"
 )
@@ -17,6 +18,8 @@ func IncorrectFormEncoding(param *v3.Parameter, qp *helpers.QueryParam, i int) *
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' is not exploded correctly", param.Name),
+		MessageKey:        MessageKeyIncorrectFormEncoding,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' has a default or 'form' encoding defined, "+
 			"however the value '%s' is encoded as an object or an array using commas. The contract defines "+
 			"the explode value to set to 'true'", param.Name, qp.Values[i]),
@@ -33,6 +36,8 @@ func IncorrectSpaceDelimiting(param *v3.Parameter, qp *helpers.QueryParam) *Vali
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' delimited incorrectly", param.Name),
+		MessageKey:        MessageKeyIncorrectSpaceDelimiting,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' has 'spaceDelimited' style defined, "+
 			"and explode is defined as false. There are multiple values (%d) supplied, instead of a single"+
 			" space delimited value", param.Name, len(qp.Values)),
@@ -49,6 +54,8 @@ func IncorrectPipeDelimiting(param *v3.Parameter, qp *helpers.QueryParam) *Valid
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' delimited incorrectly", param.Name),
+		MessageKey:        MessageKeyIncorrectPipeDelimiting,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' has 'pipeDelimited' style defined, "+
 			"and explode is defined as false. There are multiple values (%d) supplied, instead of a single"+
 			" space delimited value", param.Name, len(qp.Values)),
@@ -65,6 +72,8 @@ func InvalidDeepObject(param *v3.Parameter, qp *helpers.QueryParam) *ValidationE
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' is not a valid deepObject", param.Name),
+		MessageKey:        MessageKeyInvalidDeepObject,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' has the 'deepObject' style defined, "+
 			"There are multiple values (%d) supplied, instead of a single "+
 			"value", param.Name, len(qp.Values)),
@@ -76,11 +85,31 @@ func InvalidDeepObject(param *v3.Parameter, qp *helpers.QueryParam) *ValidationE
 	}
 }
 
+// InvalidDeepObjectNesting is returned when the brackets of a nested deepObject query parameter (decoded because of
+// 'WithNestedDeepObjects') do not fit the structure of the schema of the parameter.
+func InvalidDeepObjectNesting(param *v3.Parameter, nestingErr *helpers.DeepObjectNestingError) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' is not structured like its schema", param.Name),
+		MessageKey:        MessageKeyInvalidDeepObjectNesting,
+		MessageArgs:       map[string]any{"name": param.Name, "key": nestingErr.Key},
+		Reason: fmt.Sprintf("The query parameter '%s' has the 'deepObject' style defined, however the key '%s' "+
+			"does not fit the schema, %s", param.Name, nestingErr.Key, nestingErr.Reason),
+		SpecLine: param.GoLow().Style.ValueNode.Line,
+		SpecCol:  param.GoLow().Style.ValueNode.Column,
+		Context:  param,
+		HowToFix: fmt.Sprintf(HowToFixDeepObjectNesting, param.Name+"[0][property]=value"),
+	}
+}
+
 func QueryParameterMissing(param *v3.Parameter) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' is missing", param.Name),
+		MessageKey:        MessageKeyQueryParameterMissing,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' is defined as being required, "+
 			"however it's missing from the requests", param.Name),
 		SpecLine: param.GoLow().Required.KeyNode.Line,
@@ -89,11 +118,123 @@ func QueryParameterMissing(param *v3.Parameter) *ValidationError {
 	}
 }
 
+func QueryParameterNotDefined(name string, op *v3.Operation) *ValidationError {
+	line, col := operationParametersLocation(op)
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' is not defined", name),
+		MessageKey:        MessageKeyQueryParameterNotDefined,
+		MessageArgs:       map[string]any{"name": name},
+		Reason: fmt.Sprintf("The query parameter '%s' is not defined by the operation, "+
+			"and strict query parameter validation is enabled", name),
+		SpecLine: line,
+		SpecCol:  col,
+		HowToFix: HowToFixUndefinedQueryParam,
+	}
+}
+
+// QueryParameterIgnored is a warning for a query parameter that is not defined by the operation, and was ignored,
+// as strict query parameter validation is not enabled (otherwise it's reported by QueryParameterNotDefined).
+func QueryParameterIgnored(name string, op *v3.Operation) *ValidationError {
+	line, col := operationParametersLocation(op)
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' is not defined, and was ignored", name),
+		MessageKey:        MessageKeyQueryParameterIgnored,
+		MessageArgs:       map[string]any{"name": name},
+		Reason: fmt.Sprintf("The query parameter '%s' is not defined by the operation, "+
+			"so it was not validated", name),
+		SpecLine: line,
+		SpecCol:  col,
+		HowToFix: HowToFixUndefinedQueryParam,
+	}
+}
+
+// QueryParameterOmitted is a warning for an optional query parameter that is defined by the operation, and was not
+// supplied by the request (a required query parameter is reported by QueryParameterMissing).
+func QueryParameterOmitted(param *v3.Parameter) *ValidationError {
+	line, col := -1, -1
+	if low := param.GoLow(); low != nil && low.Name.KeyNode != nil {
+		line, col = low.Name.KeyNode.Line, low.Name.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' is optional, and was not supplied", param.Name),
+		MessageKey:        MessageKeyQueryParameterOmitted,
+		MessageArgs:       map[string]any{"name": param.Name},
+		Reason: fmt.Sprintf("The query parameter '%s' is defined by the operation, however it's not "+
+			"required and is missing from the request", param.Name),
+		SpecLine: line,
+		SpecCol:  col,
+		Context:  param,
+		HowToFix: HowToFixOmittedQueryParam,
+	}
+}
+
+// ParameterDeprecated is a warning for a parameter that is marked as 'deprecated' in the specification, and was
+// supplied by the request.
+func ParameterDeprecated(param *v3.Parameter) *ValidationError {
+	line, col := -1, -1
+	if low := param.GoLow(); low != nil && low.Deprecated.KeyNode != nil {
+		line, col = low.Deprecated.KeyNode.Line, low.Deprecated.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: param.In,
+		Message:           fmt.Sprintf("The %s parameter '%s' is deprecated", param.In, param.Name),
+		MessageKey:        MessageKeyParameterDeprecated,
+		MessageArgs:       map[string]any{"name": param.Name, "in": param.In},
+		Reason: fmt.Sprintf("The %s parameter '%s' was supplied, however it's marked as 'deprecated' in the "+
+			"specification", param.In, param.Name),
+		SpecLine: line,
+		SpecCol:  col,
+		Context:  param,
+		HowToFix: HowToFixDeprecatedParam,
+	}
+}
+
+func QueryParameterEmpty(param *v3.Parameter, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' has an empty value", param.Name),
+		MessageKey:        MessageKeyQueryParameterEmpty,
+		MessageArgs:       map[string]any{"name": param.Name},
+		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a %s, however the value is empty "+
+			"and the parameter does not allow empty values", param.Name, strings.Join(sch.Type, " or ")),
+		SpecLine: param.GoLow().Schema.KeyNode.Line,
+		SpecCol:  param.GoLow().Schema.KeyNode.Column,
+		Context:  sch,
+		HowToFix: HowToFixEmptyValue,
+	}
+}
+
+func QueryParameterMultipleValues(param *v3.Parameter, sch *base.Schema, values []string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' received multiple values but expects a single value", param.Name),
+		MessageKey:        MessageKeyQueryParameterMultipleValues,
+		MessageArgs:       map[string]any{"name": param.Name, "values": values},
+		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a %s, however it was supplied %d times "+
+			"with the values '%s'", param.Name, strings.Join(sch.Type, " or "), len(values), strings.Join(values, "', '")),
+		SpecLine: param.GoLow().Name.KeyNode.Line,
+		SpecCol:  param.GoLow().Name.KeyNode.Column,
+		Context:  sch,
+		HowToFix: HowToFixMultipleValues,
+	}
+}
+
 func HeaderParameterMissing(param *v3.Parameter) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header parameter '%s' is missing", param.Name),
+		MessageKey:        MessageKeyHeaderParameterMissing,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter '%s' is defined as being required, "+
 			"however it's missing from the requests", param.Name),
 		SpecLine: param.GoLow().Required.KeyNode.Line,
@@ -102,15 +243,166 @@ func HeaderParameterMissing(param *v3.Parameter) *ValidationError {
 	}
 }
 
+func CookieParameterMissing(param *v3.Parameter) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie parameter '%s' is missing", param.Name),
+		MessageKey:        MessageKeyCookieParameterMissing,
+		MessageArgs:       map[string]any{"name": param.Name},
+		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being required, "+
+			"however it's missing from the request", param.Name),
+		SpecLine: param.GoLow().Required.KeyNode.Line,
+		SpecCol:  param.GoLow().Required.KeyNode.Column,
+		HowToFix: HowToFixMissingValue,
+	}
+}
+
+func ParameterStyleNotAllowed(param *v3.Parameter, specPath string, allowedStyles []string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentParameterStyle,
+		Message: fmt.Sprintf("Parameter '%s' uses the '%s' style, which is not allowed for '%s' parameters",
+			param.Name, param.Style, param.In),
+		MessageKey:  MessageKeyParameterStyleNotAllowed,
+		MessageArgs: map[string]any{"name": param.Name, "style": param.Style, "in": param.In},
+		Reason: fmt.Sprintf("The parameter '%s' is defined in the '%s', which only allows the styles: '%s'. "+
+			"The specification is incorrect", param.Name, param.In, strings.Join(allowedStyles, "', '")),
+		SpecLine: param.GoLow().Style.KeyNode.Line,
+		SpecCol:  param.GoLow().Style.KeyNode.Column,
+		SpecPath: specPath,
+		HowToFix: fmt.Sprintf(HowToFixParameterStyle, strings.Join(allowedStyles, "', '")),
+	}
+}
+
+func ParameterStyleUnsupported(param *v3.Parameter, specPath string, allowedStyles []string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentParameterStyle,
+		Message:           fmt.Sprintf("Unsupported style '%s' for %s parameter '%s'", param.Style, param.In, param.Name),
+		MessageKey:        MessageKeyParameterStyleUnsupported,
+		MessageArgs:       map[string]any{"name": param.Name, "style": param.Style, "in": param.In},
+		Reason: fmt.Sprintf("The parameter '%s' uses the '%s' style, which is not a style defined by the "+
+			"OpenAPI specification. '%s' parameters allow the styles: '%s'. The specification is incorrect",
+			param.Name, param.Style, param.In, strings.Join(allowedStyles, "', '")),
+		SpecLine: param.GoLow().Style.KeyNode.Line,
+		SpecCol:  param.GoLow().Style.KeyNode.Column,
+		SpecPath: specPath,
+		HowToFix: fmt.Sprintf(HowToFixParameterStyle, strings.Join(allowedStyles, "', '")),
+	}
+}
+
+// PathParameterNotInTemplate is returned when a parameter is declared with 'in: path', but the path (the template) has
+// no placeholder for it, so it can never be supplied.
+func PathParameterNotInTemplate(param *v3.Parameter, specPath string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentPathParameter,
+		Message:           fmt.Sprintf("Path parameter '%s' is not part of the path '%s'", param.Name, specPath),
+		MessageKey:        MessageKeyPathParamNotInTemplate,
+		MessageArgs:       map[string]any{"name": param.Name, "path": specPath},
+		Reason: fmt.Sprintf("The path parameter '%s' is declared, however the path '%s' has no '{%s}' "+
+			"placeholder for it. The specification is incorrect", param.Name, specPath, param.Name),
+		SpecLine: param.GoLow().Name.KeyNode.Line,
+		SpecCol:  param.GoLow().Name.KeyNode.Column,
+		SpecPath: specPath,
+		HowToFix: fmt.Sprintf(HowToFixPathParamTemplate, param.Name),
+	}
+}
+
+// PathParameterNotDeclared is returned when the path (the template) has a placeholder that is not declared as a path
+// parameter, by the operation or by the path.
+func PathParameterNotDeclared(name, method, specPath string, op *v3.Operation) *ValidationError {
+	line, col := -1, -1
+	if low := op.GoLow(); low != nil && low.KeyNode != nil {
+		line, col = low.KeyNode.Line, low.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentPathParameter,
+		Message: fmt.Sprintf("Path parameter '%s' of '%s' is not declared by the %s operation",
+			name, specPath, strings.ToUpper(method)),
+		MessageKey:  MessageKeyPathParamNotDeclared,
+		MessageArgs: map[string]any{"name": name, "path": specPath, "method": strings.ToUpper(method)},
+		Reason: fmt.Sprintf("The path '%s' has a '{%s}' placeholder, however there is no parameter named '%s' "+
+			"with 'in: path' for the %s operation. The specification is incorrect",
+			specPath, name, name, strings.ToUpper(method)),
+		SpecLine: line,
+		SpecCol:  col,
+		SpecPath: specPath,
+		Context:  op,
+		HowToFix: fmt.Sprintf(HowToFixPathParamDeclare, name),
+	}
+}
+
+// PathParameterNotRequired is returned when a path parameter is not marked as 'required: true', the specification
+// requires it for every path parameter.
+func PathParameterNotRequired(param *v3.Parameter, specPath string) *ValidationError {
+	keyNode := param.GoLow().Name.KeyNode
+	if param.GoLow().Required.KeyNode != nil {
+		keyNode = param.GoLow().Required.KeyNode
+	}
+	return &ValidationError{
+		ValidationType:    helpers.DocumentValidation,
+		ValidationSubType: helpers.DocumentPathParameter,
+		Message:           fmt.Sprintf("Path parameter '%s' must be required", param.Name),
+		MessageKey:        MessageKeyPathParamNotRequired,
+		MessageArgs:       map[string]any{"name": param.Name},
+		Reason: fmt.Sprintf("The path parameter '%s' is not marked as 'required: true', the OpenAPI specification "+
+			"requires every path parameter to be required", param.Name),
+		SpecLine: keyNode.Line,
+		SpecCol:  keyNode.Column,
+		SpecPath: specPath,
+		HowToFix: HowToFixPathParamRequired,
+	}
+}
+
+// ParameterStyleUndefined is reported when a request is validated against a parameter that uses a style that is not
+// defined by the OpenAPI specification, the value of the parameter is not decoded, as there is no way to decode it.
+func ParameterStyleUndefined(param *v3.Parameter) *ValidationError {
+	allowedStyles := helpers.AllowedParameterStyles(param.In)
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: param.In,
+		Message:           fmt.Sprintf("Unsupported style '%s' for %s parameter '%s'", param.Style, param.In, param.Name),
+		MessageKey:        MessageKeyParameterStyleUnsupported,
+		MessageArgs:       map[string]any{"name": param.Name, "style": param.Style, "in": param.In},
+		Reason: fmt.Sprintf("The %s parameter '%s' is defined with a style of '%s', which is not a style defined "+
+			"by the OpenAPI specification. The specification is incorrect, so the parameter cannot be decoded",
+			param.In, param.Name, param.Style),
+		SpecLine: param.GoLow().Style.KeyNode.Line,
+		SpecCol:  param.GoLow().Style.KeyNode.Column,
+		HowToFix: fmt.Sprintf(HowToFixParameterStyle, strings.Join(allowedStyles, "', '")),
+	}
+}
+
+func HeaderParameterInvalidStyle(param *v3.Parameter) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationHeader,
+		Message:           fmt.Sprintf("Header parameter '%s' has an invalid style '%s'", param.Name, param.Style),
+		MessageKey:        MessageKeyHeaderParameterInvalidStyle,
+		MessageArgs:       map[string]any{"name": param.Name, "style": param.Style},
+		Reason: fmt.Sprintf("The header parameter '%s' is defined with a style of '%s', however "+
+			"header parameters can only use the '%s' style. The specification is incorrect, "+
+			"so the header cannot be decoded", param.Name, param.Style, helpers.SimpleStyle),
+		SpecLine: param.GoLow().Style.KeyNode.Line,
+		SpecCol:  param.GoLow().Style.KeyNode.Column,
+		HowToFix: HowToFixInvalidHeaderStyle,
+	}
+}
+
 func HeaderParameterCannotBeDecoded(param *v3.Parameter, val string) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header parameter '%s' cannot be decoded", param.Name),
+		MessageKey:        MessageKeyHeaderParameterCannotBeDecoded,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter '%s' cannot be "+
 			"extracted into an object, '%s' is malformed", param.Name, val),
 		SpecLine: param.GoLow().Schema.Value.Schema().Type.KeyNode.Line,
-		SpecCol:  param.GoLow().Schema.Value.Schema().Type.KeyNode.Line,
+		SpecCol:  param.GoLow().Schema.Value.Schema().Type.KeyNode.Column,
 		HowToFix: HowToFixInvalidEncoding,
 	}
 }
@@ -125,6 +417,8 @@ func IncorrectHeaderParamEnum(param *v3.Parameter, ef string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header parameter '%s' does not match allowed values", param.Name),
+		MessageKey:        MessageKeyIncorrectHeaderParamEnum,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter '%s' has pre-defined "+
 			"values set via an enum. The value '%s' is not one of those values.", param.Name, ef),
 		SpecLine: param.GoLow().Schema.Value.Schema().Enum.KeyNode.Line,
@@ -141,6 +435,8 @@ func IncorrectQueryParamArrayBoolean(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectQueryParamArrayBoolean,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid true/false value", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -155,6 +451,8 @@ func IncorrectParamArrayMaxNumItems(param *v3.Parameter, sch *base.Schema, expec
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' has too many items", param.Name),
+		MessageKey:        MessageKeyIncorrectParamArrayMaxNumItems,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' has a maximum item length of %d, "+
 			"however the request provided %d items", param.Name, expected, actual),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -169,6 +467,8 @@ func IncorrectParamArrayMinNumItems(param *v3.Parameter, sch *base.Schema, expec
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' does not have enough items", param.Name),
+		MessageKey:        MessageKeyIncorrectParamArrayMinNumItems,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' has a minimum items length of %d, "+
 			"however the request provided %d items", param.Name, expected, actual),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -183,6 +483,8 @@ func IncorrectParamArrayUniqueItems(param *v3.Parameter, sch *base.Schema, dupli
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' contains non-unique items", param.Name),
+		MessageKey:        MessageKeyIncorrectParamArrayUniqueItems,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason:            fmt.Sprintf("The query parameter (which is an array) '%s' contains the following duplicates: '%s'", param.Name, duplicates),
 		SpecLine:          sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
 		SpecCol:           sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
@@ -198,6 +500,8 @@ func IncorrectCookieParamArrayBoolean(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
 		Message:           fmt.Sprintf("Cookie array parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectCookieParamArrayBoolean,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The cookie parameter (which is an array) '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid true/false value", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -214,6 +518,8 @@ func IncorrectQueryParamArrayNumber(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyIncorrectQueryParamArrayNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -230,6 +536,8 @@ func IncorrectCookieParamArrayNumber(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
 		Message:           fmt.Sprintf("Cookie array parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyIncorrectCookieParamArrayNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The cookie parameter (which is an array) '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -244,6 +552,8 @@ func IncorrectParamEncodingJSON(param *v3.Parameter, ef string, sch *base.Schema
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' is not valid JSON", param.Name),
+		MessageKey:        MessageKeyIncorrectParamEncodingJSON,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a JSON object, "+
 			"however the value '%s' is not valid JSON", param.Name, ef),
 		SpecLine: param.GoLow().FindContent(helpers.JSONContentType).ValueNode.Line,
@@ -258,6 +568,8 @@ func IncorrectQueryParamBool(param *v3.Parameter, ef string, sch *base.Schema) *
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectQueryParamBool,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid boolean", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -272,6 +584,8 @@ func InvalidQueryParamNumber(param *v3.Parameter, ef string, sch *base.Schema) *
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyInvalidQueryParamNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -291,6 +605,8 @@ func IncorrectQueryParamEnum(param *v3.Parameter, ef string, sch *base.Schema) *
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' does not match allowed values", param.Name),
+		MessageKey:        MessageKeyIncorrectQueryParamEnum,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' has pre-defined "+
 			"values set via an enum. The value '%s' is not one of those values.", param.Name, ef),
 		SpecLine: param.GoLow().Schema.Value.Schema().Enum.KeyNode.Line,
@@ -312,10 +628,12 @@ func IncorrectQueryParamEnumArray(param *v3.Parameter, ef string, sch *base.Sche
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' does not match allowed values", param.Name),
+		MessageKey:        MessageKeyIncorrectQueryParamEnumArray,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query array parameter '%s' has pre-defined "+
 			"values set via an enum. The value '%s' is not one of those values.", param.Name, ef),
 		SpecLine: param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Line,
-		SpecCol:  param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Line,
+		SpecCol:  param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Column,
 		Context:  sch,
 		HowToFix: fmt.Sprintf(HowToFixParamInvalidEnum, ef, validEnums),
 	}
@@ -326,6 +644,8 @@ func IncorrectReservedValues(param *v3.Parameter, ef string, sch *base.Schema) *
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query parameter '%s' value contains reserved values", param.Name),
+		MessageKey:        MessageKeyIncorrectReservedValues,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The query parameter '%s' has 'allowReserved' set to false, "+
 			"however the value '%s' contains one of the following characters: :/?#[]@!$&'()*+,;=", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -340,6 +660,8 @@ func InvalidHeaderParamNumber(param *v3.Parameter, ef string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyInvalidHeaderParamNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -354,6 +676,8 @@ func InvalidCookieParamNumber(param *v3.Parameter, ef string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
 		Message:           fmt.Sprintf("Cookie parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyInvalidCookieParamNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -368,6 +692,8 @@ func IncorrectHeaderParamBool(param *v3.Parameter, ef string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectHeaderParamBool,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid boolean", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -382,6 +708,8 @@ func IncorrectCookieParamBool(param *v3.Parameter, ef string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
 		Message:           fmt.Sprintf("Cookie parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectCookieParamBool,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid boolean", param.Name, ef),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -401,6 +729,8 @@ func IncorrectCookieParamEnum(param *v3.Parameter, ef string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
 		Message:           fmt.Sprintf("Cookie parameter '%s' does not match allowed values", param.Name),
+		MessageKey:        MessageKeyIncorrectCookieParamEnum,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The cookie parameter '%s' has pre-defined "+
 			"values set via an enum. The value '%s' is not one of those values.", param.Name, ef),
 		SpecLine: param.GoLow().Schema.Value.Schema().Enum.KeyNode.Line,
@@ -417,6 +747,8 @@ func IncorrectHeaderParamArrayBoolean(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header array parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectHeaderParamArrayBoolean,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter (which is an array) '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid true/false value", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -433,6 +765,8 @@ func IncorrectHeaderParamArrayNumber(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header array parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyIncorrectHeaderParamArrayNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The header parameter (which is an array) '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -447,6 +781,8 @@ func IncorrectPathParamBool(param *v3.Parameter, item string, sch *base.Schema)
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamBool,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The path parameter '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid boolean", param.Name, item),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -466,6 +802,8 @@ func IncorrectPathParamEnum(param *v3.Parameter, ef string, sch *base.Schema) *V
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path parameter '%s' does not match allowed values", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamEnum,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The path parameter '%s' has pre-defined "+
 			"values set via an enum. The value '%s' is not one of those values.", param.Name, ef),
 		SpecLine: param.GoLow().Schema.Value.Schema().Enum.KeyNode.Line,
@@ -480,6 +818,8 @@ func IncorrectPathParamNumber(param *v3.Parameter, item string, sch *base.Schema
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The path parameter '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, item),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
@@ -489,6 +829,22 @@ func IncorrectPathParamNumber(param *v3.Parameter, item string, sch *base.Schema
 	}
 }
 
+func IncorrectPathParamInteger(param *v3.Parameter, item string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationPath,
+		Message:           fmt.Sprintf("Path parameter '%s' is not a valid integer", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamInteger,
+		MessageArgs:       map[string]any{"name": param.Name},
+		Reason: fmt.Sprintf("The path parameter '%s' is defined as being an integer, "+
+			"however the value '%s' is not a valid integer", param.Name, item),
+		SpecLine: param.GoLow().Schema.KeyNode.Line,
+		SpecCol:  param.GoLow().Schema.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidInteger, item),
+	}
+}
+
 func IncorrectPathParamArrayNumber(
 	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema,
 ) *ValidationError {
@@ -496,6 +852,8 @@ func IncorrectPathParamArrayNumber(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path array parameter '%s' is not a valid number", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamArrayNumber,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The path parameter (which is an array) '%s' is defined as being a number, "+
 			"however the value '%s' is not a valid number", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -505,6 +863,26 @@ func IncorrectPathParamArrayNumber(
 	}
 }
 
+func IncorrectPathParamMatrixArray(param *v3.Parameter, value string, sch *base.Schema) *ValidationError {
+	example := fmt.Sprintf(";%s=1,2", param.Name)
+	if param.IsExploded() {
+		example = fmt.Sprintf(";%s=1;%s=2", param.Name, param.Name)
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationPath,
+		Message:           fmt.Sprintf("Path array parameter '%s' is not matrix encoded", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamMatrixArray,
+		MessageArgs:       map[string]any{"name": param.Name},
+		Reason: fmt.Sprintf("The path parameter (which is an array) '%s' is defined as using the 'matrix' style, "+
+			"however the value '%s' is not a matrix encoded array", param.Name, value),
+		SpecLine: sch.GoLow().Type.KeyNode.Line,
+		SpecCol:  sch.GoLow().Type.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixMatrixArray, example),
+	}
+}
+
 func IncorrectPathParamArrayBoolean(
 	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema,
 ) *ValidationError {
@@ -512,6 +890,8 @@ func IncorrectPathParamArrayBoolean(
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path array parameter '%s' is not a valid boolean", param.Name),
+		MessageKey:        MessageKeyIncorrectPathParamArrayBoolean,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The path parameter (which is an array) '%s' is defined as being a boolean, "+
 			"however the value '%s' is not a valid boolean", param.Name, item),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
@@ -526,6 +906,8 @@ func PathParameterMissing(param *v3.Parameter) *ValidationError {
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path parameter '%s' is missing", param.Name),
+		MessageKey:        MessageKeyPathParameterMissing,
+		MessageArgs:       map[string]any{"name": param.Name},
 		Reason: fmt.Sprintf("The path parameter '%s' is defined as being required, "+
 			"however it's missing from the requests", param.Name),
 		SpecLine: param.GoLow().Required.KeyNode.Line,
@@ -533,3 +915,18 @@ func PathParameterMissing(param *v3.Parameter) *ValidationError {
 		HowToFix: HowToFixMissingValue,
 	}
 }
+
+// operationParametersLocation returns the line and column of the 'parameters' declaration of an operation,
+// falling back to the operation itself when no parameters are declared. -1 is returned when unknown.
+func operationParametersLocation(op *v3.Operation) (int, int) {
+	if op == nil || op.GoLow() == nil {
+		return -1, -1
+	}
+	low := op.GoLow()
+	for _, node := range []*yaml.Node{low.Parameters.KeyNode, low.KeyNode, low.RootNode} {
+		if node != nil {
+			return node.Line, node.Column
+		}
+	}
+	return -1, -1
+}
@@ -130,6 +130,70 @@ func TestQueryParameterMissing(t *testing.T) {
 	require.Equal(t, HowToFixMissingValue, err.HowToFix)
 }
 
+func TestHeaderParameterInvalidStyle(t *testing.T) {
+	param := createMockParameterWithSchema()
+	param.Style = "form"
+	param.GoLow().Style.KeyNode = &yaml.Node{Line: 12, Column: 5}
+
+	err := HeaderParameterInvalidStyle(param)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationHeader, err.ValidationSubType)
+	require.Equal(t, "Header parameter 'testParam' has an invalid style 'form'", err.Message)
+	require.Contains(t, err.Reason, "can only use the 'simple' style")
+	require.Equal(t, 12, err.SpecLine)
+	require.Equal(t, HowToFixInvalidHeaderStyle, err.HowToFix)
+}
+
+func TestParameterStyleUndefined(t *testing.T) {
+	param := createMockParameterWithSchema()
+	param.In = helpers.Query
+	param.Style = "weird"
+	param.GoLow().Style.KeyNode = &yaml.Node{Line: 12, Column: 5}
+
+	err := ParameterStyleUndefined(param)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Equal(t, "Unsupported style 'weird' for query parameter 'testParam'", err.Message)
+	require.Equal(t, MessageKeyParameterStyleUnsupported, err.MessageKey)
+	require.Contains(t, err.Reason, "cannot be decoded")
+	require.Equal(t, 12, err.SpecLine)
+	require.Equal(t, "Change the 'style' of the parameter in the specification to one of: "+
+		"'form', 'spaceDelimited', 'pipeDelimited', 'deepObject'", err.HowToFix)
+}
+
+func TestParameterDeprecated(t *testing.T) {
+	param := createMockParameterWithSchema()
+	param.In = helpers.Header
+	param.Deprecated = true
+	param.GoLow().Deprecated.KeyNode = &yaml.Node{Line: 9, Column: 3}
+
+	err := ParameterDeprecated(param)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationHeader, err.ValidationSubType)
+	require.Equal(t, "The header parameter 'testParam' is deprecated", err.Message)
+	require.Equal(t, MessageKeyParameterDeprecated, err.MessageKey)
+	require.Equal(t, 9, err.SpecLine)
+	require.Equal(t, HowToFixDeprecatedParam, err.HowToFix)
+}
+
+func TestQueryParameterIgnored(t *testing.T) {
+	err := QueryParameterIgnored("fries", nil)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Equal(t, "Query parameter 'fries' is not defined, and was ignored", err.Message)
+	require.Equal(t, MessageKeyQueryParameterIgnored, err.MessageKey)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixUndefinedQueryParam, err.HowToFix)
+}
+
 func TestHeaderParameterMissing(t *testing.T) {
 	param := createMockParameterWithSchema()
 
@@ -143,6 +207,23 @@ func TestHeaderParameterMissing(t *testing.T) {
 	require.Contains(t, err.Message, "Header parameter 'testParam' is missing")
 	require.Contains(t, err.Reason, "'testParam' is defined as being required")
 	require.Equal(t, HowToFixMissingValue, err.HowToFix)
+	require.Equal(t, MessageKeyHeaderParameterMissing, err.MessageKey)
+	require.Equal(t, map[string]any{"name": "testParam"}, err.MessageArgs)
+}
+
+func TestCookieParameterMissing(t *testing.T) {
+	param := createMockParameterWithSchema()
+
+	// Call the function
+	err := CookieParameterMissing(param)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationCookie, err.ValidationSubType)
+	require.Contains(t, err.Message, "Cookie parameter 'testParam' is missing")
+	require.Contains(t, err.Reason, "'testParam' is defined as being required")
+	require.Equal(t, HowToFixMissingValue, err.HowToFix)
 }
 
 func TestHeaderParameterCannotBeDecoded(t *testing.T) {
@@ -568,6 +649,54 @@ func TestInvalidQueryParamNumber(t *testing.T) {
 	require.Contains(t, err.HowToFix, "notNumber")
 }
 
+func TestQueryParameterNotDefined(t *testing.T) {
+	err := QueryParameterNotDefined("fries", nil)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Equal(t, "Query parameter 'fries' is not defined", err.Message)
+	require.Contains(t, err.Reason, "strict query parameter validation is enabled")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixUndefinedQueryParam, err.HowToFix)
+}
+
+func TestQueryParameterNotDefined_OperationLocation(t *testing.T) {
+	op := &lowv3.Operation{
+		KeyNode: &yaml.Node{Line: 4, Column: 5},
+		Parameters: low.NodeReference[[]low.ValueReference[*lowv3.Parameter]]{
+			KeyNode: &yaml.Node{Line: 5, Column: 7},
+		},
+	}
+	err := QueryParameterNotDefined("fries", v3.NewOperation(op))
+
+	require.NotNil(t, err)
+	require.Equal(t, 5, err.SpecLine)
+	require.Equal(t, 7, err.SpecCol)
+
+	// without parameters, the operation itself is used.
+	op.Parameters = low.NodeReference[[]low.ValueReference[*lowv3.Parameter]]{}
+	err = QueryParameterNotDefined("fries", v3.NewOperation(op))
+	require.Equal(t, 4, err.SpecLine)
+	require.Equal(t, 5, err.SpecCol)
+}
+
+func TestQueryParameterEmpty(t *testing.T) {
+	param := createMockParameter()
+	baseSchema := createMockLowBaseSchema()
+
+	// Call the function with an empty value
+	err := QueryParameterEmpty(param, base.NewSchema(baseSchema))
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Equal(t, "Query parameter 'testQueryParam' has an empty value", err.Message)
+	require.Contains(t, err.Reason, "does not allow empty values")
+	require.Equal(t, HowToFixEmptyValue, err.HowToFix)
+}
+
 func TestIncorrectQueryParamEnum(t *testing.T) {
 	enum := `enum: [fish, crab, lobster]`
 	var n yaml.Node
@@ -900,6 +1029,21 @@ func TestIncorrectPathParamNumber(t *testing.T) {
 	require.Contains(t, err.HowToFix, "milky")
 }
 
+func TestIncorrectPathParamInteger(t *testing.T) {
+	param := createMockParameter()
+	param.GoLow().Schema.KeyNode = &yaml.Node{}
+
+	err := IncorrectPathParamInteger(param, "1.5", base.NewSchema(createMockLowBaseSchema()))
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationPath, err.ValidationSubType)
+	require.Equal(t, "Path parameter 'testQueryParam' is not a valid integer", err.Message)
+	require.Contains(t, err.Reason, "the value '1.5' is not a valid integer")
+	require.Contains(t, err.HowToFix, "1.5")
+}
+
 func TestIncorrectPathParamArrayNumber(t *testing.T) {
 	items := `items:
   type: number`
@@ -7,6 +7,7 @@ const (
 	HowToFixReservedValues string = "parameter values need to URL Encoded to ensure reserved " +
 		"values are correctly encoded, for example: '%s'"
 	HowToFixParamInvalidNumber                      string = "Convert the value '%s' into a number"
+	HowToFixParamInvalidInteger                     string = "Convert the value '%s' into an integer (a whole number)"
 	HowToFixParamInvalidString                      string = "Convert the value '%s' into a string (cannot start with a number, or be a floating point)"
 	HowToFixParamInvalidBoolean                     string = "Convert the value '%s' into a true/false value"
 	HowToFixParamInvalidEnum                        string = "Instead of '%s', use one of the allowed values: '%s'"
@@ -18,15 +19,43 @@ const (
 		"they should be separated by pipes '|'. For example: '%s'"
 	HowToFixParamInvalidDeepObjectMultipleValues string = "There can only be a single value per property name, " +
 		"deepObject parameters should contain the property key in square brackets next to the parameter name. For example: '%s'"
-	HowToFixInvalidJSON         string = "The JSON submitted is invalid, please check the syntax"
-	HowToFixDecodingError              = "The object can't be decoded, so make sure it's being encoded correctly according to the spec."
-	HowToFixInvalidContentType         = "The content type is invalid, Use one of the %d supported types for this operation: %s"
-	HowToFixInvalidResponseCode        = "The service is responding with a code that is not defined in the spec, fix the service or add the code to the specification"
-	HowToFixInvalidEncoding            = "Ensure the correct encoding has been used on the object"
-	HowToFixMissingValue               = "Ensure the value has been set"
-	HowToFixPath                       = "Check the path is correct, and check that the correct HTTP method has been used (e.g. GET, POST, PUT, DELETE)"
-	HowToFixPathMethod                 = "Add the missing operation to the contract for the path"
-	HowToFixInvalidMaxItems            = "Reduce the number of items in the array to %d or less"
-	HowToFixInvalidMinItems            = "Increase the number of items in the array to %d or more"
-	HowToFixMissingHeader              = "Make sure the service responding sets the required headers with this response code"
+	HowToFixInvalidJSON           string = "The JSON submitted is invalid, please check the syntax"
+	HowToFixDecodingError                = "The object can't be decoded, so make sure it's being encoded correctly according to the spec."
+	HowToFixInvalidContentType           = "The content type is invalid, Use one of the %d supported types for this operation: %s"
+	HowToFixInvalidResponseCode          = "The service is responding with a code that is not defined in the spec, fix the service or add the code to the specification"
+	HowToFixInvalidEncoding              = "Ensure the correct encoding has been used on the object"
+	HowToFixMissingValue                 = "Ensure the value has been set"
+	HowToFixUndefinedQueryParam          = "Remove the query parameter from the request, or define it in the specification"
+	HowToFixOmittedQueryParam            = "Supply the query parameter if it's needed, it's optional so the request is still valid without it"
+	HowToFixDeprecatedParam              = "Stop sending the parameter, it's deprecated and may be removed from the specification"
+	HowToFixDeprecatedOperation          = "Move away from the operation, it's deprecated and may be removed from the specification"
+	HowToFixParameterStyle               = "Change the 'style' of the parameter in the specification to one of: '%s'"
+	HowToFixPathParamTemplate            = "Add a '{%s}' placeholder to the path, or remove the path parameter from the specification"
+	HowToFixPathParamDeclare             = "Declare a parameter named '%s' with 'in: path' for the operation, or for the path"
+	HowToFixPathParamRequired            = "Mark the path parameter as 'required: true' in the specification"
+	HowToFixInvalidExample               = "Change the example in the specification so it matches its schema, or correct the schema"
+	HowToFixPatternNotCompiled           = "Use a regex engine that supports the pattern (see 'WithRegexEngine'), or change the pattern so it can be compiled"
+	HowToFixSchemaNotCompiled            = "Correct the schema in the specification, it cannot be compiled, so nothing can be validated against it"
+	HowToFixMatrixArray                  = "Encode the array using the 'matrix' style, for example: '%s'"
+	HowToFixDeepObjectNesting            = "Nest the brackets of the query parameter to match the schema, using numbers for the indexes of arrays. For example: '%s'"
+	HowToFixContentEncoding              = "Ensure the request body is compressed with the encoding declared by the 'Content-Encoding' header"
+	HowToFixInvalidDefault               = "Change the default value in the specification so it matches its schema, or correct the schema"
+	HowToFixInvalidHeaderStyle           = "Change the 'style' of the header parameter in the specification to 'simple', or remove it"
+	HowToFixEmptyValue                   = "Set a value for the parameter, or set 'allowEmptyValue' to true on the parameter"
+	HowToFixMultipleValues               = "Send the parameter once, or define it as an array in the specification"
+	HowToFixRequestBodyTooLarge          = "Reduce the size of the request body to %d bytes or less"
+	HowToFixMissingRequestBody           = "Ensure a request body is sent with the request, it is required by the operation"
+	HowToFixUndeclaredRequestBody        = "Send the request without a body, or declare a 'requestBody' for the operation in the specification"
+	HowToFixPath                         = "Check the path is correct, and check that the correct HTTP method has been used (e.g. GET, POST, PUT, DELETE)"
+	HowToFixPathMethod                   = "Add the missing operation to the contract for the path"
+	HowToFixMethodNotAllowed             = "Use one of the allowed methods (%s), or add the missing operation to the contract for the path"
+	HowToFixOperationId                  = "Check the operationId is correct, and that it has been defined on an operation in the contract"
+	HowToFixWebhook                      = "Check the webhook name is correct, and that it has been declared in the 'webhooks' of the contract"
+	HowToFixSchemaName                   = "Check the schema name is correct, and that it has been declared in the 'components.schemas' of the contract"
+	HowToFixCallback                     = "Check the operationId, callback name and expression are correct, and that the callback has been declared on the operation in the contract"
+	HowToFixUndeclaredContentType        = "Send the request with a content type that is accepted by a request body in the contract"
+	HowToFixWebhookMethod                = "Add the missing operation to the webhook in the contract, or check the correct HTTP method has been used"
+	HowToFixInvalidMaxItems              = "Reduce the number of items in the array to %d or less"
+	HowToFixInvalidMinItems              = "Increase the number of items in the array to %d or more"
+	HowToFixMissingHeader                = "Make sure the service responding sets the required headers with this response code"
 )
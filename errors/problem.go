@@ -0,0 +1,136 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+const (
+	// ProblemTypeValidationFailed is the problem type used when a request or response failed validation.
+	ProblemTypeValidationFailed = "https://pb33f.io/libopenapi-validator/problems/validation-failed"
+
+	// ProblemTypeBlank is the problem type used when the HTTP status code says everything there is to say
+	// (for example, a path that does not exist). As defined by RFC 7807.
+	ProblemTypeBlank = "about:blank"
+)
+
+// Problem is an RFC 7807 'application/problem+json' document, that aggregates validation errors into a single
+// payload that can be returned to an API client.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type.
+	Type string `json:"type" yaml:"type"`
+
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title" yaml:"title"`
+
+	// Status is the HTTP status code that best describes the problem.
+	Status int `json:"status" yaml:"status"`
+
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+
+	// Instance is a URI reference that identifies the specific occurrence of the problem (the request path).
+	Instance string `json:"instance,omitempty" yaml:"instance,omitempty"`
+
+	// Errors contains every individual validation failure that makes up the problem.
+	Errors []*ProblemError `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// ProblemError is a single validation failure, contained within a Problem.
+type ProblemError struct {
+	// Pointer is the JSON pointer (RFC 6901) of the failure within the submitted object (e.g. '/pets/0/name'), for
+	// schema violations only. It's empty if the location is not known.
+	Pointer string `json:"pointer,omitempty" yaml:"pointer,omitempty"`
+
+	// Reason is a human-readable message describing the reason for the failure.
+	Reason string `json:"reason" yaml:"reason"`
+
+	// Type is the type of validation that failed (e.g. 'parameter', 'requestBody')
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// SubType is the subtype of validation that failed (e.g. 'query', 'schema')
+	SubType string `json:"subType,omitempty" yaml:"subType,omitempty"`
+}
+
+// ProblemFromErrors aggregates validation errors into an RFC 7807 Problem. A missing path is reported as a
+// 404 and a missing operation (method) as a 405, everything else is treated as a bad request (400).
+// Nil errors are ignored, if there are no errors, nil is returned.
+func ProblemFromErrors(validationErrors []*ValidationError) *Problem {
+	var found []*ValidationError
+	for _, ve := range validationErrors {
+		if ve != nil {
+			found = append(found, ve)
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	problem := &Problem{
+		Type:   ProblemTypeValidationFailed,
+		Title:  "Validation failed",
+		Status: http.StatusBadRequest,
+	}
+	for _, ve := range found {
+		if problem.Instance == "" {
+			problem.Instance = ve.RequestPath
+		}
+		switch {
+		case ve.IsPathMissingError():
+			problem.Status = http.StatusNotFound
+		case ve.IsOperationMissingError() && problem.Status != http.StatusNotFound:
+			problem.Status = http.StatusMethodNotAllowed
+		}
+		if len(ve.SchemaValidationErrors) == 0 {
+			problem.Errors = append(problem.Errors, &ProblemError{
+				Reason:  ve.Reason,
+				Type:    ve.ValidationType,
+				SubType: ve.ValidationSubType,
+			})
+			continue
+		}
+		for _, sve := range ve.SchemaValidationErrors {
+			if sve == nil {
+				continue
+			}
+			problem.Errors = append(problem.Errors, &ProblemError{
+				Pointer: instancePointer(sve),
+				Reason:  sve.Reason,
+				Type:    ve.ValidationType,
+				SubType: ve.ValidationSubType,
+			})
+		}
+	}
+	if problem.Status != http.StatusBadRequest {
+		// routing problems are fully described by the status code.
+		problem.Type = ProblemTypeBlank
+		problem.Title = http.StatusText(problem.Status)
+		problem.Detail = found[0].Message
+		return problem
+	}
+	if len(found) == 1 {
+		problem.Detail = found[0].Message
+	} else {
+		problem.Detail = fmt.Sprintf("%d validation errors were found", len(found))
+	}
+	return problem
+}
+
+// instancePointer renders the location of a schema violation within the submitted object as a JSON pointer, from
+// the field path (or the instance location of the original error). An empty string is returned if it's not known.
+func instancePointer(sve *SchemaValidationFailure) string {
+	segments := helpers.JSONPathSegments(sve.FieldPath)
+	if len(segments) == 0 && sve.OriginalError != nil {
+		segments = sve.OriginalError.InstanceLocation
+	}
+	var pointer strings.Builder
+	for _, segment := range segments {
+		pointer.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1"))
+	}
+	return pointer.String()
+}
@@ -0,0 +1,121 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func TestProblemFromErrors_Empty(t *testing.T) {
+	require.Nil(t, ProblemFromErrors(nil))
+}
+
+func TestProblemFromErrors_SchemaFailures(t *testing.T) {
+	validationErrors := []*ValidationError{
+		{
+			ValidationType:    helpers.RequestBodyValidation,
+			ValidationSubType: helpers.Schema,
+			Message:           "POST request body for '/burgers' failed to validate schema",
+			Reason:            "The request body is defined as an object",
+			RequestPath:       "/burgers",
+			SchemaValidationErrors: []*SchemaValidationFailure{
+				{Reason: "missing property 'name'", Location: "/required", FieldPath: "$"},
+				{Reason: "got string, want integer", Location: "/properties/patties/type", FieldPath: "$.toppings[1]['a/b']"},
+				{Reason: "value is not valid", Location: "unavailable"},
+			},
+		},
+		{
+			ValidationType:    helpers.ParameterValidation,
+			ValidationSubType: helpers.ParameterValidationHeader,
+			Message:           "Header parameter 'bash' is missing",
+			Reason:            "The header parameter 'bash' is defined as being required",
+			RequestPath:       "/burgers",
+		},
+	}
+
+	problem := ProblemFromErrors(validationErrors)
+	require.NotNil(t, problem)
+	require.Equal(t, ProblemTypeValidationFailed, problem.Type)
+	require.Equal(t, http.StatusBadRequest, problem.Status)
+	require.Equal(t, "Validation failed", problem.Title)
+	require.Equal(t, "2 validation errors were found", problem.Detail)
+	require.Equal(t, "/burgers", problem.Instance)
+	require.Len(t, problem.Errors, 4)
+
+	// the pointer is the location within the submitted object, not within the schema.
+	require.Equal(t, "", problem.Errors[0].Pointer)
+	require.Equal(t, "missing property 'name'", problem.Errors[0].Reason)
+	require.Equal(t, "/toppings/1/a~1b", problem.Errors[1].Pointer)
+	require.Equal(t, "", problem.Errors[2].Pointer)
+	require.Equal(t, "", problem.Errors[3].Pointer)
+	require.Equal(t, helpers.ParameterValidationHeader, problem.Errors[3].SubType)
+
+	rendered, err := json.Marshal(problem)
+	require.NoError(t, err)
+	require.Contains(t, string(rendered), `"status":400`)
+}
+
+func TestProblemFromErrors_NilErrors(t *testing.T) {
+	problem := ProblemFromErrors([]*ValidationError{
+		nil,
+		{
+			ValidationType:    helpers.ParameterValidationPath,
+			ValidationSubType: "missing",
+			Message:           "GET Path '/nope' not found",
+			RequestPath:       "/nope",
+		},
+	})
+	require.Equal(t, http.StatusNotFound, problem.Status)
+	require.Equal(t, "GET Path '/nope' not found", problem.Detail)
+
+	// only the errors that are not nil are counted.
+	problem = ProblemFromErrors([]*ValidationError{
+		nil,
+		{
+			ValidationType:    helpers.ParameterValidation,
+			ValidationSubType: helpers.ParameterValidationHeader,
+			Message:           "Header parameter 'bash' is missing",
+			Reason:            "The header parameter 'bash' is defined as being required",
+		},
+	})
+	require.Equal(t, http.StatusBadRequest, problem.Status)
+	require.Equal(t, "Header parameter 'bash' is missing", problem.Detail)
+	require.Len(t, problem.Errors, 1)
+
+	require.Nil(t, ProblemFromErrors([]*ValidationError{nil, nil}))
+}
+
+func TestProblemFromErrors_PathNotFound(t *testing.T) {
+	problem := ProblemFromErrors([]*ValidationError{
+		{
+			ValidationType:    helpers.ParameterValidationPath,
+			ValidationSubType: "missing",
+			Message:           "GET Path '/nope' not found",
+			RequestPath:       "/nope",
+		},
+	})
+	require.Equal(t, http.StatusNotFound, problem.Status)
+	require.Equal(t, ProblemTypeBlank, problem.Type)
+	require.Equal(t, "Not Found", problem.Title)
+	require.Equal(t, "GET Path '/nope' not found", problem.Detail)
+}
+
+func TestProblemFromErrors_OperationNotFound(t *testing.T) {
+	problem := ProblemFromErrors([]*ValidationError{
+		{
+			ValidationType:    helpers.ParameterValidationPath,
+			ValidationSubType: helpers.RequestMissingOperation,
+			Message:           "PATCH Path '/burgers' not found",
+			RequestPath:       "/burgers",
+		},
+	})
+	require.Equal(t, http.StatusMethodNotAllowed, problem.Status)
+	require.Equal(t, "Method Not Allowed", problem.Title)
+}
@@ -4,6 +4,8 @@
 package errors
 
 import (
+	"encoding/json"
+	stdError "errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -16,7 +18,7 @@ import (
 )
 
 func RequestContentTypeNotFound(op *v3.Operation, request *http.Request, specPath string) *ValidationError {
-	ct := request.Header.Get(helpers.ContentTypeHeader)
+	ct, _, _ := helpers.ExtractContentType(request.Header.Get(helpers.ContentTypeHeader))
 	var ctypes []string
 	for pair := orderedmap.First(op.RequestBody.Content); pair != nil; pair = pair.Next() {
 		ctypes = append(ctypes, pair.Key())
@@ -24,8 +26,9 @@ func RequestContentTypeNotFound(op *v3.Operation, request *http.Request, specPat
 	return &ValidationError{
 		ValidationType:    helpers.RequestBodyValidation,
 		ValidationSubType: helpers.RequestBodyContentType,
-		Message: fmt.Sprintf("%s operation request content type '%s' does not exist",
-			request.Method, ct),
+		Message:           fmt.Sprintf("Content type '%s' is not supported", ct),
+		MessageKey:        MessageKeyRequestContentTypeNotFound,
+		MessageArgs:       map[string]any{"contentType": ct},
 		Reason: fmt.Sprintf("The content type '%s' of the %s request submitted has not "+
 			"been defined, it's an unknown type", ct, request.Method),
 		SpecLine:      op.RequestBody.GoLow().Content.KeyNode.Line,
@@ -38,12 +41,158 @@ func RequestContentTypeNotFound(op *v3.Operation, request *http.Request, specPat
 	}
 }
 
+func ContentTypeNotDeclared(request *http.Request, contentType string) *ValidationError {
+	ct, _, _ := helpers.ExtractContentType(contentType)
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyContentType,
+		Message:           fmt.Sprintf("Content type '%s' is not supported by any operation", ct),
+		MessageKey:        MessageKeyContentTypeNotDeclared,
+		MessageArgs:       map[string]any{"contentType": ct},
+		Reason: fmt.Sprintf("The content type '%s' of the %s request submitted is not accepted "+
+			"by the request body of any operation in the specification", ct, request.Method),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixUndeclaredContentType,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+func RequestBodyCannotBeDecoded(request *http.Request, contentType, specPath string, err error) *ValidationError {
+	ct, _, _ := helpers.ExtractContentType(contentType)
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.Schema,
+		Message: fmt.Sprintf("%s request body for '%s' cannot be decoded as '%s'",
+			request.Method, request.URL.Path, ct),
+		MessageKey:    MessageKeyRequestBodyCannotBeDecoded,
+		MessageArgs:   map[string]any{"method": request.Method, "path": request.URL.Path, "contentType": ct},
+		Reason:        fmt.Sprintf("The request body cannot be decoded: %s", err.Error()),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixDecodingError,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+// RequestBodyInvalidJSON is returned when a JSON request body cannot be parsed (for example, it was truncated), so
+// it's never validated against its schema. The byte offset of the syntax error is included, when it's known.
+func RequestBodyInvalidJSON(request *http.Request, err error) *ValidationError {
+	offset := int64(-1)
+	var syntaxErr *json.SyntaxError
+	if stdError.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyInvalidJSON,
+		Message: fmt.Sprintf("%s request body is not valid JSON for '%s'",
+			request.Method, request.URL.Path),
+		MessageKey:  MessageKeyRequestBodyInvalidJSON,
+		MessageArgs: map[string]any{"method": request.Method, "path": request.URL.Path, "offset": offset},
+		Reason: fmt.Sprintf("The request body cannot be parsed as JSON, the syntax error is at byte offset %d: %s",
+			offset, err.Error()),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixInvalidJSON,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+func RequestBodyEncodingInvalid(request *http.Request, encoding, specPath string, err error) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyEncoding,
+		Message: fmt.Sprintf("%s request body for '%s' failed to decode content encoding '%s'",
+			request.Method, request.URL.Path, encoding),
+		MessageKey:    MessageKeyRequestBodyEncodingInvalid,
+		MessageArgs:   map[string]any{"method": request.Method, "path": request.URL.Path, "encoding": encoding},
+		Reason:        fmt.Sprintf("The request body failed to decode content encoding '%s': %s", encoding, err.Error()),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixContentEncoding,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+func RequestBodyMissing(op *v3.Operation, request *http.Request, specPath string) *ValidationError {
+	line, col := -1, -1
+	if low := op.RequestBody.GoLow(); low != nil && low.Required.KeyNode != nil {
+		line, col = low.Required.KeyNode.Line, low.Required.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyMissing,
+		Message:           "Request body is required",
+		MessageKey:        MessageKeyRequestBodyMissing,
+		Reason: fmt.Sprintf("The %s request body is empty, however the request body is defined as "+
+			"being required", request.Method),
+		SpecLine:      line,
+		SpecCol:       col,
+		Context:       op,
+		HowToFix:      HowToFixMissingRequestBody,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+func RequestBodyNotAccepted(op *v3.Operation, request *http.Request, specPath string) *ValidationError {
+	line, col := -1, -1
+	if low := op.GoLow(); low != nil && low.KeyNode != nil {
+		line, col = low.KeyNode.Line, low.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyNotAccepted,
+		Message:           fmt.Sprintf("%s operation does not accept a request body", request.Method),
+		MessageKey:        MessageKeyRequestBodyNotAccepted,
+		MessageArgs:       map[string]any{"method": request.Method},
+		Reason: fmt.Sprintf("The %s request contains a body, however the operation does not declare a "+
+			"'requestBody' in the specification", request.Method),
+		SpecLine:      line,
+		SpecCol:       col,
+		Context:       op,
+		HowToFix:      HowToFixUndeclaredRequestBody,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+func RequestBodyTooLarge(request *http.Request, specPath string, maxBytes int64) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyTooLarge,
+		Message: fmt.Sprintf("%s request body exceeds maximum size of %d bytes",
+			request.Method, maxBytes),
+		MessageKey:  MessageKeyRequestBodyTooLarge,
+		MessageArgs: map[string]any{"method": request.Method, "maxBytes": maxBytes},
+		Reason: fmt.Sprintf("The %s request body is larger than the configured maximum of %d bytes, "+
+			"so it was not validated", request.Method, maxBytes),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      fmt.Sprintf(HowToFixRequestBodyTooLarge, maxBytes),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
 func OperationNotFound(pathItem *v3.PathItem, request *http.Request, method string, specPath string) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.RequestValidation,
 		ValidationSubType: helpers.RequestMissingOperation,
 		Message: fmt.Sprintf("%s operation request content type '%s' does not exist",
 			request.Method, method),
+		MessageKey:    MessageKeyOperationNotFound,
+		MessageArgs:   map[string]any{"method": request.Method, "operation": method},
 		Reason:        fmt.Sprintf("The path was found, but there was no '%s' method found in the spec", request.Method),
 		SpecLine:      pathItem.GoLow().KeyNode.Line,
 		SpecCol:       pathItem.GoLow().KeyNode.Column,
@@ -54,3 +203,121 @@ func OperationNotFound(pathItem *v3.PathItem, request *http.Request, method stri
 		SpecPath:      specPath,
 	}
 }
+
+// MethodNotAllowed is returned when the path of a request was found, but the path does not declare an operation for
+// the method of the request. The methods that are allowed are part of the message arguments ('allowed'), so a
+// '405 Method Not Allowed' response (and its 'Allow' header) can be built from the error.
+func MethodNotAllowed(pathItem *v3.PathItem, request *http.Request, specPath string) *ValidationError {
+	allowed := helpers.AllowedMethods(pathItem)
+	line, col := -1, -1
+	if low := pathItem.GoLow(); low != nil && low.KeyNode != nil {
+		line, col = low.KeyNode.Line, low.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidationPath,
+		ValidationSubType: helpers.RequestMissingOperation,
+		Message:           fmt.Sprintf("Method %s not allowed on path '%s'", request.Method, request.URL.Path),
+		MessageKey:        MessageKeyMethodNotAllowed,
+		MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path, "allowed": allowed},
+		Reason: fmt.Sprintf("The path '%s' was found, however there is no '%s' operation declared for it, "+
+			"the allowed methods are: %s", specPath, strings.ToLower(request.Method), strings.Join(allowed, ", ")),
+		SpecLine: line,
+		SpecCol:  col,
+		Context:  pathItem,
+		HowToFix: fmt.Sprintf(HowToFixMethodNotAllowed, strings.Join(allowed, ", ")),
+	}
+}
+
+// OperationDeprecated is a warning for a request to an operation that is marked as 'deprecated' in the specification.
+func OperationDeprecated(op *v3.Operation, request *http.Request, specPath string) *ValidationError {
+	line, col := -1, -1
+	if low := op.GoLow(); low != nil && low.Deprecated.KeyNode != nil {
+		line, col = low.Deprecated.KeyNode.Line, low.Deprecated.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestDeprecated,
+		Message:           fmt.Sprintf("%s operation for '%s' is deprecated", request.Method, specPath),
+		MessageKey:        MessageKeyOperationDeprecated,
+		MessageArgs:       map[string]any{"method": request.Method, "path": specPath},
+		Reason: fmt.Sprintf("The %s operation for '%s' is marked as 'deprecated' in the specification",
+			request.Method, specPath),
+		SpecLine:      line,
+		SpecCol:       col,
+		Context:       op,
+		HowToFix:      HowToFixDeprecatedOperation,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+func OperationIdNotFound(operationId string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingOperation,
+		Message:           fmt.Sprintf("Operation with operationId '%s' not found", operationId),
+		MessageKey:        MessageKeyOperationIdNotFound,
+		MessageArgs:       map[string]any{"operationId": operationId},
+		Reason: fmt.Sprintf("There is no operation with an operationId of '%s' "+
+			"defined in the specification", operationId),
+		SpecLine: -1,
+		SpecCol:  -1,
+		HowToFix: HowToFixOperationId,
+	}
+}
+
+func WebhookNotFound(name string, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.WebhookValidation,
+		ValidationSubType: "missing",
+		Message:           fmt.Sprintf("Webhook '%s' not found", name),
+		MessageKey:        MessageKeyWebhookNotFound,
+		MessageArgs:       map[string]any{"name": name},
+		Reason: fmt.Sprintf("There is no webhook named '%s' "+
+			"declared in the 'webhooks' of the specification", name),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixWebhook,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      name,
+	}
+}
+
+func WebhookOperationNotFound(name string, pathItem *v3.PathItem, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.WebhookValidation,
+		ValidationSubType: helpers.RequestMissingOperation,
+		Message:           fmt.Sprintf("%s operation for webhook '%s' not found", request.Method, name),
+		MessageKey:        MessageKeyWebhookOperationNotFound,
+		MessageArgs:       map[string]any{"method": request.Method, "name": name},
+		Reason: fmt.Sprintf("The webhook '%s' was found, but there was no '%s' method "+
+			"found for it in the spec", name, request.Method),
+		SpecLine:      pathItem.GoLow().KeyNode.Line,
+		SpecCol:       pathItem.GoLow().KeyNode.Column,
+		Context:       pathItem,
+		HowToFix:      HowToFixWebhookMethod,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      name,
+	}
+}
+
+func CallbackNotFound(operationId, callbackName, expression string, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.CallbackValidation,
+		ValidationSubType: "missing",
+		Message:           fmt.Sprintf("Callback '%s' not found for operation '%s'", callbackName, operationId),
+		MessageKey:        MessageKeyCallbackNotFound,
+		MessageArgs:       map[string]any{"name": callbackName, "operationId": operationId, "expression": expression},
+		Reason: fmt.Sprintf("There is no callback named '%s' with the expression '%s' "+
+			"declared on the operation '%s' in the specification", callbackName, expression, operationId),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixCallback,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      expression,
+	}
+}
@@ -4,6 +4,8 @@
 package errors
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -71,13 +73,97 @@ func TestRequestContentTypeNotFound(t *testing.T) {
 	require.NotNil(t, err)
 	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
 	require.Equal(t, helpers.RequestBodyContentType, err.ValidationSubType)
-	require.Contains(t, err.Message, "'application/xml' does not exist")
+	require.Equal(t, "Content type 'application/xml' is not supported", err.Message)
 	require.Contains(t, err.Reason, "The content type 'application/xml' of the POST request submitted has not been defined")
 	require.Equal(t, 10, err.SpecLine)
 	require.Equal(t, 20, err.SpecCol)
 	require.Contains(t, err.HowToFix, "application/json")
 }
 
+func TestRequestBodyMissing(t *testing.T) {
+	op := createMockOperationWithRequestBody()
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	err := RequestBodyMissing(op, request, "/test")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyMissing, err.ValidationSubType)
+	require.Equal(t, "Request body is required", err.Message)
+	require.Contains(t, err.Reason, "The POST request body is empty")
+	require.Equal(t, "/test", err.SpecPath)
+	require.Equal(t, HowToFixMissingRequestBody, err.HowToFix)
+}
+
+func TestRequestBodyNotAccepted(t *testing.T) {
+	op := createMockOperationWithRequestBody()
+	request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+
+	err := RequestBodyNotAccepted(op, request, "/test")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyNotAccepted, err.ValidationSubType)
+	require.Equal(t, "GET operation does not accept a request body", err.Message)
+	require.Equal(t, MessageKeyRequestBodyNotAccepted, err.MessageKey)
+	require.Contains(t, err.Reason, "does not declare a 'requestBody'")
+	require.Equal(t, "/test", err.SpecPath)
+	require.Equal(t, HowToFixUndeclaredRequestBody, err.HowToFix)
+}
+
+func TestRequestBodyCannotBeDecoded(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	err := RequestBodyCannotBeDecoded(request, "Application/XML; charset=utf-8", "/test",
+		fmt.Errorf("XML syntax error on line 1: unexpected EOF"))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.Schema, err.ValidationSubType)
+	require.Equal(t, "POST request body for '/test' cannot be decoded as 'application/xml'", err.Message)
+	require.Equal(t, "The request body cannot be decoded: XML syntax error on line 1: unexpected EOF", err.Reason)
+	require.Equal(t, MessageKeyRequestBodyCannotBeDecoded, err.MessageKey)
+	require.Equal(t, "/test", err.SpecPath)
+	require.Equal(t, HowToFixDecodingError, err.HowToFix)
+}
+
+func TestRequestBodyInvalidJSON(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	var decoded any
+	err := RequestBodyInvalidJSON(request, json.Unmarshal([]byte(`{"name": "cla`), &decoded))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyInvalidJSON, err.ValidationSubType)
+	require.Equal(t, "POST request body is not valid JSON for '/test'", err.Message)
+	require.Equal(t, "The request body cannot be parsed as JSON, the syntax error is at byte offset 13: "+
+		"unexpected end of JSON input", err.Reason)
+	require.Equal(t, MessageKeyRequestBodyInvalidJSON, err.MessageKey)
+	require.Equal(t, int64(13), err.MessageArgs["offset"])
+	require.Equal(t, HowToFixInvalidJSON, err.HowToFix)
+	require.Empty(t, err.SchemaValidationErrors)
+
+	// the offset is unknown for an error that is not a syntax error.
+	err = RequestBodyInvalidJSON(request, fmt.Errorf("unexpected EOF"))
+	require.Equal(t, int64(-1), err.MessageArgs["offset"])
+}
+
+func TestRequestBodyEncodingInvalid(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	err := RequestBodyEncodingInvalid(request, "gzip", "/test", fmt.Errorf("gzip: invalid header"))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyEncoding, err.ValidationSubType)
+	require.Equal(t, "POST request body for '/test' failed to decode content encoding 'gzip'", err.Message)
+	require.Equal(t, "The request body failed to decode content encoding 'gzip': gzip: invalid header", err.Reason)
+	require.Equal(t, MessageKeyRequestBodyEncodingInvalid, err.MessageKey)
+	require.Equal(t, "/test", err.SpecPath)
+	require.Equal(t, HowToFixContentEncoding, err.HowToFix)
+}
+
 func TestOperationNotFound(t *testing.T) {
 	// Create a mock path item
 	pathItem := createMockPathItem()
@@ -98,3 +184,103 @@ func TestOperationNotFound(t *testing.T) {
 	require.Equal(t, 25, err.SpecCol)
 	require.Equal(t, HowToFixPathMethod, err.HowToFix)
 }
+
+func TestMethodNotAllowed(t *testing.T) {
+	pathItem := createMockPathItem()
+	pathItem.Get = &v3.Operation{}
+	pathItem.Post = &v3.Operation{}
+	request, _ := http.NewRequest(http.MethodOptions, "/test", nil)
+
+	err := MethodNotAllowed(pathItem, request, "/test")
+
+	require.NotNil(t, err)
+	require.True(t, err.IsOperationMissingError())
+	require.Equal(t, "Method OPTIONS not allowed on path '/test'", err.Message)
+	require.Equal(t, MessageKeyMethodNotAllowed, err.MessageKey)
+	require.Equal(t, []string{http.MethodGet, http.MethodPost}, err.MessageArgs["allowed"])
+	require.Contains(t, err.Reason, "the allowed methods are: GET, POST")
+	require.Equal(t, 15, err.SpecLine)
+	require.Equal(t, fmt.Sprintf(HowToFixMethodNotAllowed, "GET, POST"), err.HowToFix)
+}
+
+func TestOperationDeprecated(t *testing.T) {
+	op := createMockOperationWithRequestBody()
+	request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+
+	err := OperationDeprecated(op, request, "/test")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestDeprecated, err.ValidationSubType)
+	require.Equal(t, "GET operation for '/test' is deprecated", err.Message)
+	require.Equal(t, MessageKeyOperationDeprecated, err.MessageKey)
+	require.Equal(t, "/test", err.SpecPath)
+	require.Equal(t, HowToFixDeprecatedOperation, err.HowToFix)
+}
+
+func TestOperationIdNotFound(t *testing.T) {
+	err := OperationIdNotFound("createBurger")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingOperation, err.ValidationSubType)
+	require.Equal(t, "Operation with operationId 'createBurger' not found", err.Message)
+	require.Equal(t, HowToFixOperationId, err.HowToFix)
+	require.Equal(t, -1, err.SpecLine)
+}
+
+func TestWebhookNotFound(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/hooks", nil)
+
+	err := WebhookNotFound("newBurger", request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.WebhookValidation, err.ValidationType)
+	require.Equal(t, "Webhook 'newBurger' not found", err.Message)
+	require.Contains(t, err.Reason, "no webhook named 'newBurger'")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, "newBurger", err.SpecPath)
+	require.Equal(t, HowToFixWebhook, err.HowToFix)
+}
+
+func TestWebhookOperationNotFound(t *testing.T) {
+	pathItem := createMockPathItem()
+	request, _ := http.NewRequest(http.MethodPatch, "/hooks", nil)
+
+	err := WebhookOperationNotFound("newBurger", pathItem, request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.WebhookValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingOperation, err.ValidationSubType)
+	require.Equal(t, "PATCH operation for webhook 'newBurger' not found", err.Message)
+	require.Contains(t, err.Reason, "there was no 'PATCH' method")
+	require.Equal(t, 15, err.SpecLine)
+	require.Equal(t, 25, err.SpecCol)
+	require.Equal(t, HowToFixWebhookMethod, err.HowToFix)
+}
+
+func TestCallbackNotFound(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/callbacks", nil)
+
+	err := CallbackNotFound("createOrder", "onDelivery", "{$request.body#/callbackUrl}", request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.CallbackValidation, err.ValidationType)
+	require.Equal(t, "Callback 'onDelivery' not found for operation 'createOrder'", err.Message)
+	require.Contains(t, err.Reason, "'{$request.body#/callbackUrl}'")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixCallback, err.HowToFix)
+}
+
+func TestContentTypeNotDeclared(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/fries", nil)
+
+	err := ContentTypeNotDeclared(request, "application/xml; charset=utf-8")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyContentType, err.ValidationSubType)
+	require.Equal(t, "Content type 'application/xml' is not supported by any operation", err.Message)
+	require.Contains(t, err.Reason, "POST request")
+	require.Equal(t, HowToFixUndeclaredContentType, err.HowToFix)
+}
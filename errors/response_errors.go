@@ -48,6 +48,8 @@ func ResponseContentTypeNotFound(op *v3.Operation,
 		ValidationSubType: helpers.RequestBodyContentType,
 		Message: fmt.Sprintf("%s / %s operation response content type '%s' does not exist",
 			request.Method, code, mediaTypeString),
+		MessageKey:  MessageKeyResponseContentTypeNotFound,
+		MessageArgs: map[string]any{"method": request.Method, "code": code, "contentType": mediaTypeString},
 		Reason: fmt.Sprintf("The content type '%s' of the %s response received has not "+
 			"been defined, it's an unknown type", mediaTypeString, request.Method),
 		SpecLine: specLine,
@@ -58,12 +60,38 @@ func ResponseContentTypeNotFound(op *v3.Operation,
 	}
 }
 
+// ResponseCodeNotDefined is returned when a status code (or its range, e.g. '4XX') is not declared by the responses
+// of an operation, and the operation has no 'default' response.
+func ResponseCodeNotDefined(op *v3.Operation, request *http.Request, code int) *ValidationError {
+	line, col := -1, -1
+	if low := op.GoLow(); low != nil && low.Responses.KeyNode != nil {
+		line, col = low.Responses.KeyNode.Line, low.Responses.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.ResponseBodyResponseCode,
+		Message:           fmt.Sprintf("status code %d is not defined for this operation", code),
+		MessageKey:        MessageKeyResponseCodeNotDefined,
+		MessageArgs:       map[string]any{"code": code},
+		Reason: fmt.Sprintf("The status code %d returned for the %s request is not declared by the responses "+
+			"of the operation (neither is its range, e.g. '%dXX', or a 'default' response)", code, request.Method, code/100),
+		SpecLine:      line,
+		SpecCol:       col,
+		Context:       op,
+		HowToFix:      HowToFixInvalidResponseCode,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
 func ResponseCodeNotFound(op *v3.Operation, request *http.Request, code int) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ResponseBodyValidation,
 		ValidationSubType: helpers.ResponseBodyResponseCode,
 		Message: fmt.Sprintf("%s operation request response code '%d' does not exist",
 			request.Method, code),
+		MessageKey:  MessageKeyResponseCodeNotFound,
+		MessageArgs: map[string]any{"method": request.Method, "code": code},
 		Reason: fmt.Sprintf("The response code '%d' of the %s request submitted has not "+
 			"been defined, it's an unknown type", code, request.Method),
 		SpecLine: op.GoLow().Responses.KeyNode.Line,
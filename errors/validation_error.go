@@ -4,9 +4,14 @@
 package errors
 
 import (
+	"encoding/json"
+	stdError "errors"
 	"fmt"
+	"strings"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
 )
 
 // SchemaValidationFailure is a wrapper around the jsonschema.ValidationError object, to provide a more
@@ -21,6 +26,15 @@ type SchemaValidationFailure struct {
 	// DeepLocation is the path to the validation failure as exposed by the jsonschema library.
 	DeepLocation string `json:"deepLocation,omitempty" yaml:"deepLocation,omitempty"`
 
+	// FieldName is the name of the field in the validated object that caused the failure (if known).
+	FieldName string `json:"fieldName,omitempty" yaml:"fieldName,omitempty"`
+
+	// FieldPath is the JSONPath of the field in the validated object that caused the failure (e.g. '$.pets[0].name').
+	FieldPath string `json:"fieldPath,omitempty" yaml:"fieldPath,omitempty"`
+
+	// Value is the offending value of the field, if known (e.g. the string that failed to match a 'pattern').
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+
 	// AbsoluteLocation is the absolute path to the validation failure as exposed by the jsonschema library.
 	AbsoluteLocation string `json:"absoluteLocation,omitempty" yaml:"absoluteLocation,omitempty"`
 
@@ -52,6 +66,46 @@ func (s *SchemaValidationFailure) Error() string {
 	return fmt.Sprintf("Reason: %s, Location: %s", s.Reason, s.Location)
 }
 
+// ExpandSchemaValidationFailure populates the field name and path of a failure, using the flattened output
+// unit it was created from, and the decoded JSON schema that was validated against (optional).
+// If the unit refers to more than one field (for example, several properties rejected by
+// 'additionalProperties: false'), a copy of the failure is returned for each field.
+func ExpandSchemaValidationFailure(failure *SchemaValidationFailure, unit jsonschema.OutputUnit, decodedSchema any) []*SchemaValidationFailure {
+	fields := helpers.ExtractSchemaFailureFields(unit, decodedSchema)
+	failures := make([]*SchemaValidationFailure, len(fields))
+	for i, field := range fields {
+		f := *failure
+		f.FieldName = field.Name
+		f.FieldPath = field.Path
+		f.Value = field.Value
+		if field.Reason != "" {
+			f.Reason = field.Reason
+		}
+		failures[i] = &f
+	}
+	return failures
+}
+
+// DeduplicateSchemaValidationFailures removes failures that report the same reason for the same field.
+// Composed schemas (such as 'allOf') can reject a value for an identical reason from more than one branch,
+// only the first of those failures is kept, the order of the remaining failures is preserved.
+func DeduplicateSchemaValidationFailures(failures []*SchemaValidationFailure) []*SchemaValidationFailure {
+	if len(failures) < 2 {
+		return failures
+	}
+	seen := make(map[string]bool, len(failures))
+	deduplicated := failures[:0:0]
+	for _, failure := range failures {
+		key := failure.Reason + "\x00" + failure.FieldPath + "\x00" + failure.Value
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduplicated = append(deduplicated, failure)
+	}
+	return deduplicated
+}
+
 // ValidationError is a struct that contains all the information about a validation error.
 type ValidationError struct {
 	// Message is a human-readable message describing the error.
@@ -60,6 +114,12 @@ type ValidationError struct {
 	// Reason is a human-readable message describing the reason for the error.
 	Reason string `json:"reason" yaml:"reason"`
 
+	// MessageKey is a stable key identifying the message, independent of the language it is rendered in.
+	MessageKey string `json:"messageKey,omitempty" yaml:"messageKey,omitempty"`
+
+	// MessageArgs are the named values used to format the message identified by MessageKey.
+	MessageArgs map[string]any `json:"messageArgs,omitempty" yaml:"messageArgs,omitempty"`
+
 	// ValidationType is a string that describes the type of validation that failed.
 	ValidationType string `json:"validationType" yaml:"validationType"`
 
@@ -114,6 +174,84 @@ func (v *ValidationError) Error() string {
 	}
 }
 
+// Sentinel errors for the major categories of validation errors, a ValidationError matches the sentinel of its
+// category when checked with errors.Is (for example, errors.Is(err, errors.ErrPathNotFound)).
+var (
+	ErrPathNotFound      = stdError.New("path not found")
+	ErrOperationNotFound = stdError.New("operation not found")
+	ErrMethodNotAllowed  = stdError.New("method not allowed")
+	ErrParameter         = stdError.New("parameter validation failed")
+	ErrRequestBody       = stdError.New("request body validation failed")
+	ErrResponseBody      = stdError.New("response body validation failed")
+	ErrSecurity          = stdError.New("security validation failed")
+	ErrSchema            = stdError.New("schema validation failed")
+	ErrDocument          = stdError.New("document validation failed")
+	ErrSchemaCompilation = stdError.New("schema compilation failed")
+)
+
+// Is reports whether the error belongs to the category of a sentinel error (e.g. ErrPathNotFound), so the
+// category can be checked with errors.Is.
+func (v *ValidationError) Is(target error) bool {
+	switch target {
+	case ErrPathNotFound:
+		return v.IsPathMissingError()
+	case ErrOperationNotFound:
+		return v.IsOperationMissingError()
+	case ErrMethodNotAllowed:
+		return v.IsMethodNotAllowedError()
+	case ErrParameter:
+		return v.ValidationType == helpers.ParameterValidation
+	case ErrRequestBody:
+		return v.ValidationType == helpers.RequestBodyValidation
+	case ErrResponseBody:
+		return v.ValidationType == helpers.ResponseBodyValidation
+	case ErrSecurity:
+		return v.ValidationType == helpers.SecurityValidation
+	case ErrSchema:
+		return v.ValidationType == helpers.Schema
+	case ErrDocument:
+		return v.ValidationType == helpers.DocumentValidation
+	case ErrSchemaCompilation:
+		return v.IsSchemaCompilationError()
+	}
+	return false
+}
+
+// ValidationErrors is a slice of validation errors that implements the error interface, so a set of validation
+// errors can be returned as a single error. errors.Is and errors.As check every error in the slice.
+type ValidationErrors []*ValidationError
+
+// Error returns the errors, one per line.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, validationError := range e {
+		if validationError != nil {
+			messages = append(messages, validationError.Error())
+		}
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors, so errors.Is and errors.As can check each of them.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, validationError := range e {
+		if validationError != nil {
+			errs = append(errs, validationError)
+		}
+	}
+	return errs
+}
+
+// JoinValidationErrors returns the validation errors as a single error (a ValidationErrors), or nil if there are
+// no errors. Use this rather than converting the slice directly, as an empty ValidationErrors is not a nil error.
+func JoinValidationErrors(validationErrors []*ValidationError) error {
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return ValidationErrors(validationErrors)
+}
+
 // IsPathMissingError returns true if the error has a ValidationType of "path" and a ValidationSubType of "missing"
 func (v *ValidationError) IsPathMissingError() bool {
 	return v.ValidationType == "path" && v.ValidationSubType == "missing"
@@ -123,3 +261,133 @@ func (v *ValidationError) IsPathMissingError() bool {
 func (v *ValidationError) IsOperationMissingError() bool {
 	return v.ValidationType == "path" && v.ValidationSubType == "missingOperation"
 }
+
+// IsMethodNotAllowedError returns true if the path of the request was found, but the method was not (a 405, rather
+// than a 404). It's also an operation missing error, the methods that are allowed are returned by AllowedMethods.
+func (v *ValidationError) IsMethodNotAllowedError() bool {
+	return v.IsOperationMissingError() && v.MessageKey == MessageKeyMethodNotAllowed
+}
+
+// AllowedMethods returns the (upper-case) HTTP methods declared by the path of a method not allowed error, for the
+// 'Allow' header of a '405 Method Not Allowed' response. nil is returned for any other error.
+func (v *ValidationError) AllowedMethods() []string {
+	if !v.IsMethodNotAllowedError() {
+		return nil
+	}
+	allowed, _ := v.MessageArgs["allowed"].([]string)
+	return allowed
+}
+
+// IsSchemaCompilationError returns true if the error has a ValidationType of "schemaCompilation", that is the
+// specification is at fault (a schema could not be compiled), not the request or response.
+func (v *ValidationError) IsSchemaCompilationError() bool {
+	return v.ValidationType == helpers.SchemaCompilationError
+}
+
+// ValidationErrorPayload is a compact and stable representation of a ValidationError, designed to be serialized
+// and returned to API clients (for example, inside an error response). Noisy values like the rendered schema,
+// the submitted object and the original jsonschema error are left out.
+type ValidationErrorPayload struct {
+	// Type is the type of validation that failed (e.g. 'parameter', 'requestBody')
+	Type string `json:"type" yaml:"type"`
+
+	// SubType is the subtype of validation that failed (e.g. 'query', 'schema')
+	SubType string `json:"subType,omitempty" yaml:"subType,omitempty"`
+
+	// Message is a human-readable message describing the error.
+	Message string `json:"message" yaml:"message"`
+
+	// MessageKey is a stable key identifying the message, so clients can look up their own rendering of it.
+	MessageKey string `json:"messageKey,omitempty" yaml:"messageKey,omitempty"`
+
+	// Reason is a human-readable message describing the reason for the error.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// Path is the path of the request that failed validation.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// Method is the HTTP method of the request that failed validation.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// SpecPath is the path from the specification that corresponds to the request.
+	SpecPath string `json:"specPath,omitempty" yaml:"specPath,omitempty"`
+
+	// SpecLine is the line number in the spec where the error occurred (omitted when unknown).
+	SpecLine int `json:"specLine,omitempty" yaml:"specLine,omitempty"`
+
+	// SpecCol is the column number in the spec where the error occurred (omitted when unknown).
+	SpecCol int `json:"specColumn,omitempty" yaml:"specColumn,omitempty"`
+
+	// HowToFix is a human-readable message describing how to fix the error.
+	HowToFix string `json:"howToFix,omitempty" yaml:"howToFix,omitempty"`
+
+	// SchemaErrors contains the individual schema violations, if the error was caused by a schema.
+	SchemaErrors []*SchemaValidationFailurePayload `json:"schemaErrors,omitempty" yaml:"schemaErrors,omitempty"`
+}
+
+// SchemaValidationFailurePayload is a compact and stable representation of a SchemaValidationFailure.
+type SchemaValidationFailurePayload struct {
+	// Reason is a human-readable message describing the reason for the failure.
+	Reason string `json:"reason" yaml:"reason"`
+
+	// Location is the location of the failure, as reported by the validator.
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+
+	// FieldName is the name of the field that caused the failure (if known).
+	FieldName string `json:"fieldName,omitempty" yaml:"fieldName,omitempty"`
+
+	// FieldPath is the JSONPath of the field that caused the failure (if known).
+	FieldPath string `json:"fieldPath,omitempty" yaml:"fieldPath,omitempty"`
+
+	// Value is the offending value of the field (if known).
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// Line is the line number of the violation within the schema (omitted when unknown).
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+
+	// Column is the column number of the violation within the schema (omitted when unknown).
+	Column int `json:"column,omitempty" yaml:"column,omitempty"`
+}
+
+// Payload returns a compact and stable representation of the ValidationError, that is safe to serialize
+// and return to API clients.
+func (v *ValidationError) Payload() *ValidationErrorPayload {
+	payload := &ValidationErrorPayload{
+		Type:       v.ValidationType,
+		SubType:    v.ValidationSubType,
+		Message:    v.Message,
+		MessageKey: v.MessageKey,
+		Reason:     v.Reason,
+		Path:       v.RequestPath,
+		Method:     v.RequestMethod,
+		SpecPath:   v.SpecPath,
+		HowToFix:   v.HowToFix,
+	}
+	// negative values are used to signal that there is no location in the spec.
+	if v.SpecLine > 0 {
+		payload.SpecLine = v.SpecLine
+	}
+	if v.SpecCol > 0 {
+		payload.SpecCol = v.SpecCol
+	}
+	for _, sve := range v.SchemaValidationErrors {
+		if sve == nil {
+			continue
+		}
+		payload.SchemaErrors = append(payload.SchemaErrors, &SchemaValidationFailurePayload{
+			Reason:    sve.Reason,
+			Location:  sve.Location,
+			FieldName: sve.FieldName,
+			FieldPath: sve.FieldPath,
+			Value:     sve.Value,
+			Line:      sve.Line,
+			Column:    sve.Column,
+		})
+	}
+	return payload
+}
+
+// ToJSON renders the compact Payload of the ValidationError as JSON.
+func (v *ValidationError) ToJSON() ([]byte, error) {
+	return json.Marshal(v.Payload())
+}
@@ -4,10 +4,13 @@
 package errors
 
 import (
+	stdError "errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
 )
 
 func TestSchemaValidationFailure_Error(t *testing.T) {
@@ -116,3 +119,140 @@ func TestValidationError_IsOperationMissingError(t *testing.T) {
 	v.ValidationSubType = "missingOperation"
 	require.False(t, v.IsOperationMissingError())
 }
+
+func TestValidationError_IsMethodNotAllowedError(t *testing.T) {
+	v := &ValidationError{
+		ValidationType:    "path",
+		ValidationSubType: "missingOperation",
+		MessageKey:        MessageKeyMethodNotAllowed,
+		MessageArgs:       map[string]any{"allowed": []string{"GET", "PUT"}},
+	}
+	require.True(t, v.IsMethodNotAllowedError())
+	require.True(t, v.IsOperationMissingError())
+	require.Equal(t, []string{"GET", "PUT"}, v.AllowedMethods())
+
+	// a path that was not found is not a method that is not allowed.
+	v = &ValidationError{ValidationType: "path", ValidationSubType: "missing", MessageKey: MessageKeyPathNotFound}
+	require.False(t, v.IsMethodNotAllowedError())
+	require.Nil(t, v.AllowedMethods())
+}
+
+func TestValidationError_Payload(t *testing.T) {
+	v := &ValidationError{
+		Message:           "Query parameter 'fishy' failed to validate",
+		Reason:            "The query parameter 'fishy' is defined as an object",
+		ValidationType:    "parameter",
+		ValidationSubType: "query",
+		SpecLine:          -1,
+		SpecCol:           -1,
+		HowToFix:          HowToFixInvalidSchema,
+		RequestPath:       "/a/fishy",
+		RequestMethod:     "GET",
+		SpecPath:          "/a/{fish}",
+		SchemaValidationErrors: []*SchemaValidationFailure{
+			{
+				Reason:          "missing property 'vinegar'",
+				Location:        "/required",
+				Line:            3,
+				Column:          5,
+				ReferenceSchema: "type: object",
+				ReferenceObject: "{}",
+			},
+		},
+		Context: "a very noisy context",
+	}
+
+	payload := v.Payload()
+	require.Equal(t, "parameter", payload.Type)
+	require.Equal(t, "query", payload.SubType)
+	require.Equal(t, "/a/fishy", payload.Path)
+	require.Equal(t, "GET", payload.Method)
+	require.Equal(t, 0, payload.SpecLine)
+	require.Equal(t, 0, payload.SpecCol)
+	require.Len(t, payload.SchemaErrors, 1)
+	require.Equal(t, "missing property 'vinegar'", payload.SchemaErrors[0].Reason)
+
+	rendered, err := v.ToJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "parameter",
+		"subType": "query",
+		"message": "Query parameter 'fishy' failed to validate",
+		"reason": "The query parameter 'fishy' is defined as an object",
+		"path": "/a/fishy",
+		"method": "GET",
+		"specPath": "/a/{fish}",
+		"howToFix": "Ensure that the object being submitted, matches the schema correctly",
+		"schemaErrors": [
+			{"reason": "missing property 'vinegar'", "location": "/required", "line": 3, "column": 5}
+		]
+	}`, string(rendered))
+}
+
+func TestDeduplicateSchemaValidationFailures(t *testing.T) {
+	failures := []*SchemaValidationFailure{
+		{Reason: "missing property 'name'", Location: "/allOf/1/required", FieldPath: "$.name"},
+		{Reason: "missing property 'id'", Location: "/allOf/0/required", FieldPath: "$.id"},
+		{Reason: "missing property 'name'", Location: "/allOf/2/required", FieldPath: "$.name"},
+		{Reason: "missing property 'name'", Location: "/properties/a/required", FieldPath: "$.a.name"},
+	}
+
+	deduplicated := DeduplicateSchemaValidationFailures(failures)
+	require.Len(t, deduplicated, 3)
+	require.Equal(t, "/allOf/1/required", deduplicated[0].Location)
+	require.Equal(t, "/allOf/0/required", deduplicated[1].Location)
+	require.Equal(t, "$.a.name", deduplicated[2].FieldPath)
+	require.Len(t, failures, 4)
+}
+
+func TestValidationError_Is(t *testing.T) {
+	pathMissing := &ValidationError{ValidationType: helpers.ParameterValidationPath, ValidationSubType: "missing"}
+	require.True(t, stdError.Is(pathMissing, ErrPathNotFound))
+	require.False(t, stdError.Is(pathMissing, ErrOperationNotFound))
+	require.False(t, stdError.Is(pathMissing, ErrMethodNotAllowed))
+
+	methodNotAllowed := &ValidationError{ValidationType: helpers.ParameterValidationPath,
+		ValidationSubType: helpers.RequestMissingOperation, MessageKey: MessageKeyMethodNotAllowed}
+	require.True(t, stdError.Is(methodNotAllowed, ErrMethodNotAllowed))
+	require.True(t, stdError.Is(methodNotAllowed, ErrOperationNotFound))
+
+	query := &ValidationError{ValidationType: helpers.ParameterValidation, ValidationSubType: helpers.ParameterValidationQuery}
+	require.True(t, stdError.Is(query, ErrParameter))
+	require.False(t, stdError.Is(query, ErrRequestBody))
+
+	require.True(t, stdError.Is(&ValidationError{ValidationType: helpers.RequestBodyValidation}, ErrRequestBody))
+	require.True(t, stdError.Is(&ValidationError{ValidationType: helpers.ResponseBodyValidation}, ErrResponseBody))
+	require.True(t, stdError.Is(&ValidationError{ValidationType: helpers.SecurityValidation}, ErrSecurity))
+	require.True(t, stdError.Is(&ValidationError{ValidationType: helpers.Schema}, ErrSchema))
+	require.True(t, stdError.Is(&ValidationError{ValidationType: helpers.DocumentValidation}, ErrDocument))
+	require.True(t, stdError.Is(&ValidationError{ValidationType: helpers.SchemaCompilationError}, ErrSchemaCompilation))
+	require.False(t, stdError.Is(&ValidationError{ValidationType: helpers.SchemaCompilationError}, ErrSchema))
+
+	// wrapped errors are matched too.
+	require.True(t, stdError.Is(fmt.Errorf("request rejected: %w", query), ErrParameter))
+}
+
+func TestValidationErrors(t *testing.T) {
+	require.NoError(t, JoinValidationErrors(nil))
+
+	query := &ValidationError{Message: "Query parameter 'id' is missing", Reason: "required",
+		ValidationType: helpers.ParameterValidation}
+	body := &ValidationError{Message: "POST request body for '/burgers' failed to validate schema", Reason: "invalid",
+		ValidationType: helpers.RequestBodyValidation}
+
+	err := JoinValidationErrors([]*ValidationError{query, body})
+	require.Error(t, err)
+	require.Equal(t, query.Error()+"\n"+body.Error(), err.Error())
+
+	require.True(t, stdError.Is(err, ErrParameter))
+	require.True(t, stdError.Is(err, ErrRequestBody))
+	require.False(t, stdError.Is(err, ErrPathNotFound))
+
+	var validationError *ValidationError
+	require.True(t, stdError.As(err, &validationError))
+	require.Same(t, query, validationError)
+
+	var validationErrors ValidationErrors
+	require.True(t, stdError.As(err, &validationErrors))
+	require.Len(t, validationErrors, 2)
+}
@@ -0,0 +1,326 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// IsXMLMediaType will determine if a media type describes XML, either because it is XML itself (like
+// 'application/xml' or 'text/xml') or because it has a '+xml' structured syntax suffix (like 'application/atom+xml').
+// The check is case-insensitive, and parameters (like charset) are ignored.
+func IsXMLMediaType(mediaType string) bool {
+	mType, _, _ := ExtractContentType(mediaType)
+	_, subType, found := strings.Cut(strings.ToLower(mType), Slash)
+	if !found {
+		return false
+	}
+	return subType == XMLType || strings.HasSuffix(subType, XMLSuffix)
+}
+
+// IsFormMediaType will determine if a media type describes form data, that is 'application/x-www-form-urlencoded'
+// or 'multipart/form-data'. The check is case-insensitive, and parameters (like boundary) are ignored.
+func IsFormMediaType(mediaType string) bool {
+	mType, _, _ := ExtractContentType(mediaType)
+	switch strings.ToLower(mType) {
+	case FormURLEncodedContentType, MultipartFormContentType:
+		return true
+	}
+	return false
+}
+
+// CanDecodeBody will determine if there is a codec for a body with the media type, so it can be validated against a
+// schema. That is JSON, a sequence of JSON records, XML or form data.
+func CanDecodeBody(mediaType string) bool {
+	return IsJSONMediaType(mediaType) || IsJSONSequenceMediaType(mediaType) ||
+		IsXMLMediaType(mediaType) || IsFormMediaType(mediaType)
+}
+
+// CanDecodeContentEncoding will determine if content with a 'Content-Encoding' (e.g. 'gzip, deflate') can be
+// decoded, that is every encoding listed is 'gzip' (or 'x-gzip'), 'deflate' or 'identity'.
+func CanDecodeContentEncoding(encoding string) bool {
+	for _, coding := range strings.Split(encoding, Comma) {
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case GzipEncoding, "x-" + GzipEncoding, DeflateEncoding, IdentityEncoding, "":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeContentEncoding will decode content that was compressed as described by a 'Content-Encoding' header. The
+// encodings are listed in the order they were applied, so they are decoded in reverse. If maxBytes is greater than
+// zero, no more than maxBytes+1 bytes are decoded, and the second return value will be true if the decoded content
+// is larger than maxBytes (so a zip bomb is never decoded in full).
+func DecodeContentEncoding(content []byte, encoding string, maxBytes int64) ([]byte, bool, error) {
+	codings := strings.Split(encoding, Comma)
+	for i := len(codings) - 1; i >= 0; i-- {
+		var reader io.Reader
+		switch coding := strings.ToLower(strings.TrimSpace(codings[i])); coding {
+		case GzipEncoding, "x-" + GzipEncoding:
+			gz, err := gzip.NewReader(bytes.NewReader(content))
+			if err != nil {
+				return nil, false, err
+			}
+			reader = gz
+		case DeflateEncoding:
+			// 'deflate' is meant to be zlib wrapped, however some clients send a raw deflate stream.
+			zr, err := zlib.NewReader(bytes.NewReader(content))
+			if err != nil {
+				reader = flate.NewReader(bytes.NewReader(content))
+			} else {
+				reader = zr
+			}
+		case IdentityEncoding, "":
+			continue
+		default:
+			return nil, false, fmt.Errorf("the content encoding '%s' is not supported", coding)
+		}
+		if maxBytes > 0 {
+			reader = io.LimitReader(reader, maxBytes+1)
+		}
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, false, err
+		}
+		if maxBytes > 0 && int64(len(decoded)) > maxBytes {
+			return nil, true, nil
+		}
+		content = decoded
+	}
+	return content, false, nil
+}
+
+// DecodeBody will decode a body that is not JSON (XML or form data) into the same types a JSON body decodes into,
+// so it can be validated against the schema of the body. The schema guides the decoding, values are converted into
+// the types it defines (like CastParamValue), properties that are arrays collect repeated elements or fields, and the
+// 'xml' object of a schema is honored for the names of elements, attributes and wrapped arrays.
+func DecodeBody(body []byte, contentType string, sch *base.Schema) (any, error) {
+	switch {
+	case IsXMLMediaType(contentType):
+		root, err := parseXMLElement(body)
+		if err != nil {
+			return nil, err
+		}
+		return root.decode(sch), nil
+	case IsFormMediaType(contentType):
+		mType, _, boundary := ExtractContentType(contentType)
+		if strings.EqualFold(mType, MultipartFormContentType) {
+			return decodeMultipartForm(body, boundary, sch)
+		}
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		return decodeFormValues(values, nil, sch), nil
+	}
+	return nil, fmt.Errorf("there is no decoder for the content type '%s'", contentType)
+}
+
+// decodeFormValues converts the fields of a form into an object, using the schema of each property. Fields that were
+// sent as JSON (like the parts of a multipart form with a JSON content type) are decoded as JSON.
+func decodeFormValues(values url.Values, jsonFields map[string]bool, sch *base.Schema) map[string]any {
+	decoded := make(map[string]any, len(values))
+	for name, fieldValues := range values {
+		if len(fieldValues) == 0 {
+			continue
+		}
+		var propSchema *base.Schema
+		if sch != nil {
+			propSchema = propertySchema(sch, name)
+		}
+		// a binary field (like a file) is the raw content of the field, it's never decoded or cast.
+		if propSchema != nil && propSchema.Format == BinaryFormat && !slices.Contains(propSchema.Type, Array) {
+			decoded[name] = fieldValues[0]
+			continue
+		}
+		if jsonFields[name] {
+			var value any
+			if json.Unmarshal([]byte(fieldValues[0]), &value) == nil {
+				decoded[name] = value
+				continue
+			}
+		}
+		switch {
+		case propSchema != nil && slices.Contains(propSchema.Type, Array):
+			decoded[name] = decodeArrayItems(fieldValues, propSchema)
+		case len(fieldValues) > 1:
+			// a field sent more than once is kept as an array, so the schema can reject it.
+			decoded[name] = decodeArrayItems(fieldValues, &base.Schema{})
+		default:
+			decoded[name] = CastParamValue(fieldValues[0], propSchema)
+		}
+	}
+	return decoded
+}
+
+// decodeMultipartForm reads each part of a multipart form, a file is read as a string of its contents. A part for
+// a property with the 'binary' format is kept as it is, whatever the content type of the part.
+func decodeMultipartForm(body []byte, boundary string, sch *base.Schema) (map[string]any, error) {
+	if boundary == "" {
+		return nil, errors.New("the multipart form has no boundary")
+	}
+	values := make(url.Values)
+	jsonFields := make(map[string]bool)
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		name := part.FormName()
+		values[name] = append(values[name], string(content))
+		if IsJSONMediaType(part.Header.Get(ContentTypeHeader)) {
+			jsonFields[name] = true
+		}
+	}
+	return decodeFormValues(values, jsonFields, sch), nil
+}
+
+// xmlElement is an element of an XML document, kept generic until it's decoded with the help of a schema.
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// parseXMLElement parses an XML document into a tree of elements, and returns the root element.
+func parseXMLElement(body []byte) (*xmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	var root *xmlElement
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			} else if root == nil {
+				root = element
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("the XML document has no root element")
+	}
+	return root, nil
+}
+
+// decode converts an element into the type defined by the schema. Without a schema, an element with children (or
+// attributes) is decoded as an object, and an element with only text as a scalar.
+func (e *xmlElement) decode(sch *base.Schema) any {
+	isObject := len(e.children) > 0 || len(e.attrs) > 0
+	if sch != nil {
+		switch {
+		case slices.Contains(sch.Type, Array):
+			var itemsSchema *base.Schema
+			if sch.Items != nil && sch.Items.IsA() {
+				itemsSchema = sch.Items.A.Schema()
+			}
+			items := make([]any, len(e.children))
+			for i, child := range e.children {
+				items[i] = child.decode(itemsSchema)
+			}
+			return items
+		case slices.Contains(sch.Type, Object) || sch.Properties != nil:
+			isObject = true
+		case len(sch.Type) > 0:
+			isObject = false
+		}
+	}
+	if !isObject {
+		return CastParamValue(strings.TrimSpace(e.text.String()), sch)
+	}
+
+	decoded := make(map[string]any)
+	for _, attr := range e.attrs {
+		// namespace declarations are not properties of the object.
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		name, propSchema := xmlProperty(sch, attr.Name.Local)
+		decoded[name] = CastParamValue(attr.Value, propSchema)
+	}
+	for _, child := range e.children {
+		name, propSchema := xmlProperty(sch, child.name)
+		switch {
+		case propSchema != nil && slices.Contains(propSchema.Type, Array):
+			if propSchema.XML != nil && propSchema.XML.Wrapped {
+				// the items of a wrapped array are the children of the wrapping element.
+				decoded[name] = child.decode(propSchema)
+				continue
+			}
+			var itemsSchema *base.Schema
+			if propSchema.Items != nil && propSchema.Items.IsA() {
+				itemsSchema = propSchema.Items.A.Schema()
+			}
+			items, _ := decoded[name].([]any)
+			decoded[name] = append(items, child.decode(itemsSchema))
+		case decoded[name] != nil:
+			// an element that repeats is kept as an array, so the schema can reject it.
+			items, ok := decoded[name].([]any)
+			if !ok {
+				items = []any{decoded[name]}
+			}
+			decoded[name] = append(items, child.decode(propSchema))
+		default:
+			decoded[name] = child.decode(propSchema)
+		}
+	}
+	return decoded
+}
+
+// xmlProperty finds the property of a schema that an element (or attribute) describes. The 'xml' name of a property
+// is preferred over the name of the property. The element name is used when there is no matching property.
+func xmlProperty(sch *base.Schema, elementName string) (string, *base.Schema) {
+	if sch == nil {
+		return elementName, nil
+	}
+	if sch.Properties != nil {
+		for name, proxy := range sch.Properties.FromOldest() {
+			if propSchema := proxy.Schema(); propSchema != nil && propSchema.XML != nil && propSchema.XML.Name == elementName {
+				return name, propSchema
+			}
+		}
+	}
+	return elementName, propertySchema(sch, elementName)
+}
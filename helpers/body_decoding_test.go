@@ -0,0 +1,207 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsXMLMediaType(t *testing.T) {
+	require.True(t, IsXMLMediaType("application/xml"))
+	require.True(t, IsXMLMediaType("Text/XML; charset=utf-8"))
+	require.True(t, IsXMLMediaType("application/atom+xml"))
+	require.False(t, IsXMLMediaType("application/json"))
+	require.False(t, IsXMLMediaType(""))
+}
+
+func TestIsFormMediaType(t *testing.T) {
+	require.True(t, IsFormMediaType("application/x-www-form-urlencoded"))
+	require.True(t, IsFormMediaType("multipart/form-data; boundary=abc"))
+	require.False(t, IsFormMediaType("application/json"))
+	require.True(t, CanDecodeBody("application/x-ndjson"))
+	require.False(t, CanDecodeBody("text/plain"))
+}
+
+func TestDecodeBody(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      type: object
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        name:
+          type: string
+        vegan:
+          type: boolean
+        patties:
+          type: integer
+        toppings:
+          type: array
+          items:
+            type: string
+        sauces:
+          type: array
+          xml:
+            wrapped: true
+          items:
+            type: string
+            xml:
+              name: sauce
+        meta:
+          type: object
+    Upload:
+      type: object
+      properties:
+        file:
+          type: string
+          format: binary
+        code:
+          format: binary`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	sch := m.Model.Components.Schemas.GetOrZero("Burger").Schema()
+
+	decoded, err := DecodeBody([]byte(`<?xml version="1.0"?>
+<burger id="7" xmlns="https://pb33f.io/burgers">
+  <name>big mac</name>
+  <vegan>false</vegan>
+  <patties>2</patties>
+  <toppings>lettuce</toppings>
+  <toppings>pickles</toppings>
+  <sauces><sauce>special</sauce><sauce>ketchup</sauce></sauces>
+</burger>`), "application/xml", sch)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"id":       int64(7),
+		"name":     "big mac",
+		"vegan":    false,
+		"patties":  int64(2),
+		"toppings": []any{"lettuce", "pickles"},
+		"sauces":   []any{"special", "ketchup"},
+	}, decoded)
+
+	_, err = DecodeBody([]byte(`<burger><name>big mac</burger>`), "application/xml", sch)
+	require.Error(t, err)
+
+	decoded, err = DecodeBody([]byte("name=big+mac&patties=2&toppings=lettuce&toppings=pickles"),
+		"application/x-www-form-urlencoded", sch)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"name":     "big mac",
+		"patties":  int64(2),
+		"toppings": []any{"lettuce", "pickles"},
+	}, decoded)
+
+	multipartBody := "--abc\r\n" +
+		"Content-Disposition: form-data; name=\"name\"\r\n\r\n" +
+		"whopper\r\n" +
+		"--abc\r\n" +
+		"Content-Disposition: form-data; name=\"meta\"\r\n" +
+		"Content-Type: application/json\r\n\r\n" +
+		"{\"flame\": true}\r\n" +
+		"--abc--\r\n"
+	decoded, err = DecodeBody([]byte(multipartBody), "multipart/form-data; boundary=abc", sch)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"name": "whopper",
+		"meta": map[string]any{"flame": true},
+	}, decoded)
+
+	_, err = DecodeBody([]byte(multipartBody), "multipart/form-data", sch)
+	require.Error(t, err)
+
+	// a binary part is kept as raw content, even if it looks like JSON, or a number.
+	binarySchema := m.Model.Components.Schemas.GetOrZero("Upload").Schema()
+	multipartBody = "--abc\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"burger.json\"\r\n" +
+		"Content-Type: application/json\r\n\r\n" +
+		"{\"flame\": true}\r\n" +
+		"--abc\r\n" +
+		"Content-Disposition: form-data; name=\"code\"\r\n\r\n" +
+		"123\r\n" +
+		"--abc--\r\n"
+	decoded, err = DecodeBody([]byte(multipartBody), "multipart/form-data; boundary=abc", binarySchema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"file": `{"flame": true}`,
+		"code": "123",
+	}, decoded)
+
+	_, err = DecodeBody([]byte("hello"), "text/plain", sch)
+	require.Error(t, err)
+}
+
+func TestCanDecodeContentEncoding(t *testing.T) {
+	require.True(t, CanDecodeContentEncoding("gzip"))
+	require.True(t, CanDecodeContentEncoding("X-GZIP"))
+	require.True(t, CanDecodeContentEncoding("deflate, gzip"))
+	require.True(t, CanDecodeContentEncoding("identity"))
+	require.False(t, CanDecodeContentEncoding("br"))
+	require.False(t, CanDecodeContentEncoding("gzip, br"))
+}
+
+func TestDecodeContentEncoding(t *testing.T) {
+	content := []byte(`{"name": "big mac"}`)
+
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, _ = gw.Write(content)
+	_ = gw.Close()
+
+	var zlibbed bytes.Buffer
+	zw := zlib.NewWriter(&zlibbed)
+	_, _ = zw.Write(content)
+	_ = zw.Close()
+
+	var deflated bytes.Buffer
+	fw, _ := flate.NewWriter(&deflated, flate.DefaultCompression)
+	_, _ = fw.Write(content)
+	_ = fw.Close()
+
+	decoded, tooLarge, err := DecodeContentEncoding(gzipped.Bytes(), "gzip", 0)
+	require.NoError(t, err)
+	require.False(t, tooLarge)
+	require.Equal(t, content, decoded)
+
+	// 'deflate' is zlib wrapped, but a raw deflate stream is accepted too.
+	decoded, _, err = DecodeContentEncoding(zlibbed.Bytes(), "deflate", 0)
+	require.NoError(t, err)
+	require.Equal(t, content, decoded)
+	decoded, _, err = DecodeContentEncoding(deflated.Bytes(), "deflate", 0)
+	require.NoError(t, err)
+	require.Equal(t, content, decoded)
+
+	// encodings are decoded in the reverse of the order they were applied.
+	var twice bytes.Buffer
+	gw = gzip.NewWriter(&twice)
+	_, _ = gw.Write(zlibbed.Bytes())
+	_ = gw.Close()
+	decoded, _, err = DecodeContentEncoding(twice.Bytes(), "deflate, identity, gzip", 0)
+	require.NoError(t, err)
+	require.Equal(t, content, decoded)
+
+	// content that decodes into more than the cap is not decoded in full.
+	decoded, tooLarge, err = DecodeContentEncoding(gzipped.Bytes(), "gzip", 10)
+	require.NoError(t, err)
+	require.True(t, tooLarge)
+	require.Nil(t, decoded)
+
+	// corrupt content.
+	_, _, err = DecodeContentEncoding(content, "gzip", 0)
+	require.Error(t, err)
+	_, _, err = DecodeContentEncoding(gzipped.Bytes()[:len(gzipped.Bytes())-8], "gzip", 0)
+	require.Error(t, err)
+}
@@ -14,13 +14,30 @@ const (
 	Schema                    = "schema"
 	ResponseBodyValidation    = "response"
 	RequestBodyContentType    = "contentType"
+	RequestBodyMissing        = "missing"
+	RequestBodyTooLarge       = "tooLarge"
+	RequestBodyEncoding       = "contentEncoding"
+	RequestBodyInvalidJSON    = "invalidJSON"
+	RequestBodyNotAccepted    = "notAccepted"
 	RequestMissingOperation   = "missingOperation"
+	RequestDeprecated         = "deprecated"
+	SecurityValidation        = "security"
+	WebhookValidation         = "webhook"
+	CallbackValidation        = "callback"
 	ResponseBodyResponseCode  = "statusCode"
 	SpaceDelimited            = "spaceDelimited"
 	PipeDelimited             = "pipeDelimited"
 	DefaultDelimited          = "default"
 	MatrixStyle               = "matrix"
 	LabelStyle                = "label"
+	SimpleStyle               = "simple"
+	DocumentValidation        = "document"
+	DocumentParameterStyle    = "parameterStyle"
+	DocumentPathParameter     = "pathParameter"
+	DocumentExample           = "example"
+	DocumentDefault           = "default"
+	SchemaPattern             = "pattern"
+	SchemaCompilationError    = "schemaCompilation"
 	Pipe                      = "|"
 	Comma                     = ","
 	Space                     = " "
@@ -35,6 +52,8 @@ const (
 	String                    = "string"
 	Array                     = "array"
 	Boolean                   = "boolean"
+	ByteFormat                = "byte"
+	BinaryFormat              = "binary"
 	DeepObject                = "deepObject"
 	Header                    = "header"
 	Cookie                    = "cookie"
@@ -42,11 +61,28 @@ const (
 	Form                      = "form"
 	Query                     = "query"
 	JSONContentType           = "application/json"
+	ProblemJSONContentType    = "application/problem+json"
 	JSONType                  = "json"
+	JSONSuffix                = "+json"
+	NDJSONContentType         = "application/x-ndjson"
+	JSONLinesContentType      = "application/jsonl"
+	JSONSeqContentType        = "application/json-seq"
+	XMLType                   = "xml"
+	XMLSuffix                 = "+xml"
+	FormURLEncodedContentType = "application/x-www-form-urlencoded"
+	MultipartFormContentType  = "multipart/form-data"
 	ContentTypeHeader         = "Content-Type"
+	ContentEncodingHeader     = "Content-Encoding"
+	GzipEncoding              = "gzip"
+	DeflateEncoding           = "deflate"
+	IdentityEncoding          = "identity"
+	AcceptHeader              = "Accept"
 	AuthorizationHeader       = "Authorization"
+	CookieHeader              = "Cookie"
+	ForwardedPrefixHeader     = "X-Forwarded-Prefix"
 	Charset                   = "charset"
 	Boundary                  = "boundary"
 	Preferred                 = "preferred"
 	FailSegment               = "**&&FAIL&&**"
+	CatchAllExtension         = "x-catch-all"
 )
@@ -0,0 +1,160 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"bytes"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MediaTypeMatchesRange will determine if a media type (e.g. 'application/json') falls within a media range
+// (e.g. 'application/*' or '*/*'). An exact match is also considered a match. Parameters (like charset) on either
+// value are ignored.
+func MediaTypeMatchesRange(mediaRange, mediaType string) bool {
+	rangeType, _, _ := ExtractContentType(mediaRange)
+	mType, _, _ := ExtractContentType(mediaType)
+	rangeParts := strings.SplitN(rangeType, Slash, 2)
+	typeParts := strings.SplitN(mType, Slash, 2)
+	if len(rangeParts) != 2 || len(typeParts) != 2 {
+		return false
+	}
+	return (rangeParts[0] == Asterisk || rangeParts[0] == typeParts[0]) &&
+		(rangeParts[1] == Asterisk || rangeParts[1] == typeParts[1])
+}
+
+// MediaTypeMatches will determine if a content type (e.g. 'Application/JSON; charset=UTF-8') is described by a
+// declared media type, or media range (e.g. 'application/json' or 'application/*'). Types and subtypes are compared
+// case-insensitively. A 'charset' on the declared media type must match the charset of the content type (if it has
+// one), other parameters (like 'boundary', which differs for every message) are ignored.
+func MediaTypeMatches(declared, contentType string) bool {
+	return mediaTypeMatchScore(declared, contentType) > 0
+}
+
+// MatchMediaType will find the declared media type that best describes a content type, using the same rules as
+// MediaTypeMatches. An exact type is preferred over a media range (and 'application/*' over '*/*'), and a declared
+// media type with a matching charset is preferred over one without a charset. When more than one declared media
+// type is equally specific, the first one wins. The declared media type is returned as it was declared.
+func MatchMediaType(declared iter.Seq[string], contentType string) (string, bool) {
+	var best string
+	bestScore := 0
+	for mediaType := range declared {
+		if score := mediaTypeMatchScore(mediaType, contentType); score > bestScore {
+			best, bestScore = mediaType, score
+		}
+	}
+	return best, bestScore > 0
+}
+
+// mediaTypeMatchScore scores how specifically a declared media type describes a content type, zero means it
+// does not match at all.
+func mediaTypeMatchScore(declared, contentType string) int {
+	declaredType, declaredCharset, _ := ExtractContentType(declared)
+	mType, charset, _ := ExtractContentType(contentType)
+	if declaredCharset != "" && charset != "" && !strings.EqualFold(declaredCharset, charset) {
+		return 0
+	}
+	declaredParts := strings.SplitN(strings.ToLower(declaredType), Slash, 2)
+	typeParts := strings.SplitN(strings.ToLower(mType), Slash, 2)
+	if len(declaredParts) != 2 || len(typeParts) != 2 {
+		return 0
+	}
+	// each part that matches exactly, rather than by a wildcard, makes the match more specific.
+	score := 1
+	for i := range declaredParts {
+		switch declaredParts[i] {
+		case typeParts[i]:
+			score += 2
+		case Asterisk:
+		default:
+			return 0
+		}
+	}
+	if declaredCharset != "" && charset != "" {
+		score++
+	}
+	return score
+}
+
+// IsJSONMediaType will determine if a media type describes JSON, either because it is JSON itself (like
+// 'application/json') or because it has a '+json' structured syntax suffix (like 'application/vnd.api+json').
+// The check is case-insensitive, and parameters (like charset) are ignored.
+func IsJSONMediaType(mediaType string) bool {
+	mType, _, _ := ExtractContentType(mediaType)
+	_, subType, found := strings.Cut(strings.ToLower(mType), Slash)
+	if !found {
+		return false
+	}
+	return subType == JSONType || strings.HasSuffix(subType, JSONSuffix)
+}
+
+// IsJSONSequenceMediaType will determine if a media type describes a sequence of JSON records, rather than a single
+// JSON document. That is newline-delimited JSON ('application/x-ndjson' or 'application/jsonl') or a JSON text
+// sequence ('application/json-seq', RFC 7464). The check is case-insensitive, and parameters are ignored.
+func IsJSONSequenceMediaType(mediaType string) bool {
+	mType, _, _ := ExtractContentType(mediaType)
+	switch strings.ToLower(mType) {
+	case NDJSONContentType, JSONLinesContentType, JSONSeqContentType:
+		return true
+	}
+	return false
+}
+
+// SplitJSONSequence will break down the body of a JSON sequence into its records. Records of a JSON text sequence
+// ('application/json-seq') are each preceded by a record separator (0x1E), other sequences are newline-delimited.
+// Surrounding whitespace is trimmed from each record, and empty records are dropped.
+func SplitJSONSequence(body []byte, mediaType string) [][]byte {
+	separator := byte('\n')
+	if mType, _, _ := ExtractContentType(mediaType); strings.EqualFold(mType, JSONSeqContentType) {
+		separator = 0x1E
+	}
+	var records [][]byte
+	for _, record := range bytes.Split(body, []byte{separator}) {
+		if record = bytes.TrimSpace(record); len(record) > 0 {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// ExtractAcceptedMediaTypes will break down an 'Accept' header into the media ranges it contains, ordered by
+// the client's preference (the 'q' quality value). Media ranges with a quality of zero are not acceptable to the
+// client, so they are dropped. Ranges with equal quality keep the order in which they were supplied.
+func ExtractAcceptedMediaTypes(accept string) []string {
+	type acceptedType struct {
+		mediaRange string
+		quality    float64
+	}
+	var accepted []acceptedType
+	for _, part := range strings.Split(accept, Comma) {
+		segments := strings.Split(part, SemiColon)
+		mediaRange := strings.ToLower(strings.TrimSpace(segments[0]))
+		if mediaRange == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range segments[1:] {
+			k, val, found := strings.Cut(strings.TrimSpace(param), Equals)
+			if found && strings.TrimSpace(k) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		accepted = append(accepted, acceptedType{mediaRange: mediaRange, quality: quality})
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+	mediaTypes := make([]string, len(accepted))
+	for i := range accepted {
+		mediaTypes[i] = accepted[i].mediaRange
+	}
+	return mediaTypes
+}
@@ -0,0 +1,97 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaTypeMatchesRange(t *testing.T) {
+	require.True(t, MediaTypeMatchesRange("application/json", "application/json"))
+	require.True(t, MediaTypeMatchesRange("application/json", "application/json; charset=utf-8"))
+	require.True(t, MediaTypeMatchesRange("application/*", "application/json"))
+	require.True(t, MediaTypeMatchesRange("*/*", "text/plain"))
+	require.False(t, MediaTypeMatchesRange("application/json", "application/xml"))
+	require.False(t, MediaTypeMatchesRange("text/*", "application/json"))
+	require.False(t, MediaTypeMatchesRange("*/*", "nonsense"))
+	require.False(t, MediaTypeMatchesRange("*/*", ""))
+}
+
+func TestMediaTypeMatches(t *testing.T) {
+	require.True(t, MediaTypeMatches("application/json", "Application/JSON; charset=UTF-8"))
+	require.True(t, MediaTypeMatches("application/json; charset=utf-8", "application/json; charset=UTF-8"))
+	require.True(t, MediaTypeMatches("application/json; charset=utf-8", "application/json"))
+	require.True(t, MediaTypeMatches("multipart/form-data", "multipart/form-data; boundary=abc123"))
+	require.True(t, MediaTypeMatches("*/json", "application/json"))
+	require.False(t, MediaTypeMatches("application/json; charset=utf-8", "application/json; charset=iso-8859-1"))
+	require.False(t, MediaTypeMatches("application/json", "application/xml"))
+	require.False(t, MediaTypeMatches("*/*", ""))
+}
+
+func TestMatchMediaType(t *testing.T) {
+	declared := slices.Values([]string{"*/*", "text/*", "text/plain; charset=iso-8859-1", "text/plain; charset=utf-8", "text/plain"})
+
+	match, ok := MatchMediaType(declared, "TEXT/PLAIN; charset=UTF-8")
+	require.True(t, ok)
+	require.Equal(t, "text/plain; charset=utf-8", match)
+
+	match, _ = MatchMediaType(declared, "text/plain")
+	require.Equal(t, "text/plain; charset=iso-8859-1", match)
+
+	match, _ = MatchMediaType(declared, "text/plain; charset=us-ascii")
+	require.Equal(t, "text/plain", match)
+
+	match, _ = MatchMediaType(declared, "text/html")
+	require.Equal(t, "text/*", match)
+
+	match, _ = MatchMediaType(declared, "image/png")
+	require.Equal(t, "*/*", match)
+
+	_, ok = MatchMediaType(slices.Values([]string{"application/json"}), "application/xml")
+	require.False(t, ok)
+}
+
+func TestIsJSONMediaType(t *testing.T) {
+	require.True(t, IsJSONMediaType("application/json"))
+	require.True(t, IsJSONMediaType("application/json; charset=utf-8"))
+	require.True(t, IsJSONMediaType("application/vnd.myco.v2+json"))
+	require.True(t, IsJSONMediaType("Application/HAL+JSON; charset=utf-8"))
+	require.True(t, IsJSONMediaType("text/json"))
+	require.False(t, IsJSONMediaType("application/xml"))
+	require.False(t, IsJSONMediaType("application/jsonl"))
+	require.False(t, IsJSONMediaType("application/json-seq"))
+	require.False(t, IsJSONMediaType("json"))
+	require.False(t, IsJSONMediaType(""))
+}
+
+func TestIsJSONSequenceMediaType(t *testing.T) {
+	require.True(t, IsJSONSequenceMediaType("application/x-ndjson"))
+	require.True(t, IsJSONSequenceMediaType("application/jsonl; charset=utf-8"))
+	require.True(t, IsJSONSequenceMediaType("Application/JSON-SEQ"))
+	require.False(t, IsJSONSequenceMediaType("application/json"))
+	require.False(t, IsJSONSequenceMediaType(""))
+}
+
+func TestSplitJSONSequence(t *testing.T) {
+	records := SplitJSONSequence([]byte("{\"a\":1}\r\n\n  {\"a\":2}\n"), NDJSONContentType)
+	require.Equal(t, [][]byte{[]byte(`{"a":1}`), []byte(`{"a":2}`)}, records)
+
+	records = SplitJSONSequence([]byte("\x1e{\"a\":1}\n\x1e{\"a\":\n2}\n"), JSONSeqContentType)
+	require.Equal(t, [][]byte{[]byte(`{"a":1}`), []byte("{\"a\":\n2}")}, records)
+
+	require.Empty(t, SplitJSONSequence([]byte("\n\n"), NDJSONContentType))
+}
+
+func TestExtractAcceptedMediaTypes(t *testing.T) {
+	accepted := ExtractAcceptedMediaTypes("text/html, application/xml;q=0.9, application/json")
+	require.Equal(t, []string{"text/html", "application/json", "application/xml"}, accepted)
+
+	accepted = ExtractAcceptedMediaTypes("application/xml;q=0.2, */*;q=0.1, application/json;q=0")
+	require.Equal(t, []string{"application/xml", "*/*"}, accepted)
+
+	require.Empty(t, ExtractAcceptedMediaTypes(""))
+}
@@ -4,8 +4,11 @@
 package helpers
 
 import (
+	"fmt"
 	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/pb33f/libopenapi/datamodel/high/v3"
 )
@@ -34,6 +37,100 @@ func ExtractOperation(request *http.Request, item *v3.PathItem) *v3.Operation {
 	return nil
 }
 
+// AllowedMethods returns the (upper-case) HTTP methods of the operations declared by a path item, for example, to
+// build the 'Allow' header of a '405 Method Not Allowed' response.
+func AllowedMethods(item *v3.PathItem) []string {
+	var methods []string
+	if item == nil {
+		return methods
+	}
+	for pair := item.GetOperations().First(); pair != nil; pair = pair.Next() {
+		methods = append(methods, strings.ToUpper(pair.Key()))
+	}
+	return methods
+}
+
+// FindOperationByOperationId searches every path in the document for an operation with a matching operationId.
+// The path, the (upper-case) HTTP method, the path item and the operation are returned. If no operation is
+// found, all return values will be empty.
+func FindOperationByOperationId(document *v3.Document, operationId string) (string, string, *v3.PathItem, *v3.Operation) {
+	if document == nil || document.Paths == nil || document.Paths.PathItems == nil || operationId == "" {
+		return "", "", nil, nil
+	}
+	for path, pathItem := range document.Paths.PathItems.FromOldest() {
+		for method, operation := range pathItem.GetOperations().FromOldest() {
+			if operation != nil && operation.OperationId == operationId {
+				return path, strings.ToUpper(method), pathItem, operation
+			}
+		}
+	}
+	return "", "", nil, nil
+}
+
+// FindCallbackPathItem looks up the path item of a callback declared on an operation, by the name of the callback
+// and the runtime expression it is keyed by (e.g. '{$request.body#/callbackUrl}'). If the expression is empty and
+// the callback declares a single expression, that path item is returned. nil is returned if nothing matches.
+func FindCallbackPathItem(operation *v3.Operation, callbackName, expression string) *v3.PathItem {
+	if operation == nil || operation.Callbacks == nil {
+		return nil
+	}
+	callback := operation.Callbacks.GetOrZero(callbackName)
+	if callback == nil || callback.Expression == nil {
+		return nil
+	}
+	if expression == "" {
+		if callback.Expression.Len() != 1 {
+			return nil
+		}
+		return callback.Expression.First().Value()
+	}
+	return callback.Expression.GetOrZero(expression)
+}
+
+// FindResponse looks up the response an operation declares for a status code. The code itself is checked first,
+// then its range (e.g. '4XX'), and then the 'default' response. The key of the response that matched is returned
+// as well (e.g. '418', '4XX' or 'default'). nil is returned if no response is declared for the code.
+func FindResponse(operation *v3.Operation, statusCode int) (*v3.Response, string) {
+	if operation == nil || operation.Responses == nil {
+		return nil, ""
+	}
+	if operation.Responses.Codes != nil {
+		for _, code := range []string{
+			strconv.Itoa(statusCode),
+			fmt.Sprintf("%dXX", statusCode/100),
+			fmt.Sprintf("%dxx", statusCode/100),
+		} {
+			if response := operation.Responses.Codes.GetOrZero(code); response != nil {
+				return response, code
+			}
+		}
+	}
+	if operation.Responses.Default != nil {
+		return operation.Responses.Default, "default"
+	}
+	return nil, ""
+}
+
+// RequestContentTypeDeclared determines if a request body of any operation of the path items accepts the content
+// type (e.g. 'application/json; charset=utf-8'), media ranges (e.g. 'application/*') declared by an operation
+// are honored.
+func RequestContentTypeDeclared(pathItems []*v3.PathItem, contentType string) bool {
+	for _, pathItem := range pathItems {
+		if pathItem == nil {
+			continue
+		}
+		for _, operation := range pathItem.GetOperations().FromOldest() {
+			if operation == nil || operation.RequestBody == nil || operation.RequestBody.Content == nil {
+				continue
+			}
+			if _, ok := MatchMediaType(operation.RequestBody.Content.KeysFromOldest(), contentType); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ExtractContentType extracts the content type from the request header. First return argument is the content type
 // of the request.The second (optional) argument is the charset of the request. The third (optional)
 // argument is the boundary of the type (only used with forms really).
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -112,3 +113,116 @@ func TestExtractContentType(t *testing.T) {
 	require.Empty(t, charset)
 	require.Empty(t, boundary)
 }
+
+func TestFindOperationByOperationId(t *testing.T) {
+	put := &v3.Operation{OperationId: "updateBurger"}
+	pathItems := orderedmap.New[string, *v3.PathItem]()
+	pathItems.Set("/burgers", &v3.PathItem{Get: &v3.Operation{OperationId: "listBurgers"}})
+	pathItems.Set("/burgers/{burgerId}", &v3.PathItem{Put: put})
+	document := &v3.Document{Paths: &v3.Paths{PathItems: pathItems}}
+
+	path, method, pathItem, operation := FindOperationByOperationId(document, "updateBurger")
+	require.Equal(t, "/burgers/{burgerId}", path)
+	require.Equal(t, http.MethodPut, method)
+	require.NotNil(t, pathItem)
+	require.Equal(t, put, operation)
+
+	path, method, pathItem, operation = FindOperationByOperationId(document, "deleteBurger")
+	require.Empty(t, path)
+	require.Empty(t, method)
+	require.Nil(t, pathItem)
+	require.Nil(t, operation)
+
+	_, _, pathItem, _ = FindOperationByOperationId(nil, "updateBurger")
+	require.Nil(t, pathItem)
+}
+
+func TestAllowedMethods(t *testing.T) {
+	pathItem := &v3.PathItem{
+		Get:     &v3.Operation{},
+		Post:    &v3.Operation{},
+		Options: &v3.Operation{},
+	}
+	require.Equal(t, []string{http.MethodGet, http.MethodPost, http.MethodOptions}, AllowedMethods(pathItem))
+	require.Empty(t, AllowedMethods(&v3.PathItem{}))
+	require.Empty(t, AllowedMethods(nil))
+}
+
+func TestFindCallbackPathItem(t *testing.T) {
+	delivered := &v3.PathItem{Post: &v3.Operation{OperationId: "burgerDelivered"}}
+	failed := &v3.PathItem{Post: &v3.Operation{OperationId: "burgerFailed"}}
+
+	single := orderedmap.New[string, *v3.PathItem]()
+	single.Set("{$request.body#/callbackUrl}", delivered)
+	multiple := orderedmap.New[string, *v3.PathItem]()
+	multiple.Set("{$request.body#/deliveredUrl}", delivered)
+	multiple.Set("{$request.body#/failedUrl}", failed)
+
+	callbacks := orderedmap.New[string, *v3.Callback]()
+	callbacks.Set("onDelivery", &v3.Callback{Expression: single})
+	callbacks.Set("onStatus", &v3.Callback{Expression: multiple})
+	operation := &v3.Operation{Callbacks: callbacks}
+
+	require.Equal(t, delivered, FindCallbackPathItem(operation, "onDelivery", "{$request.body#/callbackUrl}"))
+	require.Equal(t, delivered, FindCallbackPathItem(operation, "onDelivery", ""))
+	require.Equal(t, failed, FindCallbackPathItem(operation, "onStatus", "{$request.body#/failedUrl}"))
+
+	// the expression is ambiguous, unknown or the callback does not exist.
+	require.Nil(t, FindCallbackPathItem(operation, "onStatus", ""))
+	require.Nil(t, FindCallbackPathItem(operation, "onDelivery", "{$request.body#/nope}"))
+	require.Nil(t, FindCallbackPathItem(operation, "onRefund", ""))
+	require.Nil(t, FindCallbackPathItem(&v3.Operation{}, "onDelivery", ""))
+	require.Nil(t, FindCallbackPathItem(nil, "onDelivery", ""))
+}
+
+func TestFindResponse(t *testing.T) {
+	ok, created, clientError, fallback := &v3.Response{}, &v3.Response{}, &v3.Response{}, &v3.Response{}
+	codes := orderedmap.New[string, *v3.Response]()
+	codes.Set("200", ok)
+	codes.Set("2XX", created)
+	codes.Set("4xx", clientError)
+	operation := &v3.Operation{Responses: &v3.Responses{Codes: codes, Default: fallback}}
+
+	response, code := FindResponse(operation, 200)
+	require.Equal(t, ok, response)
+	require.Equal(t, "200", code)
+
+	response, code = FindResponse(operation, 201)
+	require.Equal(t, created, response)
+	require.Equal(t, "2XX", code)
+
+	response, code = FindResponse(operation, 418)
+	require.Equal(t, clientError, response)
+	require.Equal(t, "4xx", code)
+
+	response, code = FindResponse(operation, 500)
+	require.Equal(t, fallback, response)
+	require.Equal(t, "default", code)
+
+	operation.Responses.Default = nil
+	response, code = FindResponse(operation, 500)
+	require.Nil(t, response)
+	require.Empty(t, code)
+
+	response, _ = FindResponse(&v3.Operation{}, 200)
+	require.Nil(t, response)
+	response, _ = FindResponse(nil, 200)
+	require.Nil(t, response)
+}
+
+func TestRequestContentTypeDeclared(t *testing.T) {
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set("application/json", &v3.MediaType{})
+	content.Set("text/*", &v3.MediaType{})
+	pathItems := []*v3.PathItem{
+		nil,
+		{Get: &v3.Operation{}},
+		{Post: &v3.Operation{RequestBody: &v3.RequestBody{Content: content}}},
+	}
+
+	require.True(t, RequestContentTypeDeclared(pathItems, "application/json"))
+	require.True(t, RequestContentTypeDeclared(pathItems, "application/json; charset=utf-8"))
+	require.True(t, RequestContentTypeDeclared(pathItems, "text/csv"))
+	require.False(t, RequestContentTypeDeclared(pathItems, "application/xml"))
+	require.False(t, RequestContentTypeDeclared(nil, "application/json"))
+}
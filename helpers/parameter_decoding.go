@@ -0,0 +1,337 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ExtractQueryParams will break down the query string of a request into query parameters, keyed by name.
+// Parameters encoded as a property of an object (e.g. 'color[R]=100') are keyed by the name of the object.
+func ExtractQueryParams(query url.Values) map[string][]*QueryParam {
+	queryParams := make(map[string][]*QueryParam)
+	for qKey, qVal := range query {
+		// check if the param is encoded as a property / deepObject
+		if strings.IndexRune(qKey, '[') > 0 && strings.IndexRune(qKey, ']') > 0 {
+			stripped := qKey[:strings.IndexRune(qKey, '[')]
+			value := qKey[strings.IndexRune(qKey, '[')+1 : strings.IndexRune(qKey, ']')]
+			queryParams[stripped] = append(queryParams[stripped], &QueryParam{
+				Key:      stripped,
+				Values:   qVal,
+				Property: value,
+				Path:     splitBracketPath(qKey[strings.IndexRune(qKey, '['):], value),
+			})
+		} else {
+			queryParams[qKey] = append(queryParams[qKey], &QueryParam{
+				Key:    qKey,
+				Values: qVal,
+			})
+		}
+	}
+	return queryParams
+}
+
+// ParseReservedQuery parses a raw query string in the same way as url.ParseQuery, for parameters that set
+// 'allowReserved'. Reserved characters are sent as they are, so a '+' in a value is kept (rather than decoded as a
+// space), and a ';' does not invalidate the pair it's in. Percent-encoded characters are still decoded.
+func ParseReservedQuery(rawQuery string) url.Values {
+	values := make(url.Values)
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			continue
+		}
+		value, err = url.PathUnescape(value)
+		if err != nil {
+			continue
+		}
+		values[key] = append(values[key], value)
+	}
+	return values
+}
+
+// splitBracketPath splits the bracketed keys of a query key (e.g. '[0][field]') into a path. A key that does not
+// consist of brackets only (e.g. '[0]x') is kept as a path of the property alone.
+func splitBracketPath(brackets, property string) []string {
+	var path []string
+	for brackets != "" {
+		end := strings.IndexRune(brackets, ']')
+		if brackets[0] != '[' || end < 0 {
+			return []string{property}
+		}
+		path = append(path, brackets[1:end])
+		brackets = brackets[end+1:]
+	}
+	return path
+}
+
+// DeepObjectNestingError is returned by DecodeDeepObject when the brackets of a query key do not fit the structure
+// of the schema, like an index for something that is not an array, or more levels of nesting than the schema has.
+type DeepObjectNestingError struct {
+	Key    string
+	Reason string
+}
+
+func (e *DeepObjectNestingError) Error() string {
+	return fmt.Sprintf("query key '%s' does not fit the schema: %s", e.Key, e.Reason)
+}
+
+// deepArray collects the items of an array by their index while a deepObject is decoded, as the indexes can be
+// supplied in any order.
+type deepArray map[int]any
+
+// DecodeDeepObject will decode a deepObject query parameter that nests arrays and objects using brackets, like
+// 'filter[0][field]=name&filter[0][op]=eq' (common with JSON:API style filters). The schema guides the decoding,
+// a bracketed key is an index if the schema at that level is an array, and a property otherwise. Values are
+// converted into the types defined by the schema (like CastParamValue). A *DeepObjectNestingError is returned if
+// the brackets nest deeper than the schema, or an index is not a number.
+func DecodeDeepObject(values []*QueryParam, sch *base.Schema) (any, error) {
+	var decoded any
+	for _, qp := range values {
+		if len(qp.Values) == 0 {
+			continue
+		}
+		key := qp.Key
+		for _, segment := range qp.Path {
+			key += "[" + segment + "]"
+		}
+		var err error
+		if decoded, err = insertDeepObjectValue(decoded, sch, qp.Path, qp.Values, key); err != nil {
+			return nil, err
+		}
+	}
+	return collectDeepArrays(decoded), nil
+}
+
+// insertDeepObjectValue places the values of a query key into the decoded object, at the path of the key.
+func insertDeepObjectValue(node any, sch *base.Schema, path, values []string, key string) (any, error) {
+	if len(path) == 0 {
+		if node != nil {
+			return nil, &DeepObjectNestingError{Key: key, Reason: "the value is supplied more than once"}
+		}
+		if sch != nil && slices.Contains(sch.Type, Array) {
+			return decodeArrayItems(values, sch), nil
+		}
+		return CastParamValue(values[0], sch), nil
+	}
+
+	segment := path[0]
+	switch {
+	case sch != nil && slices.Contains(sch.Type, Array):
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 {
+			return nil, &DeepObjectNestingError{Key: key, Reason: fmt.Sprintf("'%s' is not an index of an array", segment)}
+		}
+		items, ok := node.(deepArray)
+		if !ok {
+			if node != nil {
+				return nil, &DeepObjectNestingError{Key: key, Reason: "the array is also supplied as a value"}
+			}
+			items = make(deepArray)
+		}
+		var itemsSchema *base.Schema
+		if index < len(sch.PrefixItems) {
+			itemsSchema = sch.PrefixItems[index].Schema()
+		} else if sch.Items != nil && sch.Items.IsA() {
+			itemsSchema = sch.Items.A.Schema()
+		}
+		if items[index], err = insertDeepObjectValue(items[index], itemsSchema, path[1:], values, key); err != nil {
+			return nil, err
+		}
+		return items, nil
+	case sch == nil || len(sch.Type) == 0 || slices.Contains(sch.Type, Object):
+		properties, ok := node.(map[string]any)
+		if !ok {
+			if node != nil {
+				return nil, &DeepObjectNestingError{Key: key, Reason: "the object is also supplied as a value"}
+			}
+			properties = make(map[string]any)
+		}
+		var propSchema *base.Schema
+		if sch != nil {
+			propSchema = propertySchema(sch, segment)
+		}
+		value, err := insertDeepObjectValue(properties[segment], propSchema, path[1:], values, key)
+		if err != nil {
+			return nil, err
+		}
+		properties[segment] = value
+		return properties, nil
+	}
+	return nil, &DeepObjectNestingError{
+		Key:    key,
+		Reason: fmt.Sprintf("it nests deeper than the schema, which is a '%s'", strings.Join(sch.Type, "', '")),
+	}
+}
+
+// collectDeepArrays converts the arrays of a decoded deepObject into slices, with the items in order of their index.
+func collectDeepArrays(node any) any {
+	switch n := node.(type) {
+	case deepArray:
+		indexes := make([]int, 0, len(n))
+		for index := range n {
+			indexes = append(indexes, index)
+		}
+		slices.Sort(indexes)
+		items := make([]any, len(indexes))
+		for i, index := range indexes {
+			items[i] = collectDeepArrays(n[index])
+		}
+		return items
+	case map[string]any:
+		for name, value := range n {
+			n[name] = collectDeepArrays(value)
+		}
+	}
+	return node
+}
+
+// DecodeQueryParam will decode the value of a query parameter according to its style, and convert it into the
+// types defined by the schema of the parameter. Integers are converted into int64, numbers into float64,
+// booleans into bool, arrays into []any and objects into map[string]any. Anything that cannot be converted is
+// left as a string. A parameter that uses 'content' is decoded as JSON, if the media type is JSON.
+// nil is returned if the parameter was not supplied.
+func DecodeQueryParam(param *v3.Parameter, queryParams map[string][]*QueryParam) any {
+	values := queryParams[param.Name]
+
+	if param.Schema == nil {
+		// a parameter MUST contain either a schema property, or a content property.
+		for pair := orderedmap.First(param.Content); pair != nil; pair = pair.Next() {
+			raw := firstQueryValue(values)
+			if raw == nil {
+				return nil
+			}
+			var decoded any
+			if IsJSONMediaType(pair.Key()) && json.Unmarshal([]byte(*raw), &decoded) == nil {
+				return decoded
+			}
+			return *raw
+		}
+		return nil
+	}
+
+	sch := param.Schema.Schema()
+	switch {
+	case sch != nil && slices.Contains(sch.Type, Object):
+		return decodeQueryObject(param, sch, values, queryParams)
+	case sch != nil && slices.Contains(sch.Type, Array):
+		if len(values) == 0 {
+			return nil
+		}
+		var items []string
+		for _, qp := range values {
+			for _, value := range qp.Values {
+				items = append(items, ExplodeQueryValue(value, param.Style)...)
+			}
+		}
+		return decodeArrayItems(items, sch)
+	default:
+		raw := firstQueryValue(values)
+		if raw == nil {
+			return nil
+		}
+		return CastParamValue(*raw, sch)
+	}
+}
+
+// decodeQueryObject decodes a query parameter that is an object, for each of the styles an object can use.
+func decodeQueryObject(
+	param *v3.Parameter, sch *base.Schema, values []*QueryParam, queryParams map[string][]*QueryParam,
+) any {
+	decoded := make(map[string]any)
+	switch {
+	case param.Style == DeepObject:
+		for _, qp := range values {
+			if qp.Property == "" || len(qp.Values) == 0 {
+				continue
+			}
+			propSchema := propertySchema(sch, qp.Property)
+			if propSchema != nil && slices.Contains(propSchema.Type, Array) {
+				decoded[qp.Property] = decodeArrayItems(qp.Values, propSchema)
+			} else {
+				decoded[qp.Property] = CastParamValue(qp.Values[0], propSchema)
+			}
+		}
+	case len(values) > 0:
+		// the properties are delimited key / value pairs (e.g. 'R,100,G,200').
+		raw := firstQueryValue(values)
+		if raw == nil {
+			return nil
+		}
+		pairs := ExplodeQueryValue(*raw, param.Style)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			decoded[pairs[i]] = CastParamValue(pairs[i+1], propertySchema(sch, pairs[i]))
+		}
+	case param.IsDefaultFormEncoding():
+		// an exploded form object is sent as a query parameter for each property (e.g. 'R=100&G=200').
+		if sch.Properties == nil {
+			return nil
+		}
+		for name, proxy := range sch.Properties.FromOldest() {
+			if raw := firstQueryValue(queryParams[name]); raw != nil {
+				decoded[name] = CastParamValue(*raw, proxy.Schema())
+			}
+		}
+	}
+	if len(decoded) == 0 {
+		return nil
+	}
+	return decoded
+}
+
+// decodeArrayItems converts the items of an array into the types defined by the schema for each position, that is
+// 'prefixItems' for the position, falling back to 'items'.
+func decodeArrayItems(items []string, sch *base.Schema) []any {
+	var itemsSchema *base.Schema
+	if sch.Items != nil && sch.Items.IsA() {
+		itemsSchema = sch.Items.A.Schema()
+	}
+	decoded := make([]any, len(items))
+	for i, item := range items {
+		positionSchema := itemsSchema
+		if i < len(sch.PrefixItems) {
+			positionSchema = sch.PrefixItems[i].Schema()
+		}
+		decoded[i] = CastParamValue(item, positionSchema)
+	}
+	return decoded
+}
+
+// propertySchema returns the schema of a property of an object, or the schema for additional properties if the
+// property is not declared. nil is returned if there is no schema for the property.
+func propertySchema(sch *base.Schema, name string) *base.Schema {
+	if sch.Properties != nil {
+		if proxy := sch.Properties.GetOrZero(name); proxy != nil {
+			return proxy.Schema()
+		}
+	}
+	if sch.AdditionalProperties != nil && sch.AdditionalProperties.IsA() {
+		return sch.AdditionalProperties.A.Schema()
+	}
+	return nil
+}
+
+// firstQueryValue returns the first value supplied for a query parameter, or nil if there is none.
+func firstQueryValue(values []*QueryParam) *string {
+	for _, qp := range values {
+		if len(qp.Values) > 0 {
+			return &qp.Values[0]
+		}
+	}
+	return nil
+}
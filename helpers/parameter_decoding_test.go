@@ -0,0 +1,192 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractQueryParams(t *testing.T) {
+	query, _ := url.ParseQuery("id=1&id=2&color[R]=100&filter[0][field]=name")
+	queryParams := ExtractQueryParams(query)
+
+	require.Len(t, queryParams["id"], 1)
+	require.Equal(t, []string{"1", "2"}, queryParams["id"][0].Values)
+	require.Len(t, queryParams["color"], 1)
+	require.Equal(t, "R", queryParams["color"][0].Property)
+	require.Equal(t, []string{"100"}, queryParams["color"][0].Values)
+	require.Equal(t, []string{"R"}, queryParams["color"][0].Path)
+	require.Equal(t, "0", queryParams["filter"][0].Property)
+	require.Equal(t, []string{"0", "field"}, queryParams["filter"][0].Path)
+}
+
+func TestParseReservedQuery(t *testing.T) {
+	values := ParseReservedQuery("filter[url]=https://x.com/a?b=c+d;e&name=Big%20Mac&name=x%2By&flag&bad=%zz")
+	require.Equal(t, []string{"https://x.com/a?b=c+d;e"}, values["filter[url]"])
+	require.Equal(t, []string{"Big Mac", "x+y"}, values["name"])
+	require.Equal(t, []string{""}, values["flag"])
+	require.NotContains(t, values, "bad")
+	require.Empty(t, ParseReservedQuery(""))
+}
+
+func TestDecodeQueryParam(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: price
+          in: query
+          schema:
+            type: number
+        - name: vegan
+          in: query
+          schema:
+            type: boolean
+        - name: name
+          in: query
+          schema:
+            type: string
+        - name: ids
+          in: query
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: tags
+          in: query
+          style: pipeDelimited
+          schema:
+            type: array
+            items:
+              type: string
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              size:
+                type: integer
+              code:
+                type: string
+        - name: color
+          in: query
+          explode: false
+          schema:
+            type: object
+            properties:
+              R:
+                type: integer
+        - name: point
+          in: query
+          schema:
+            type: object
+            properties:
+              x:
+                type: number
+              y:
+                type: number
+        - name: meta
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+        - name: missing
+          in: query
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	params := m.Model.Paths.PathItems.GetOrZero("/burgers").Get.Parameters
+
+	query, _ := url.ParseQuery("limit=10&price=9.99&vegan=true&name=123&ids=1&ids=2&tags=a|b" +
+		"&filter[size]=2&filter[code]=007&color=R,100&x=1.5&y=2&meta={\"a\":1}")
+	queryParams := ExtractQueryParams(query)
+
+	decoded := make(map[string]any)
+	for _, param := range params {
+		decoded[param.Name] = DecodeQueryParam(param, queryParams)
+	}
+
+	require.Equal(t, int64(10), decoded["limit"])
+	require.Equal(t, 9.99, decoded["price"])
+	require.Equal(t, true, decoded["vegan"])
+	require.Equal(t, "123", decoded["name"])
+	require.Equal(t, []any{int64(1), int64(2)}, decoded["ids"])
+	require.Equal(t, []any{"a", "b"}, decoded["tags"])
+	require.Equal(t, map[string]any{"size": int64(2), "code": "007"}, decoded["filter"])
+	require.Equal(t, map[string]any{"R": int64(100)}, decoded["color"])
+	require.Equal(t, map[string]any{"x": 1.5, "y": float64(2)}, decoded["point"])
+	require.Equal(t, map[string]any{"a": float64(1)}, decoded["meta"])
+	require.Nil(t, decoded["missing"])
+
+	// a value that cannot be converted is left as a string.
+	query, _ = url.ParseQuery("limit=ten")
+	require.Equal(t, "ten", DecodeQueryParam(params[0], ExtractQueryParams(query)))
+}
+
+func TestDecodeDeepObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: array
+            items:
+              type: object
+              properties:
+                field:
+                  type: string
+                op:
+                  type: string
+                value:
+                  type: integer
+                tags:
+                  type: array
+                  items:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	sch := m.Model.Paths.PathItems.GetOrZero("/burgers").Get.Parameters[0].Schema.Schema()
+
+	query, _ := url.ParseQuery("filter[1][field]=size&filter[1][value]=2&filter[0][field]=name&filter[0][op]=eq" +
+		"&filter[0][tags]=a&filter[0][tags]=b")
+	decoded, err := DecodeDeepObject(ExtractQueryParams(query)["filter"], sch)
+	require.NoError(t, err)
+	require.Equal(t, []any{
+		map[string]any{"field": "name", "op": "eq", "tags": []any{"a", "b"}},
+		map[string]any{"field": "size", "value": int64(2)},
+	}, decoded)
+
+	// an index that is not a number.
+	query, _ = url.ParseQuery("filter[first][field]=name")
+	_, err = DecodeDeepObject(ExtractQueryParams(query)["filter"], sch)
+	var nestingErr *DeepObjectNestingError
+	require.ErrorAs(t, err, &nestingErr)
+	require.Equal(t, "filter[first][field]", nestingErr.Key)
+	require.Equal(t, "'first' is not an index of an array", nestingErr.Reason)
+
+	// brackets that nest deeper than the schema.
+	query, _ = url.ParseQuery("filter[0][field][name]=size")
+	_, err = DecodeDeepObject(ExtractQueryParams(query)["filter"], sch)
+	require.ErrorAs(t, err, &nestingErr)
+	require.Equal(t, "query key 'filter[0][field][name]' does not fit the schema: "+
+		"it nests deeper than the schema, which is a 'string'", err.Error())
+}
@@ -15,6 +15,115 @@ import (
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
 
+// parameterStyles are the styles that can be used for each parameter location, as defined by the OpenAPI
+// specification.
+var parameterStyles = map[string][]string{
+	Path:   {MatrixStyle, LabelStyle, SimpleStyle},
+	Query:  {Form, SpaceDelimited, PipeDelimited, DeepObject},
+	Header: {SimpleStyle},
+	Cookie: {Form},
+}
+
+// AllowedParameterStyles returns the styles that can be used for parameters in a location ('path', 'query', 'header'
+// or 'cookie'). nil is returned for a location that is not defined. The slice must not be modified.
+func AllowedParameterStyles(in string) []string {
+	return parameterStyles[in]
+}
+
+// IsDefinedStyle returns true if a style is one of the styles defined by the OpenAPI specification, for any
+// location. An empty style is defined too, it's the default style of the location.
+func IsDefinedStyle(style string) bool {
+	if style == "" {
+		return true
+	}
+	for _, styles := range parameterStyles {
+		if slices.Contains(styles, style) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindCatchAllParameter returns the name of the path parameter (declared by the path item, or the operation for the
+// request method) that is marked as a catch-all, using the 'x-catch-all: true' extension. A catch-all parameter
+// must be the last segment of the path (e.g. '/files/{path}'), and it captures every remaining segment of the
+// request path (e.g. 'a/b/c' for '/files/a/b/c'). An empty string is returned if there is no catch-all parameter.
+func FindCatchAllParameter(request *http.Request, pathItem *v3.PathItem) string {
+	if pathItem == nil {
+		return ""
+	}
+	for _, param := range ExtractParamsForOperation(request, pathItem) {
+		if param == nil || param.In != Path || param.Extensions == nil {
+			continue
+		}
+		if ext := param.Extensions.GetOrZero(CatchAllExtension); ext != nil {
+			if catchAll, err := strconv.ParseBool(ext.Value); err == nil && catchAll {
+				return param.Name
+			}
+		}
+	}
+	return ""
+}
+
+// standardRequestHeaders are the (canonical) names of headers that are part of HTTP itself, or are commonly added by
+// clients and proxies, rather than being parameters of an API.
+var standardRequestHeaders = map[string]bool{
+	"Accept": true, "Accept-Charset": true, "Accept-Encoding": true, "Accept-Language": true,
+	"Authorization": true, "Cache-Control": true, "Connection": true, "Content-Encoding": true,
+	"Content-Length": true, "Content-Type": true, "Cookie": true, "Date": true, "Expect": true,
+	"Forwarded": true, "Host": true, "If-Match": true, "If-Modified-Since": true, "If-None-Match": true,
+	"If-Range": true, "If-Unmodified-Since": true, "Keep-Alive": true, "Origin": true, "Pragma": true,
+	"Range": true, "Referer": true, "Te": true, "Trailer": true, "Transfer-Encoding": true, "Upgrade": true,
+	"User-Agent": true, "Via": true, "X-Forwarded-For": true, "X-Forwarded-Host": true,
+	"X-Forwarded-Prefix": true, "X-Forwarded-Proto": true, "X-Request-Id": true,
+}
+
+// IsStandardRequestHeader returns true if a header is part of HTTP itself (like 'Content-Type' or 'User-Agent'), or is
+// commonly added by proxies (like 'X-Forwarded-For'), rather than being a parameter of an API. The name is matched
+// case-insensitively.
+func IsStandardRequestHeader(name string) bool {
+	return standardRequestHeaders[http.CanonicalHeaderKey(name)]
+}
+
+// ParseCookies will parse the 'Cookie' headers of a request into cookies, the same way as request.Cookies(), that
+// is the headers are split into pairs on semicolons, the name and value of a pair are split on the first '=', so a
+// value can contain '=' (like base64), and double quotes around a value are removed. Unlike request.Cookies(), a
+// cookie is not dropped because its value contains characters that are not allowed (like a quote or a non-ASCII
+// character), so a cookie that was sent can always be validated, rather than be reported as missing.
+func ParseCookies(request *http.Request) []*http.Cookie {
+	var cookies []*http.Cookie
+	for _, line := range request.Header.Values(CookieHeader) {
+		for _, pair := range strings.Split(line, SemiColon) {
+			name, value, _ := strings.Cut(strings.TrimSpace(pair), Equals)
+			if name == "" {
+				continue
+			}
+			quoted := len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"'
+			if quoted {
+				value = value[1 : len(value)-1]
+			}
+			cookies = append(cookies, &http.Cookie{Name: name, Value: value, Quoted: quoted})
+		}
+	}
+	return cookies
+}
+
+// IsCatchAllSegment returns true if a segment of a path template is the catch-all parameter (e.g. '{path}').
+func IsCatchAllSegment(segment, catchAll string) bool {
+	return catchAll != "" && segment == "{"+catchAll+"}"
+}
+
+// CollapseCatchAllSegments joins the segments of a request path that are captured by a catch-all parameter
+// into a single segment, so the request path has the same number of segments as the path template. The request
+// segments are returned as they are, if the last segment of the template is not the catch-all parameter.
+func CollapseCatchAllSegments(template, requested []string, catchAll string) []string {
+	last := len(template) - 1
+	if last < 0 || len(requested) <= last || !IsCatchAllSegment(template[last], catchAll) {
+		return requested
+	}
+	return append(slices.Clone(requested[:last]), strings.Join(requested[last:], Slash))
+}
+
 // QueryParam is a struct that holds the key, values and property name for a query parameter
 // it's used for complex query types that need to be parsed and tracked differently depending
 // on the encoding styles used.
@@ -22,6 +131,9 @@ type QueryParam struct {
 	Key      string
 	Values   []string
 	Property string
+	// Path holds each of the bracketed keys of a nested property (e.g. ['0', 'field'] for 'filter[0][field]'),
+	// the first of which is the Property.
+	Path []string
 }
 
 // ExtractParamsForOperation will extract the parameters for the operation based on the request method.
@@ -121,6 +233,57 @@ func cast(v string) any {
 	return v
 }
 
+// CastParamValue will convert a raw parameter value into the type defined by the schema. Each type defined by the
+// schema is tried in turn, if the value cannot be converted into any of them, it is returned as-is (as a string),
+// so that schema validation can report the mismatch.
+func CastParamValue(value string, sch *base.Schema) any {
+	if sch == nil {
+		return cast(value)
+	}
+	for _, t := range sch.Type {
+		switch t {
+		case Integer:
+			if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return i
+			}
+		case Number:
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				return f
+			}
+		case Boolean:
+			if b, err := strconv.ParseBool(value); err == nil && value != "0" && value != "1" {
+				return b
+			}
+		case String:
+			return value
+		}
+	}
+	return value
+}
+
+// ParamMatchesEnum returns true if the raw value of a parameter is one of the values of the 'enum' of its schema.
+// For a number or integer schema the value is compared as a number, so '2.0' matches an enum value of 2.
+// A schema without an enum matches any value.
+func ParamMatchesEnum(value string, sch *base.Schema) bool {
+	if sch == nil || sch.Enum == nil {
+		return true
+	}
+	value = strings.TrimSpace(value)
+	number, numErr := strconv.ParseFloat(value, 64)
+	numeric := numErr == nil && (slices.Contains(sch.Type, Number) || slices.Contains(sch.Type, Integer))
+	for _, enumVal := range sch.Enum {
+		if value == fmt.Sprint(enumVal.Value) {
+			return true
+		}
+		if numeric {
+			if want, err := strconv.ParseFloat(enumVal.Value, 64); err == nil && want == number {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ConstructParamMapFromDeepObjectEncoding will construct a map from the query parameters that are encoded as
 // deep objects. It's kind of a crazy way to do things, but hey, each to their own.
 func ConstructParamMapFromDeepObjectEncoding(values []*QueryParam, sch *base.Schema) map[string]interface{} {
@@ -260,6 +423,42 @@ func ConstructKVFromCSV(values string) map[string]interface{} {
 	return props
 }
 
+// DecodeMapFromCSV will decode an object from a comma separated value string of alternating keys and values
+// (e.g. 'milk,1,sugar,2'), like ConstructMapFromCSV. Unlike ConstructMapFromCSV, nothing is dropped, if the
+// string is malformed (an odd number of tokens, or an empty key) false is returned. Empty values are kept.
+func DecodeMapFromCSV(csv string) (map[string]interface{}, bool) {
+	exploded := strings.Split(csv, Comma)
+	if len(exploded)%2 != 0 {
+		return nil, false
+	}
+	decoded := make(map[string]interface{}, len(exploded)/2)
+	for i := 0; i < len(exploded); i += 2 {
+		key := strings.TrimSpace(exploded[i])
+		if key == "" {
+			return nil, false
+		}
+		decoded[key] = cast(strings.TrimSpace(exploded[i+1]))
+	}
+	return decoded, true
+}
+
+// DecodeKVFromCSV will decode an object from a comma separated value string of key value pairs
+// (e.g. 'milk=1,sugar=2'), like ConstructKVFromCSV. Unlike ConstructKVFromCSV, nothing is dropped, if a pair
+// is malformed (no '=', or an empty key) false is returned. Empty values are kept.
+func DecodeKVFromCSV(values string) (map[string]interface{}, bool) {
+	exploded := strings.Split(values, Comma)
+	decoded := make(map[string]interface{}, len(exploded))
+	for _, pair := range exploded {
+		key, value, found := strings.Cut(pair, Equals)
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, false
+		}
+		decoded[key] = cast(strings.TrimSpace(value))
+	}
+	return decoded, true
+}
+
 // ConstructKVFromLabelEncoding will construct a map from a comma separated value string that denotes key value pairs.
 func ConstructKVFromLabelEncoding(values string) map[string]interface{} {
 	props := make(map[string]interface{})
@@ -286,6 +485,34 @@ func ConstructKVFromMatrixCSV(values string) map[string]interface{} {
 	return props
 }
 
+// DecodeMatrixArray will decode the items of an array path parameter that uses the 'matrix' style. An exploded array
+// repeats the name of the parameter for each item (e.g. ';id=3;id=4;id=5'), an array that is not exploded separates
+// the items with commas (e.g. ';id=3,4,5'). The second return value is false if the value is not encoded that way.
+func DecodeMatrixArray(value, name string, exploded bool) ([]string, bool) {
+	if !strings.HasPrefix(value, SemiColon) {
+		return nil, false
+	}
+	if !exploded {
+		key, items, found := strings.Cut(value[1:], Equals)
+		if key != name {
+			return nil, false
+		}
+		if !found || items == "" {
+			return []string{}, true
+		}
+		return strings.Split(items, Comma), true
+	}
+	var items []string
+	for _, pair := range strings.Split(value[1:], SemiColon) {
+		key, item, _ := strings.Cut(pair, Equals)
+		if key != name {
+			return nil, false
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
 // ConstructParamMapFromFormEncodingArray will construct a map from the query parameters that are encoded as
 // form encoded values.
 func ConstructParamMapFromFormEncodingArray(values []*QueryParam) map[string]interface{} {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
@@ -60,6 +61,28 @@ func TestExtractParamsForOperation(t *testing.T) {
 }
 
 // Test cast with different values (bool, int, float, string)
+func TestParseCookies(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	request.Header.Add("Cookie", `session="abc def"; token=YWJj+/ZGVm==; empty=; ;flag`)
+	request.Header.Add("Cookie", `sauce=ketchup"mayo; name=café`)
+
+	cookies := ParseCookies(request)
+	require.Len(t, cookies, 6)
+	require.Equal(t, "session", cookies[0].Name)
+	require.Equal(t, "abc def", cookies[0].Value)
+	require.True(t, cookies[0].Quoted)
+	require.Equal(t, "YWJj+/ZGVm==", cookies[1].Value)
+	require.False(t, cookies[1].Quoted)
+	require.Equal(t, "", cookies[2].Value)
+	require.Equal(t, "flag", cookies[3].Name)
+	require.Equal(t, "", cookies[3].Value)
+
+	// cookies request.Cookies() would drop are kept.
+	require.Equal(t, `ketchup"mayo`, cookies[4].Value)
+	require.Equal(t, "café", cookies[5].Value)
+	require.Equal(t, "name", cookies[5].Name)
+}
+
 func TestCast(t *testing.T) {
 	require.Equal(t, true, cast("true"))
 	require.Equal(t, int64(123), cast("123"))
@@ -68,6 +91,53 @@ func TestCast(t *testing.T) {
 }
 
 // Test ExtractSecurityForOperation with various HTTP methods
+func TestCastParamValue(t *testing.T) {
+	require.Equal(t, int64(1), CastParamValue("1", &base.Schema{Type: []string{Integer}}))
+	require.Equal(t, 1.5, CastParamValue("1.5", &base.Schema{Type: []string{Number}}))
+	require.Equal(t, true, CastParamValue("true", &base.Schema{Type: []string{Boolean}}))
+	require.Equal(t, "1", CastParamValue("1", &base.Schema{Type: []string{Boolean}}))
+	require.Equal(t, "1", CastParamValue("1", &base.Schema{Type: []string{String}}))
+	require.Equal(t, "nope", CastParamValue("nope", &base.Schema{Type: []string{Integer, Number}}))
+	require.Equal(t, 2.5, CastParamValue("2.5", &base.Schema{Type: []string{Integer, Number}}))
+	require.Equal(t, int64(7), CastParamValue("7", nil))
+}
+
+func TestIsStandardRequestHeader(t *testing.T) {
+	require.True(t, IsStandardRequestHeader("Content-Type"))
+	require.True(t, IsStandardRequestHeader("user-agent"))
+	require.True(t, IsStandardRequestHeader("X-FORWARDED-FOR"))
+	require.False(t, IsStandardRequestHeader("X-Rate-Limit"))
+}
+
+func TestParamMatchesEnum(t *testing.T) {
+	enum := func(values ...string) []*yaml.Node {
+		nodes := make([]*yaml.Node, len(values))
+		for i, v := range values {
+			nodes[i] = &yaml.Node{Kind: yaml.ScalarNode, Value: v}
+		}
+		return nodes
+	}
+	integer := &base.Schema{Type: []string{Integer}, Enum: enum("1", "2", "3")}
+	require.True(t, ParamMatchesEnum("2", integer))
+	require.True(t, ParamMatchesEnum(" 2 ", integer))
+	require.True(t, ParamMatchesEnum("2.0", integer))
+	require.False(t, ParamMatchesEnum("4", integer))
+
+	number := &base.Schema{Type: []string{Number}, Enum: enum("0.5", "1.0", "2e1")}
+	require.True(t, ParamMatchesEnum("0.50", number))
+	require.True(t, ParamMatchesEnum("1", number))
+	require.True(t, ParamMatchesEnum("20", number))
+	require.False(t, ParamMatchesEnum("1.5", number))
+	require.False(t, ParamMatchesEnum("one", number))
+
+	// strings are compared as-is.
+	str := &base.Schema{Type: []string{String}, Enum: enum("1", "2")}
+	require.True(t, ParamMatchesEnum("1", str))
+	require.False(t, ParamMatchesEnum("1.0", str))
+
+	require.True(t, ParamMatchesEnum("anything", &base.Schema{Type: []string{String}}))
+}
+
 func TestExtractSecurityForOperation(t *testing.T) {
 	// Create a PathItem with security requirements for each method
 	pathItem := &v3.PathItem{
@@ -339,6 +409,48 @@ func TestConstructKVFromCSV(t *testing.T) {
 	require.Equal(t, "value2", result["key2"])
 }
 
+func TestDecodeMapFromCSV(t *testing.T) {
+	result, ok := DecodeMapFromCSV("milk,123,sugar,two")
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"milk": int64(123), "sugar": "two"}, result)
+
+	result, ok = DecodeMapFromCSV("milk,")
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"milk": ""}, result)
+
+	for _, malformed := range []string{"milk,123,sugar", "milk,123,", ",123", "milk", ""} {
+		result, ok = DecodeMapFromCSV(malformed)
+		require.False(t, ok, malformed)
+		require.Nil(t, result)
+	}
+}
+
+func TestDecodeKVFromCSV(t *testing.T) {
+	result, ok := DecodeKVFromCSV("milk=123,sugar=two")
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"milk": int64(123), "sugar": "two"}, result)
+
+	result, ok = DecodeKVFromCSV("milk=")
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"milk": ""}, result)
+
+	for _, malformed := range []string{"milk=123,sugar", "milk=123,", "=123", "milk", ""} {
+		result, ok = DecodeKVFromCSV(malformed)
+		require.False(t, ok, malformed)
+		require.Nil(t, result)
+	}
+}
+
+func TestCollapseCatchAllSegments(t *testing.T) {
+	template := []string{"files", "{path}"}
+	require.Equal(t, []string{"files", "a/b/c"}, CollapseCatchAllSegments(template, []string{"files", "a", "b", "c"}, "path"))
+	require.Equal(t, []string{"files", "a"}, CollapseCatchAllSegments(template, []string{"files", "a"}, "path"))
+	require.Equal(t, []string{"files"}, CollapseCatchAllSegments(template, []string{"files"}, "path"))
+	require.Equal(t, []string{"files", "a", "b"}, CollapseCatchAllSegments(template, []string{"files", "a", "b"}, "other"))
+	require.Equal(t, []string{"files", "a", "b"}, CollapseCatchAllSegments(template, []string{"files", "a", "b"}, ""))
+	require.Equal(t, []string{"a", "b"}, CollapseCatchAllSegments(nil, []string{"a", "b"}, "path"))
+}
+
 // Test CollapseCSVIntoFormStyle
 func TestCollapseCSVIntoFormStyle(t *testing.T) {
 	result := CollapseCSVIntoFormStyle("key", "value1,value2")
@@ -370,6 +482,32 @@ func TestExplodeQueryValue(t *testing.T) {
 	require.Equal(t, []string{"value1", "value2"}, ExplodeQueryValue("value1|value2", "pipeDelimited"))
 }
 
+func TestDecodeMatrixArray(t *testing.T) {
+	items, ok := DecodeMatrixArray(";id=3;id=4;id=5", "id", true)
+	require.True(t, ok)
+	require.Equal(t, []string{"3", "4", "5"}, items)
+
+	items, ok = DecodeMatrixArray(";id=3,4,5", "id", false)
+	require.True(t, ok)
+	require.Equal(t, []string{"3", "4", "5"}, items)
+
+	items, ok = DecodeMatrixArray(";id", "id", false)
+	require.True(t, ok)
+	require.Empty(t, items)
+
+	// a value containing the name of the parameter is left alone.
+	items, ok = DecodeMatrixArray(";id=id=3;id=4", "id", true)
+	require.True(t, ok)
+	require.Equal(t, []string{"id=3", "4"}, items)
+
+	_, ok = DecodeMatrixArray(";id=3;ids=4", "id", true)
+	require.False(t, ok)
+	_, ok = DecodeMatrixArray(";ids=3,4", "id", false)
+	require.False(t, ok)
+	_, ok = DecodeMatrixArray("3,4", "id", false)
+	require.False(t, ok)
+}
+
 func TestConstructKVFromMatrixCSV(t *testing.T) {
 	// Test case 1: Empty input string
 	values := ""
@@ -5,10 +5,15 @@ package helpers
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"unicode"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 // ExtractJSONPathFromValidationError traverses and processes a ValidationError to construct a JSONPath string representation of its instance location.
@@ -19,30 +24,218 @@ func ExtractJSONPathFromValidationError(e *jsonschema.ValidationError) string {
 		}
 	}
 
-	if len(e.InstanceLocation) > 0 {
+	return JSONPathFromSegments(e.InstanceLocation)
+}
+
+// JSONPathFromSegments builds a JSONPath string (e.g. '$.pets[0].name') from a set of instance location segments.
+// An empty set of segments returns an empty string.
+func JSONPathFromSegments(segments []string) string {
+	if len(segments) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("$")
+	for _, seg := range segments {
+		switch {
+		case isNumeric(seg):
+			b.WriteString(fmt.Sprintf("[%s]", seg))
+
+		case isSimpleIdentifier(seg):
+			b.WriteByte('.')
+			b.WriteString(seg)
+
+		default:
+			esc := escapeBracketString(seg)
+			b.WriteString("['")
+			b.WriteString(esc)
+			b.WriteString("']")
+		}
+	}
+	return b.String()
+}
+
+// JSONPathSegments splits a JSONPath string built by JSONPathFromSegments (e.g. '$.pets[0]['pet-name']') back into
+// its segments. An empty path (or just '$') returns no segments.
+func JSONPathSegments(path string) []string {
+	var segments []string
+	path = strings.TrimPrefix(path, "$")
+	for len(path) > 0 {
+		switch {
+		case path[0] == '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			segments = append(segments, path[1:end+1])
+			path = path[end+1:]
+
+		case strings.HasPrefix(path, "['"):
+			var seg strings.Builder
+			i := 2
+			for ; i < len(path) && !strings.HasPrefix(path[i:], "']"); i++ {
+				if path[i] == '\\' && i+1 < len(path) {
+					i++
+				}
+				seg.WriteByte(path[i])
+			}
+			segments = append(segments, seg.String())
+			path = strings.TrimPrefix(path[min(i, len(path)):], "']")
+
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, path[1:end])
+			path = path[min(end+1, len(path)):]
 
-		var b strings.Builder
-		b.WriteString("$")
+		default:
+			// not a path built by JSONPathFromSegments, the rest is kept as a single segment.
+			segments = append(segments, path)
+			path = ""
+		}
+	}
+	return segments
+}
 
-		for _, seg := range e.InstanceLocation {
-			switch {
-			case isNumeric(seg):
-				b.WriteString(fmt.Sprintf("[%s]", seg))
+// SchemaFailureField describes the field (within the validated object) that a schema violation refers to.
+type SchemaFailureField struct {
+	// Name is the name of the field (the last segment of the path).
+	Name string
 
-			case isSimpleIdentifier(seg):
-				b.WriteByte('.')
-				b.WriteString(seg)
+	// Path is the JSONPath of the field, from the root of the validated object.
+	Path string
 
-			default:
-				esc := escapeBracketString(seg)
-				b.WriteString("['")
-				b.WriteString(esc)
-				b.WriteString("']")
+	// Reason is set when the violation has been narrowed down to this field alone (e.g. a single
+	// property rejected by 'additionalProperties'), otherwise it's empty.
+	Reason string
+
+	// Value is the offending value of the field, if it's carried by the violation (e.g. a 'pattern' mismatch).
+	Value string
+}
+
+// ExtractSchemaFailureFields extracts the fields a flattened schema violation refers to. Most violations
+// refer to a single field (the instance location), however an 'additionalProperties' violation is reported
+// against the parent object, so each offending property is broken out as a field of its own. A 'required' violation
+// for a single property refers to the missing property (at whatever level of nesting the object is), rather than to
+// the object it's missing from.
+//
+// The decoded JSON schema the violation was reported against is optional, if supplied, it is used to correct
+// the index of array items validated by 'items' after 'prefixItems' (which the jsonschema library reports
+// relative to the end of the prefix, rather than the start of the array).
+func ExtractSchemaFailureFields(unit jsonschema.OutputUnit, decodedSchema any) []SchemaFailureField {
+	segments := correctTupleInstanceLocation(decodedSchema, unit.KeywordLocation, splitJSONPointer(unit.InstanceLocation))
+	if unit.Error != nil {
+		if ap, ok := unit.Error.Kind.(*kind.AdditionalProperties); ok && len(ap.Properties) > 0 {
+			fields := make([]SchemaFailureField, len(ap.Properties))
+			for i, prop := range ap.Properties {
+				single := &kind.AdditionalProperties{Properties: []string{prop}}
+				fields[i] = SchemaFailureField{
+					Name:   prop,
+					Path:   JSONPathFromSegments(append(append([]string{}, segments...), prop)),
+					Reason: single.LocalizedString(message.NewPrinter(language.Tag{})),
+				}
 			}
+			return fields
 		}
-		return b.String()
+		if req, ok := unit.Error.Kind.(*kind.Required); ok && len(req.Missing) == 1 {
+			// the missing property is the field, rather than the object it's missing from.
+			return []SchemaFailureField{{
+				Name: req.Missing[0],
+				Path: JSONPathFromSegments(append(append([]string{}, segments...), req.Missing[0])),
+			}}
+		}
+	}
+	field := SchemaFailureField{Path: JSONPathFromSegments(segments)}
+	if unit.Error != nil {
+		field.Value = SchemaErrorValue(unit.Error.Kind)
+	}
+	if len(segments) > 0 {
+		field.Name = segments[len(segments)-1]
+	}
+	return []SchemaFailureField{field}
+}
+
+// correctTupleInstanceLocation walks the keyword location of a violation through the decoded schema, keeping
+// track of which instance location segment each keyword applies to. When an 'items' keyword is found alongside
+// 'prefixItems', the index of the array item is shifted by the length of the prefix.
+func correctTupleInstanceLocation(decodedSchema any, keywordLocation string, segments []string) []string {
+	if decodedSchema == nil || len(segments) == 0 {
+		return segments
+	}
+	keywords := splitJSONPointer(unescapeKeywordLocation(keywordLocation))
+	node := decodedSchema
+	instance := 0
+	for i := 0; i < len(keywords); i++ {
+		m, ok := node.(map[string]any)
+		if !ok {
+			break
+		}
+		keyword := keywords[i]
+		switch keyword {
+		case "properties", "patternProperties", "prefixItems":
+			// the next keyword segment is the name / index of the sub-schema.
+			if i+1 >= len(keywords) {
+				return segments
+			}
+			node = schemaChild(m[keyword], keywords[i+1])
+			i++
+			instance++
+		case "items", "additionalItems", "additionalProperties", "unevaluatedItems", "unevaluatedProperties", "contains":
+			if prefix, isTuple := m["prefixItems"].([]any); keyword == "items" && isTuple && instance < len(segments) {
+				if idx, err := strconv.Atoi(segments[instance]); err == nil {
+					segments = append([]string{}, segments...)
+					segments[instance] = strconv.Itoa(idx + len(prefix))
+				}
+			}
+			node = m[keyword]
+			instance++
+		case "allOf", "anyOf", "oneOf", "$defs", "definitions", "dependentSchemas":
+			if i+1 >= len(keywords) {
+				return segments
+			}
+			node = schemaChild(m[keyword], keywords[i+1])
+			i++
+		default:
+			node = m[keyword]
+		}
+	}
+	return segments
+}
+
+// schemaChild returns the child of a decoded schema node by key (for objects) or index (for arrays).
+func schemaChild(node any, key string) any {
+	switch n := node.(type) {
+	case map[string]any:
+		return n[key]
+	case []any:
+		if idx, err := strconv.Atoi(key); err == nil && idx >= 0 && idx < len(n) {
+			return n[idx]
+		}
+	}
+	return nil
+}
+
+// unescapeKeywordLocation decodes the URL escaping applied to a keyword location by the jsonschema library
+// (e.g. '/patternProperties/%5Ex-' becomes '/patternProperties/^x-').
+func unescapeKeywordLocation(location string) string {
+	if unescaped, err := url.PathUnescape(location); err == nil {
+		return unescaped
+	}
+	return location
+}
+
+// splitJSONPointer breaks a JSON pointer (e.g. '/pets/0/name') into its unescaped segments.
+func splitJSONPointer(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(pointer, "/")
+	for i := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segments[i], "~1", "/"), "~0", "~")
 	}
-	return ""
+	return segments
 }
 
 // isNumeric returns true if s is a non‐empty string of digits.
@@ -302,3 +302,59 @@ func TestExtractJSONPathsFromValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONPathSegments(t *testing.T) {
+	assert.Empty(t, JSONPathSegments(""))
+	assert.Empty(t, JSONPathSegments("$"))
+	for _, segments := range [][]string{
+		{"pets", "0", "pet-name"},
+		{"name"},
+		{"0", "1"},
+		{"it's", `back\slash`, "a.b", "[x]"},
+	} {
+		assert.Equal(t, segments, JSONPathSegments(JSONPathFromSegments(segments)))
+	}
+}
+
+func TestJSONPathFromSegments(t *testing.T) {
+	assert.Equal(t, "", JSONPathFromSegments(nil))
+	assert.Equal(t, "$.pets[0]['pet-name']", JSONPathFromSegments([]string{"pets", "0", "pet-name"}))
+}
+
+func TestSplitJSONPointer(t *testing.T) {
+	assert.Nil(t, splitJSONPointer(""))
+	assert.Equal(t, []string{"a/b", "c~d", "0"}, splitJSONPointer("/a~1b/c~0d/0"))
+}
+
+func TestCorrectTupleInstanceLocation(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"point": map[string]any{
+				"prefixItems": []any{map[string]any{"type": "string"}, map[string]any{"type": "number"}},
+				"items":       map[string]any{"type": "boolean"},
+			},
+		},
+	}
+	// the library reports the first item after the prefix as index 0.
+	assert.Equal(t, []string{"point", "2"},
+		correctTupleInstanceLocation(schema, "/properties/point/items/type", []string{"point", "0"}))
+	assert.Equal(t, []string{"point", "1"},
+		correctTupleInstanceLocation(schema, "/properties/point/prefixItems/1/type", []string{"point", "1"}))
+	assert.Equal(t, []string{"point", "0"},
+		correctTupleInstanceLocation(nil, "/properties/point/items/type", []string{"point", "0"}))
+}
+
+func TestCorrectTupleInstanceLocation_PatternProperties(t *testing.T) {
+	schema := map[string]any{
+		"patternProperties": map[string]any{
+			"^x-": map[string]any{
+				"prefixItems": []any{map[string]any{"type": "string"}},
+				"items":       map[string]any{"type": "number"},
+			},
+		},
+	}
+	// the pattern is URL escaped in the keyword location.
+	assert.Equal(t, []string{"x-point", "1"},
+		correctTupleInstanceLocation(schema, "/patternProperties/%5Ex-/items/type", []string{"x-point", "0"}))
+}
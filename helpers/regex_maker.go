@@ -137,3 +137,18 @@ func BraceIndices(s string) ([]int, error) {
 	}
 	return idxs, nil
 }
+
+// PathTemplateParams returns the names of the parameters in a path template, in the order they appear. For example,
+// '/orders/{id}/items/{itemId}' has the parameters 'id' and 'itemId'. An error is returned if the braces of the
+// template are unbalanced.
+func PathTemplateParams(template string) ([]string, error) {
+	idxs, err := BraceIndices(template)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(idxs)/2)
+	for i := 0; i < len(idxs); i += 2 {
+		names = append(names, template[idxs[i]+1:idxs[i+1]-1])
+	}
+	return names, nil
+}
@@ -2,6 +2,9 @@ package helpers
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetRegexForPath(t *testing.T) {
@@ -139,3 +142,20 @@ func equal(a, b []int) bool {
 	}
 	return true
 }
+
+func TestPathTemplateParams(t *testing.T) {
+	names, err := PathTemplateParams("/orders/{id}/items/{itemId}")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "itemId"}, names)
+
+	names, err = PathTemplateParams("/entities('{id}')")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, names)
+
+	names, err = PathTemplateParams("/orders")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, err = PathTemplateParams("/orders/{id")
+	assert.Error(t, err)
+}
@@ -2,7 +2,10 @@ package helpers
 
 import (
 	"bytes"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 
@@ -16,24 +19,126 @@ func ConfigureCompiler(c *jsonschema.Compiler, o *config.ValidationOptions) {
 		return
 	}
 
-	// nil is the default so this is OK.
-	c.UseRegexpEngine(o.RegexEngine)
+	// only set when supplied, so the engine of a custom compiler is not reset.
+	if o.RegexEngine != nil {
+		c.UseRegexpEngine(o.RegexEngine)
+	}
+
+	// patterns the engine cannot compile are skipped rather than failing the whole schema.
+	if o.SkipBadPatterns {
+		c.UseRegexpEngine(SkipBadPatternsEngine(o.RegexEngine))
+	}
+
+	// Schemas that do not declare a draft use this one.
+	if o.SchemaDraft != nil {
+		c.DefaultDraft(o.SchemaDraft)
+	}
 
-	// Enable Format assertions if required.
+	// Enable Format assertions if required. 'byte' (base64 encoded data) is a format defined by OpenAPI, not by JSON
+	// schema, so it's registered here, a custom format with the same name replaces it. 'binary' is not asserted.
 	if o.FormatAssertions {
 		c.AssertFormat()
+		c.RegisterFormat(&jsonschema.Format{Name: ByteFormat, Validate: validateBase64})
 	}
 
 	// Content Assertions
 	if o.ContentAssertions {
 		c.AssertContent()
 	}
+
+	// Custom formats
+	for name, validate := range o.Formats {
+		c.RegisterFormat(&jsonschema.Format{Name: name, Validate: validate})
+	}
 }
 
+// validateBase64 checks a string is base64 encoded (RFC 4648, the standard alphabet), with or without padding.
+func validateBase64(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	if _, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return nil
+	}
+	if _, err := base64.RawStdEncoding.DecodeString(s); err == nil {
+		return nil
+	}
+	return errors.New("value is not valid base64")
+}
+
+// SkipBadPatternsEngine wraps a regex engine (or the standard library engine, if nil), so a pattern that cannot be
+// compiled matches any value instead of failing.
+func SkipBadPatternsEngine(engine jsonschema.RegexpEngine) jsonschema.RegexpEngine {
+	return recordBadPatternsEngine(engine, nil)
+}
+
+// recordBadPatternsEngine works the same as SkipBadPatternsEngine, each pattern that is skipped is passed to record
+// (if not nil).
+func recordBadPatternsEngine(engine jsonschema.RegexpEngine, record func(pattern string, err error)) jsonschema.RegexpEngine {
+	return func(pattern string) (jsonschema.Regexp, error) {
+		var compiled jsonschema.Regexp
+		var err error
+		if engine != nil {
+			compiled, err = engine(pattern)
+		} else {
+			compiled, err = regexp.Compile(pattern)
+		}
+		if err != nil {
+			if record != nil {
+				record(pattern, err)
+			}
+			return skippedPattern(pattern), nil
+		}
+		return compiled, nil
+	}
+}
+
+// SkippedPattern is a 'pattern' that could not be compiled by the regex engine, and was skipped.
+type SkippedPattern struct {
+	Pattern string
+	Err     error
+}
+
+// FindSkippedPatterns compiles a schema, and returns the patterns that were skipped because the regex engine could not
+// compile them. Each pattern is returned once. Nothing is skipped unless config.WithSkipBadPatterns is used.
+func FindSkippedPatterns(name string, jsonSchema []byte, o *config.ValidationOptions) []SkippedPattern {
+	if o == nil || !o.SkipBadPatterns {
+		return nil
+	}
+	var skipped []SkippedPattern
+	seen := make(map[string]bool)
+	engine := recordBadPatternsEngine(o.RegexEngine, func(pattern string, err error) {
+		if !seen[pattern] {
+			seen[pattern] = true
+			skipped = append(skipped, SkippedPattern{Pattern: pattern, Err: err})
+		}
+	})
+	_, _ = compileSchema(name, jsonSchema, o, false, engine)
+	return skipped
+}
+
+// skippedPattern is a pattern that could not be compiled, it matches any value.
+type skippedPattern string
+
+func (s skippedPattern) MatchString(string) bool { return true }
+
+func (s skippedPattern) String() string { return string(s) }
+
 // NewCompilerWithOptions mints a new JSON schema compiler with custom configuration.
 func NewCompilerWithOptions(o *config.ValidationOptions) *jsonschema.Compiler {
-	// Build it
-	c := jsonschema.NewCompiler()
+	// Build it, or use the compiler supplied via the options.
+	var c *jsonschema.Compiler
+	if o != nil && o.CompilerFactory != nil {
+		c = o.CompilerFactory()
+	}
+	if c == nil {
+		c = jsonschema.NewCompiler()
+	}
+
+	// Hold integers to the range of the 'int32' and 'int64' formats.
+	c.RegisterVocabulary(integerVocabulary)
+	c.AssertVocabs()
 
 	// Configure it
 	ConfigureCompiler(c, o)
@@ -44,12 +149,28 @@ func NewCompilerWithOptions(o *config.ValidationOptions) *jsonschema.Compiler {
 
 // NewCompiledSchema establishes a programmatic representation of a JSON Schema document that is used for validation.
 func NewCompiledSchema(name string, jsonSchema []byte, o *config.ValidationOptions) (*jsonschema.Schema, error) {
+	return compileSchema(name, jsonSchema, o, false, nil)
+}
+
+// NewCompiledBodySchema works the same as NewCompiledSchema, for the schema of a request or response body. The
+// options that only apply to bodies (like config.WithIgnoreAdditionalProperties) are applied to the schema.
+func NewCompiledBodySchema(name string, jsonSchema []byte, o *config.ValidationOptions) (*jsonschema.Schema, error) {
+	return compileSchema(name, jsonSchema, o, true, nil)
+}
+
+// compileSchema compiles a schema, engine (if not nil) replaces the regex engine of the compiler.
+func compileSchema(name string, jsonSchema []byte, o *config.ValidationOptions, body bool,
+	engine jsonschema.RegexpEngine,
+) (*jsonschema.Schema, error) {
 	// Fake-Up a resource name for the schema
 	resourceName := fmt.Sprintf("%s.json", name)
 
 	// Establish a compiler with the desired configuration
 	compiler := NewCompilerWithOptions(o)
 	compiler.UseLoader(NewCompilerLoader())
+	if engine != nil {
+		compiler.UseRegexpEngine(engine)
+	}
 
 	// Decode the JSON Schema into a JSON blob.
 	decodedSchema, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonSchema))
@@ -57,6 +178,28 @@ func NewCompiledSchema(name string, jsonSchema []byte, o *config.ValidationOptio
 		return nil, fmt.Errorf("failed to unmarshal JSON schema: %w", err)
 	}
 
+	// OpenAPI 3.0 uses boolean exclusive bounds, convert them to the numeric form understood by the compiler.
+	// Schemas that declare their own draft via '$schema' are left alone, the compiler understands them as-is,
+	// as are schemas compiled as draft 4, which uses the boolean form.
+	if root, ok := decodedSchema.(map[string]any); (!ok || root["$schema"] == nil) &&
+		(o == nil || o.SchemaDraft != jsonschema.Draft4) {
+		normalizeExclusiveBounds(decodedSchema)
+	}
+
+	// Strip any keywords that have been switched off, before the compiler sees them.
+	if o != nil && len(o.IgnoredKeywords) > 0 {
+		ignored := make(map[string]bool, len(o.IgnoredKeywords))
+		for _, keyword := range o.IgnoredKeywords {
+			ignored[keyword] = true
+		}
+		stripKeywords(decodedSchema, ignored)
+	}
+
+	// Allow undeclared properties in bodies, if asked to.
+	if body && o != nil && o.IgnoreAdditional {
+		relaxAdditionalProperties(decodedSchema)
+	}
+
 	// Give our schema to the compiler.
 	if err = compiler.AddResource(resourceName, decodedSchema); err != nil {
 		return nil, fmt.Errorf("failed to add resource to schema compiler: %w", err)
@@ -71,3 +214,115 @@ func NewCompiledSchema(name string, jsonSchema []byte, o *config.ValidationOptio
 	// Done.
 	return jsch, nil
 }
+
+// schemaMapKeywords are keywords that hold a map of names to schemas, rather than a schema.
+var schemaMapKeywords = map[string]bool{
+	"properties":        true,
+	"patternProperties": true,
+	"dependentSchemas":  true,
+	"$defs":             true,
+	"definitions":       true,
+}
+
+// normalizeExclusiveBounds walks a decoded schema, and re-writes OpenAPI 3.0 (draft 4) style boolean
+// 'exclusiveMinimum' and 'exclusiveMaximum' values into the numeric form used by OpenAPI 3.1. For example,
+// 'minimum: 0, exclusiveMinimum: true' becomes 'exclusiveMinimum: 0'. Numeric bounds are left as-is.
+func normalizeExclusiveBounds(schema any) {
+	switch s := schema.(type) {
+	case map[string]any:
+		normalizeExclusiveBound(s, "exclusiveMinimum", "minimum")
+		normalizeExclusiveBound(s, "exclusiveMaximum", "maximum")
+		for k, v := range s {
+			if valueKeywords[k] {
+				continue
+			}
+			if m, ok := v.(map[string]any); ok && schemaMapKeywords[k] {
+				for _, child := range m {
+					normalizeExclusiveBounds(child)
+				}
+				continue
+			}
+			normalizeExclusiveBounds(v)
+		}
+	case []any:
+		for _, v := range s {
+			normalizeExclusiveBounds(v)
+		}
+	}
+}
+
+// valueKeywords are keywords that hold values (which may look like schemas), rather than schemas.
+var valueKeywords = map[string]bool{
+	"const":    true,
+	"enum":     true,
+	"default":  true,
+	"example":  true,
+	"examples": true,
+}
+
+// stripKeywords walks a decoded schema, and removes every keyword in ignored from it and its sub-schemas. The names
+// of properties, and the values held by keywords like 'enum' and 'default', are left alone.
+func stripKeywords(schema any, ignored map[string]bool) {
+	switch s := schema.(type) {
+	case map[string]any:
+		for k, v := range s {
+			if ignored[k] {
+				delete(s, k)
+				continue
+			}
+			if valueKeywords[k] {
+				continue
+			}
+			if m, ok := v.(map[string]any); ok && schemaMapKeywords[k] {
+				for _, child := range m {
+					stripKeywords(child, ignored)
+				}
+				continue
+			}
+			stripKeywords(v, ignored)
+		}
+	case []any:
+		for _, v := range s {
+			stripKeywords(v, ignored)
+		}
+	}
+}
+
+// relaxAdditionalProperties walks a decoded schema, and removes every 'additionalProperties: false' from it and its
+// sub-schemas, so properties that are not declared are allowed.
+func relaxAdditionalProperties(schema any) {
+	switch s := schema.(type) {
+	case map[string]any:
+		if additional, ok := s["additionalProperties"].(bool); ok && !additional {
+			delete(s, "additionalProperties")
+		}
+		for k, v := range s {
+			if valueKeywords[k] {
+				continue
+			}
+			if m, ok := v.(map[string]any); ok && schemaMapKeywords[k] {
+				for _, child := range m {
+					relaxAdditionalProperties(child)
+				}
+				continue
+			}
+			relaxAdditionalProperties(v)
+		}
+	case []any:
+		for _, v := range s {
+			relaxAdditionalProperties(v)
+		}
+	}
+}
+
+func normalizeExclusiveBound(schema map[string]any, exclusiveKey, boundKey string) {
+	exclusive, ok := schema[exclusiveKey].(bool)
+	if !ok {
+		return
+	}
+	delete(schema, exclusiveKey)
+	if bound, found := schema[boundKey]; found && exclusive {
+		schema[exclusiveKey] = bound
+		delete(schema, boundKey)
+	}
+}
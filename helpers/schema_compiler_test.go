@@ -1,8 +1,11 @@
 package helpers
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -111,3 +114,226 @@ func Test_ValidJSONSchemaWithIncompleteContent(t *testing.T) {
 	assert.Error(t, err, "Expected an error to be thrown")
 	assert.Nil(t, jsch, "invalid schema compiled!")
 }
+
+func Test_NormalizeExclusiveBounds(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"price": map[string]any{
+				"minimum":          json.Number("0"),
+				"exclusiveMinimum": true,
+				"maximum":          json.Number("10"),
+				"exclusiveMaximum": false,
+			},
+			"exclusiveMinimum": true,
+		},
+		"items": []any{map[string]any{"maximum": json.Number("5"), "exclusiveMaximum": true}},
+		"enum":  []any{map[string]any{"minimum": json.Number("1"), "exclusiveMinimum": true}},
+	}
+
+	normalizeExclusiveBounds(schema)
+
+	// values held by keywords like 'enum' are not schemas, they are left alone.
+	assert.Equal(t, []any{map[string]any{"minimum": json.Number("1"), "exclusiveMinimum": true}}, schema["enum"])
+
+	price := schema["properties"].(map[string]any)["price"].(map[string]any)
+	assert.Equal(t, map[string]any{"exclusiveMinimum": json.Number("0"), "maximum": json.Number("10")}, price)
+	assert.Equal(t, true, schema["properties"].(map[string]any)["exclusiveMinimum"])
+	assert.Equal(t, map[string]any{"exclusiveMaximum": json.Number("5")}, schema["items"].([]any)[0])
+}
+
+func Test_BooleanExclusiveBoundsCompile(t *testing.T) {
+	const schema = `{"type": "number", "minimum": 0, "exclusiveMinimum": true}`
+
+	jsch, err := NewCompiledSchema("test", []byte(schema), nil)
+	require.NoError(t, err)
+
+	assert.Error(t, jsch.Validate(json.Number("0")))
+	assert.NoError(t, jsch.Validate(json.Number("0.1")))
+}
+
+func Test_CustomFormat(t *testing.T) {
+	valOptions := config.NewValidationOptions(config.WithFormatValidator("sku", func(v any) error {
+		if s, ok := v.(string); ok && len(s) != 8 {
+			return fmt.Errorf("'%s' is not a sku", s)
+		}
+		return nil
+	}))
+
+	jsch, err := NewCompiledSchema("test", []byte(`{"type": "string", "format": "sku"}`), valOptions)
+	require.NoError(t, err)
+
+	assert.NoError(t, jsch.Validate("SKU-1234"))
+	assert.Error(t, jsch.Validate("SKU-12"))
+}
+
+func Test_ByteFormat(t *testing.T) {
+	schema := []byte(`{"type": "string", "format": "byte"}`)
+
+	jsch, err := NewCompiledSchema("test", schema, config.NewValidationOptions(config.WithFormatAssertions()))
+	require.NoError(t, err)
+
+	assert.NoError(t, jsch.Validate("aGVsbG8gd29ybGQ="))
+	assert.NoError(t, jsch.Validate("aGVsbG8gd29ybGQ"))
+	err = jsch.Validate("hello world!")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "value is not valid base64")
+
+	// 'binary' is a passthrough, and 'byte' is only asserted with format assertions.
+	jsch, err = NewCompiledSchema("test", []byte(`{"type": "string", "format": "binary"}`),
+		config.NewValidationOptions(config.WithFormatAssertions()))
+	require.NoError(t, err)
+	assert.NoError(t, jsch.Validate("\x00\x01 not base64"))
+
+	jsch, err = NewCompiledSchema("test", schema, config.NewValidationOptions())
+	require.NoError(t, err)
+	assert.NoError(t, jsch.Validate("hello world!"))
+}
+
+func Test_SchemaDraft(t *testing.T) {
+	// 'const' is not a draft 4 keyword, so it's ignored.
+	valOptions := config.NewValidationOptions(config.WithSchemaDraft(jsonschema.Draft4))
+	jsch, err := NewCompiledSchema("test", []byte(`{"const": 1}`), valOptions)
+	require.NoError(t, err)
+	assert.NoError(t, jsch.Validate(json.Number("2")))
+
+	// draft 4 uses boolean exclusive bounds, they are compiled as-is.
+	jsch, err = NewCompiledSchema("test", []byte(`{"type": "number", "minimum": 0, "exclusiveMinimum": true}`), valOptions)
+	require.NoError(t, err)
+	assert.Error(t, jsch.Validate(json.Number("0")))
+	assert.NoError(t, jsch.Validate(json.Number("0.1")))
+
+	jsch, err = NewCompiledSchema("test", []byte(`{"const": 1}`), config.NewValidationOptions())
+	require.NoError(t, err)
+	assert.Error(t, jsch.Validate(json.Number("2")))
+}
+
+func Test_CompilerFactory(t *testing.T) {
+	minted := 0
+	valOptions := config.NewValidationOptions(config.WithCompilerFactory(func() *jsonschema.Compiler {
+		minted++
+		c := jsonschema.NewCompiler()
+		c.AssertFormat()
+		c.RegisterFormat(&jsonschema.Format{Name: "sku", Validate: func(v any) error {
+			if s, ok := v.(string); ok && len(s) != 8 {
+				return fmt.Errorf("'%s' is not a sku", s)
+			}
+			return nil
+		}})
+		return c
+	}))
+
+	jsch, err := NewCompiledSchema("test", []byte(`{"type": "string", "format": "sku"}`), valOptions)
+	require.NoError(t, err)
+	assert.Equal(t, 1, minted)
+
+	assert.NoError(t, jsch.Validate("SKU-1234"))
+	assert.Error(t, jsch.Validate("SKU-12"))
+}
+
+func Test_SkipBadPatterns(t *testing.T) {
+	schema := []byte(`{"type": "object", "properties": {
+		"password": {"type": "string", "pattern": "^(?=.*[0-9]).{8,}$"},
+		"code": {"type": "string", "pattern": "^[A-Z]{3}$"}}}`)
+
+	// RE2 does not support lookaheads, so the schema cannot be compiled.
+	_, err := NewCompiledSchema("test", schema, config.NewValidationOptions())
+	require.Error(t, err)
+
+	valOptions := config.NewValidationOptions(config.WithSkipBadPatterns())
+	jsch, err := NewCompiledSchema("test", schema, valOptions)
+	require.NoError(t, err)
+
+	// the bad pattern matches anything, the good pattern is still enforced.
+	assert.NoError(t, jsch.Validate(map[string]any{"password": "nope", "code": "ABC"}))
+	assert.Error(t, jsch.Validate(map[string]any{"password": "nope", "code": "abc"}))
+
+	// the skipped pattern is found for the schema, each time it's compiled.
+	skipped := FindSkippedPatterns("test", schema, valOptions)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "^(?=.*[0-9]).{8,}$", skipped[0].Pattern)
+	assert.Error(t, skipped[0].Err)
+	assert.Len(t, FindSkippedPatterns("test", schema, valOptions), 1)
+
+	// nothing is skipped without the option.
+	assert.Empty(t, FindSkippedPatterns("test", schema, config.NewValidationOptions()))
+}
+
+func Test_IgnoredKeywords(t *testing.T) {
+	schema := []byte(`{"type": "object", "maxProperties": 3,
+		"properties": {
+			"name": {"type": "string", "maxLength": 3, "default": {"maxLength": 1}},
+			"maxLength": {"type": "integer"},
+			"tags": {"type": "array", "items": {"type": "string", "maxLength": 2}}}}`)
+
+	valOptions := config.NewValidationOptions(config.WithIgnoredKeywords([]string{"maxLength"}))
+	jsch, err := NewCompiledSchema("test", schema, valOptions)
+	require.NoError(t, err)
+
+	// 'maxLength' is not checked anywhere, the property named 'maxLength' and the other keywords are kept.
+	assert.NoError(t, jsch.Validate(map[string]any{"name": "Big Mac", "tags": []any{"cheese"}}))
+	assert.Error(t, jsch.Validate(map[string]any{"maxLength": "three"}))
+	assert.Error(t, jsch.Validate(map[string]any{"a": 1.0, "b": 2.0, "c": 3.0, "d": 4.0}))
+
+	jsch, err = NewCompiledSchema("test", schema, config.NewValidationOptions())
+	require.NoError(t, err)
+	assert.Error(t, jsch.Validate(map[string]any{"name": "Big Mac"}))
+}
+
+func Test_IgnoreAdditionalProperties(t *testing.T) {
+	schema := []byte(`{"type": "object", "additionalProperties": false,
+		"properties": {
+			"name": {"type": "string"},
+			"sauce": {"type": "object", "additionalProperties": false, "properties": {"hot": {"type": "boolean"}}},
+			"extras": {"type": "object", "additionalProperties": {"type": "integer"}}}}`)
+	instance := map[string]any{"name": "Big Mac", "fries": true, "sauce": map[string]any{"hot": true, "mild": true}}
+
+	valOptions := config.NewValidationOptions(config.WithIgnoreAdditionalProperties(true))
+	jsch, err := NewCompiledBodySchema("test", schema, valOptions)
+	require.NoError(t, err)
+	assert.NoError(t, jsch.Validate(instance))
+
+	// a schema for additional properties is still checked.
+	assert.Error(t, jsch.Validate(map[string]any{"extras": map[string]any{"cheese": "lots"}}))
+
+	// only body schemas are relaxed.
+	jsch, err = NewCompiledSchema("test", schema, valOptions)
+	require.NoError(t, err)
+	assert.Error(t, jsch.Validate(instance))
+
+	jsch, err = NewCompiledBodySchema("test", schema, config.NewValidationOptions())
+	require.NoError(t, err)
+	assert.Error(t, jsch.Validate(instance))
+}
+
+func Test_StrictIntegers(t *testing.T) {
+	jsch, err := NewCompiledSchema("test", []byte(`{"type": "integer", "format": "int32"}`), nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, jsch.Validate(json.Number("2147483647")))
+	assert.NoError(t, jsch.Validate(float64(-2147483648)))
+
+	var ve *jsonschema.ValidationError
+	require.ErrorAs(t, jsch.Validate(json.Number("2.5")), &ve)
+	units := FlattenSchemaErrors(ve, json.Number("2.5"))
+	require.Len(t, units, 1)
+	assert.Equal(t, "value 2.5 is not an integer", SchemaErrorMessage(units[0].Error.Kind))
+	assert.Equal(t, "2.5", SchemaErrorValue(units[0].Error.Kind))
+
+	require.ErrorAs(t, jsch.Validate(json.Number("2147483648")), &ve)
+	units = FlattenSchemaErrors(ve, nil)
+	require.Len(t, units, 1)
+	assert.Equal(t, "value exceeds int32 range", SchemaErrorMessage(units[0].Error.Kind))
+	assert.Equal(t, "/format", units[0].KeywordLocation)
+
+	jsch, err = NewCompiledSchema("test", []byte(`{"type": "integer", "format": "int64"}`), nil)
+	require.NoError(t, err)
+	assert.NoError(t, jsch.Validate(json.Number("9223372036854775807")))
+	require.ErrorAs(t, jsch.Validate(json.Number("9223372036854775808")), &ve)
+	assert.Equal(t, "value exceeds int64 range", SchemaErrorMessage(FlattenSchemaErrors(ve, nil)[0].Error.Kind))
+
+	// numbers are not held to being whole.
+	jsch, err = NewCompiledSchema("test", []byte(`{"type": "number"}`), nil)
+	require.NoError(t, err)
+	assert.NoError(t, jsch.Validate(json.Number("2.5")))
+}
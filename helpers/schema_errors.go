@@ -0,0 +1,221 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// SchemaErrorMessage returns a human-readable message for a schema violation. The messages rendered by the
+// jsonschema library are used, except for a few kinds (const, pattern and exclusive bounds) that are re-worded to make them clearer.
+func SchemaErrorMessage(k jsonschema.ErrorKind) string {
+	switch ek := k.(type) {
+	case *kind.Const:
+		switch ek.Want.(type) {
+		case []any, map[string]any:
+		default:
+			return fmt.Sprintf("value must be constant %s", displayValue(ek.Want))
+		}
+	case *kind.Pattern:
+		return fmt.Sprintf("value does not match pattern '%s'", ek.Want)
+	case *kind.ExclusiveMinimum:
+		want, _ := ek.Want.Float64()
+		return fmt.Sprintf("value must be greater than %v", want)
+	case *kind.ExclusiveMaximum:
+		want, _ := ek.Want.Float64()
+		return fmt.Sprintf("value must be less than %v", want)
+	case *kind.MultipleOf:
+		// the values are exact (decimal) fractions, so a fractional 'multipleOf' (e.g. 0.01) is not
+		// subject to binary floating point errors, they are only rounded for display.
+		got, _ := ek.Got.Float64()
+		want, _ := ek.Want.Float64()
+		return fmt.Sprintf("value %v is not a multiple of %v", got, want)
+	case *kind.MinProperties:
+		return fmt.Sprintf("object must have at least %s, but has %d", countProperties(ek.Want), ek.Got)
+	case *kind.MaxProperties:
+		return fmt.Sprintf("object must have at most %s, but has %d", countProperties(ek.Want), ek.Got)
+	case *kind.Not:
+		return "value must not match the 'not' schema"
+	case *kind.DependentRequired:
+		if len(ek.Missing) == 1 {
+			return fmt.Sprintf("property '%s' is required when '%s' is present", ek.Missing[0], ek.Prop)
+		}
+		return fmt.Sprintf("properties '%s' are required when '%s' is present", strings.Join(ek.Missing, "', '"), ek.Prop)
+	case *kind.ContentEncoding:
+		return fmt.Sprintf("value is not '%s' encoded: %v", ek.Want, ek.Err)
+	case *kind.ContentMediaType:
+		return fmt.Sprintf("value is not valid '%s' content: %v", ek.Want, ek.Err)
+	case *kind.ContentSchema:
+		return "the embedded content does not match the 'contentSchema'"
+	case *kind.Format:
+		switch ek.Want {
+		case "date-time", "date", "time", "uuid", "uri", "uri-reference", "ipv4", "ipv6", "hostname":
+			return fmt.Sprintf("value is not a valid %s", ek.Want)
+		}
+	}
+	return k.LocalizedString(message.NewPrinter(language.Tag{}))
+}
+
+// ConditionalReason prefixes the reason for a violation reported by the 'then' or 'else' branch of an 'if' schema,
+// with the branch that applied (and so failed), as the violation is otherwise hard to tell apart from one that
+// always applies. For nested conditionals, the innermost branch is used. Other reasons are returned unchanged.
+func ConditionalReason(keywordLocation, reason string) string {
+	branch := ""
+	keywords := splitJSONPointer(unescapeKeywordLocation(keywordLocation))
+	for i := 0; i < len(keywords); i++ {
+		switch keywords[i] {
+		case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+			// the next keyword is the name of a sub-schema, which could be 'then' or 'else'.
+			i++
+		case "then", "else":
+			branch = keywords[i]
+		}
+	}
+	switch branch {
+	case "then":
+		return fmt.Sprintf("'if' matched, so 'then' applies: %s", reason)
+	case "else":
+		return fmt.Sprintf("'if' did not match, so 'else' applies: %s", reason)
+	}
+	return reason
+}
+
+// FlattenSchemaErrors returns the flattened (basic) output units of a schema validation error. The jsonschema
+// library leaves the 'not' keyword out of the keyword location of a failed 'not' schema, so it is restored here,
+// otherwise a 'not' failure at the root of a schema has no location at all. The 'type' failure of a number that
+// is not an integer is reported with the value (which is looked up in the validated instance) instead. The failures
+// of a document embedded in a string (see 'contentSchema') are nested under the location of the string.
+func FlattenSchemaErrors(ve *jsonschema.ValidationError, instance any) []jsonschema.OutputUnit {
+	units := ve.BasicOutput().Errors
+	var embedded []jsonschema.OutputUnit
+	for i := range units {
+		parent := embeddingUnit(embedded, units[i].KeywordLocation)
+		if parent != nil {
+			units[i].InstanceLocation = parent.InstanceLocation + units[i].InstanceLocation
+		}
+		if units[i].Error == nil {
+			continue
+		}
+		if _, ok := units[i].Error.Kind.(*kind.ContentSchema); ok {
+			// the jsonschema library reports the failure at the root of the embedded document, not at the string.
+			if parent == nil {
+				units[i].InstanceLocation = embeddedLocation(units[i].KeywordLocation, instance)
+			}
+			embedded = append(embedded, units[i])
+		}
+		switch k := units[i].Error.Kind.(type) {
+		case *kind.Not:
+			units[i].KeywordLocation += "/not"
+			if units[i].AbsoluteKeywordLocation != "" {
+				units[i].AbsoluteKeywordLocation += "/not"
+			}
+		case *kind.Type:
+			if notInteger := notAnInteger(k, instance, units[i].InstanceLocation); notInteger != nil {
+				units[i].Error.Kind = notInteger
+			}
+		}
+	}
+	return units
+}
+
+// embeddingUnit returns the innermost 'contentSchema' failure a keyword location is part of, or nil. The instance
+// location of the failure is already relative to the validated instance.
+func embeddingUnit(embedded []jsonschema.OutputUnit, keywordLocation string) *jsonschema.OutputUnit {
+	var parent *jsonschema.OutputUnit
+	for i := range embedded {
+		if strings.HasPrefix(keywordLocation, embedded[i].KeywordLocation+"/") &&
+			(parent == nil || len(embedded[i].KeywordLocation) > len(parent.KeywordLocation)) {
+			parent = &embedded[i]
+		}
+	}
+	return parent
+}
+
+// embeddedLocation returns the instance location of the string that holds an embedded document, found by following
+// the keyword location of its 'contentSchema' through the instance. Where the keyword location does not tell which
+// value it applies to (e.g. which of the 'items'), the location of the container is returned.
+func embeddedLocation(keywordLocation string, instance any) string {
+	keywords := splitJSONPointer(unescapeKeywordLocation(strings.TrimSuffix(keywordLocation, "/contentSchema")))
+	var location strings.Builder
+	value := instance
+	for i := 0; i < len(keywords); i++ {
+		switch keywords[i] {
+		case "properties":
+			object, ok := value.(map[string]any)
+			if !ok || i+1 == len(keywords) {
+				return location.String()
+			}
+			i++
+			value = object[keywords[i]]
+			location.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(keywords[i], "~", "~0"), "/", "~1"))
+		case "prefixItems":
+			array, ok := value.([]any)
+			if !ok || i+1 == len(keywords) {
+				return location.String()
+			}
+			i++
+			index, err := strconv.Atoi(keywords[i])
+			if err != nil || index >= len(array) {
+				return location.String()
+			}
+			value = array[index]
+			location.WriteString("/" + keywords[i])
+		case "allOf", "anyOf", "oneOf", "$defs", "definitions", "dependentSchemas":
+			// the next keyword is the index or name of a sub-schema, that applies to the same value.
+			i++
+		case "not", "if", "then", "else":
+		default:
+			return location.String()
+		}
+	}
+	return location.String()
+}
+
+// SchemaErrorValue returns the offending value carried by a schema violation (for example, the string that
+// failed to match a 'pattern'), rendered for display. An empty string is returned if the kind does not carry it.
+func SchemaErrorValue(k jsonschema.ErrorKind) string {
+	switch ek := k.(type) {
+	case *kind.Pattern:
+		return ek.Got
+	case *NotAnInteger:
+		return ek.Got
+	case *IntegerOutOfRange:
+		return ek.Got
+	case *kind.Format:
+		return fmt.Sprint(ek.Got)
+	case *kind.Enum:
+		return displayValue(ek.Got)
+	case *kind.Const:
+		return displayValue(ek.Got)
+	}
+	return ""
+}
+
+// countProperties renders a number of properties, e.g. '1 property' or '10 properties'.
+func countProperties(n int) string {
+	if n == 1 {
+		return "1 property"
+	}
+	return fmt.Sprintf("%d properties", n)
+}
+
+// displayValue renders a value for use in a message, strings are quoted, everything else is rendered as JSON.
+func displayValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("'%s'", s)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,148 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaErrorMessage_Const(t *testing.T) {
+	assert.Equal(t, "value must be constant 'order.created'",
+		SchemaErrorMessage(&kind.Const{Got: "order.updated", Want: "order.created"}))
+	assert.Equal(t, "value must be constant 2", SchemaErrorMessage(&kind.Const{Got: 1.0, Want: 2.0}))
+	assert.Equal(t, "value must be constant true", SchemaErrorMessage(&kind.Const{Got: false, Want: true}))
+	assert.Equal(t, "const failed", SchemaErrorMessage(&kind.Const{Want: map[string]any{}}))
+}
+
+func TestSchemaErrorMessage_ExclusiveBounds(t *testing.T) {
+	assert.Equal(t, "value must be greater than 0",
+		SchemaErrorMessage(&kind.ExclusiveMinimum{Got: big.NewRat(0, 1), Want: big.NewRat(0, 1)}))
+	assert.Equal(t, "value must be less than 2.5",
+		SchemaErrorMessage(&kind.ExclusiveMaximum{Got: big.NewRat(3, 1), Want: big.NewRat(5, 2)}))
+}
+
+func TestSchemaErrorMessage_Pattern(t *testing.T) {
+	assert.Equal(t, "value does not match pattern '^[a-z]+$'",
+		SchemaErrorMessage(&kind.Pattern{Got: "Big Mac", Want: "^[a-z]+$"}))
+}
+
+func TestSchemaErrorMessage_Not(t *testing.T) {
+	assert.Equal(t, "value must not match the 'not' schema", SchemaErrorMessage(&kind.Not{}))
+}
+
+func TestSchemaErrorMessage_DateTimeFormats(t *testing.T) {
+	assert.Equal(t, "value is not a valid date-time",
+		SchemaErrorMessage(&kind.Format{Got: "2023-13-99", Want: "date-time"}))
+	assert.Equal(t, "value is not a valid date", SchemaErrorMessage(&kind.Format{Got: "2023-02-30", Want: "date"}))
+	assert.Equal(t, "value is not a valid time", SchemaErrorMessage(&kind.Format{Got: "25:99:00", Want: "time"}))
+	assert.Contains(t, SchemaErrorMessage(&kind.Format{Got: "nope", Want: "email", Err: assert.AnError}), "nope")
+}
+
+func TestSchemaErrorMessage_NetworkFormats(t *testing.T) {
+	for _, format := range []string{"uuid", "uri", "uri-reference", "ipv4", "ipv6", "hostname"} {
+		assert.Equal(t, "value is not a valid "+format,
+			SchemaErrorMessage(&kind.Format{Got: "nope", Want: format, Err: assert.AnError}))
+	}
+}
+
+func TestSchemaErrorMessage_MultipleOf(t *testing.T) {
+	assert.Equal(t, "value 7 is not a multiple of 5",
+		SchemaErrorMessage(&kind.MultipleOf{Got: big.NewRat(7, 1), Want: big.NewRat(5, 1)}))
+	assert.Equal(t, "value 19.995 is not a multiple of 0.01",
+		SchemaErrorMessage(&kind.MultipleOf{Got: big.NewRat(19995, 1000), Want: big.NewRat(1, 100)}))
+}
+
+func TestSchemaErrorMessage_PropertyCounts(t *testing.T) {
+	assert.Equal(t, "object must have at least 1 property, but has 0",
+		SchemaErrorMessage(&kind.MinProperties{Got: 0, Want: 1}))
+	assert.Equal(t, "object must have at least 2 properties, but has 1",
+		SchemaErrorMessage(&kind.MinProperties{Got: 1, Want: 2}))
+	assert.Equal(t, "object must have at most 10 properties, but has 12",
+		SchemaErrorMessage(&kind.MaxProperties{Got: 12, Want: 10}))
+}
+
+func TestSchemaErrorMessage_DependentRequired(t *testing.T) {
+	assert.Equal(t, "property 'cvv' is required when 'card' is present",
+		SchemaErrorMessage(&kind.DependentRequired{Prop: "card", Missing: []string{"cvv"}}))
+	assert.Equal(t, "properties 'cvv', 'expiry' are required when 'card' is present",
+		SchemaErrorMessage(&kind.DependentRequired{Prop: "card", Missing: []string{"cvv", "expiry"}}))
+}
+
+func TestFlattenSchemaErrors_Not(t *testing.T) {
+	schema := []byte(`{"type": "object", "not": {"required": ["legacyId"]},
+		"properties": {"name": {"type": "string", "not": {"const": "whopper"}}}}`)
+	jsch, err := NewCompiledSchema("test", schema, nil)
+	require.NoError(t, err)
+
+	var ve *jsonschema.ValidationError
+	require.ErrorAs(t, jsch.Validate(map[string]any{"legacyId": 1.0, "name": "whopper"}), &ve)
+
+	var locations []string
+	for _, unit := range FlattenSchemaErrors(ve, nil) {
+		if unit.Error != nil {
+			locations = append(locations, unit.KeywordLocation)
+		}
+	}
+	assert.ElementsMatch(t, []string{"/not", "/properties/name/not"}, locations)
+}
+
+func TestFlattenSchemaErrors_ContentSchema(t *testing.T) {
+	schema := []byte(`{"type": "object", "properties": {"doc": {"type": "string",
+		"contentMediaType": "application/json", "contentSchema": {"properties": {"name": {"type": "string"}}}}}}`)
+	jsch, err := NewCompiledSchema("test", schema, config.NewValidationOptions(config.WithContentAssertions()))
+	require.NoError(t, err)
+
+	instance := map[string]any{"doc": `{"name": 1}`}
+	var ve *jsonschema.ValidationError
+	require.ErrorAs(t, jsch.Validate(instance), &ve)
+
+	locations := map[string]string{}
+	for _, unit := range FlattenSchemaErrors(ve, instance) {
+		if unit.Error != nil {
+			locations[unit.KeywordLocation] = unit.InstanceLocation
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"/properties/doc/contentSchema":                      "/doc",
+		"/properties/doc/contentSchema/properties/name/type": "/doc/name",
+	}, locations)
+}
+
+func TestSchemaErrorMessage_Content(t *testing.T) {
+	assert.Equal(t, "value is not 'base64' encoded: illegal base64 data at input byte 3",
+		SchemaErrorMessage(&kind.ContentEncoding{Want: "base64", Err: errors.New("illegal base64 data at input byte 3")}))
+	assert.Equal(t, "the embedded content does not match the 'contentSchema'", SchemaErrorMessage(&kind.ContentSchema{}))
+}
+
+func TestSchemaErrorValue(t *testing.T) {
+	assert.Equal(t, "Big Mac", SchemaErrorValue(&kind.Pattern{Got: "Big Mac", Want: "^[a-z]+$"}))
+	assert.Equal(t, "'pickles'", SchemaErrorValue(&kind.Enum{Got: "pickles", Want: []any{"cheese"}}))
+	assert.Equal(t, "1", SchemaErrorValue(&kind.Const{Got: 1.0, Want: 2.0}))
+	assert.Empty(t, SchemaErrorValue(&kind.Required{Missing: []string{"name"}}))
+}
+
+func TestSchemaErrorMessage_Default(t *testing.T) {
+	assert.Equal(t, "missing property 'name'", SchemaErrorMessage(&kind.Required{Missing: []string{"name"}}))
+}
+
+func TestConditionalReason(t *testing.T) {
+	assert.Equal(t, "'if' matched, so 'then' applies: missing property 'bark'",
+		ConditionalReason("/then/required", "missing property 'bark'"))
+	assert.Equal(t, "'if' did not match, so 'else' applies: missing property 'meow'",
+		ConditionalReason("/properties/pet/else/required", "missing property 'meow'"))
+	assert.Equal(t, "'if' did not match, so 'else' applies: value must be a string",
+		ConditionalReason("/then/properties/owner/else/type", "value must be a string"))
+
+	// properties named 'then' or 'else' are not branches.
+	assert.Equal(t, "value must be a string", ConditionalReason("/properties/then/type", "value must be a string"))
+	assert.Equal(t, "value must be a string", ConditionalReason("/properties/name/type", "value must be a string"))
+}
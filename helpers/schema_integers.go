@@ -0,0 +1,144 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/message"
+)
+
+// integerVocabulary holds integers to the range of their 'int32' or 'int64' format. The formats are only annotations
+// in JSON schema, so they are not asserted otherwise.
+var integerVocabulary = &jsonschema.Vocabulary{
+	URL:     "https://pb33f.io/libopenapi-validator/vocab/integers",
+	Compile: compileIntegerChecks,
+}
+
+// integerRanges are the bounds of the integer formats defined by OpenAPI.
+var integerRanges = map[string][2]int64{
+	"int32": {math.MinInt32, math.MaxInt32},
+	"int64": {math.MinInt64, math.MaxInt64},
+}
+
+// NotAnInteger is reported (in place of the 'type' failure) when the value of a schema that is typed as an
+// 'integer' is a number that is not whole, so the value is part of the message.
+type NotAnInteger struct {
+	Got string
+}
+
+func (*NotAnInteger) KeywordPath() []string {
+	return []string{"type"}
+}
+
+func (k *NotAnInteger) LocalizedString(*message.Printer) string {
+	return fmt.Sprintf("value %s is not an integer", k.Got)
+}
+
+// IntegerOutOfRange is reported when an integer does not fit the range of its 'int32' or 'int64' format.
+type IntegerOutOfRange struct {
+	Format string
+	Got    string
+}
+
+func (*IntegerOutOfRange) KeywordPath() []string {
+	return []string{"format"}
+}
+
+func (k *IntegerOutOfRange) LocalizedString(*message.Printer) string {
+	return fmt.Sprintf("value exceeds %s range", k.Format)
+}
+
+type integerRange struct {
+	format string
+}
+
+func compileIntegerChecks(_ *jsonschema.CompilerContext, obj map[string]any) (jsonschema.SchemaExt, error) {
+	if format, ok := obj["format"].(string); ok {
+		if _, found := integerRanges[format]; found {
+			return &integerRange{format: format}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *integerRange) Validate(ctx *jsonschema.ValidatorContext, v any) {
+	got, display := numberValue(v)
+	if got == nil || !got.IsInt() {
+		return
+	}
+	bounds := integerRanges[r.format]
+	minimum, maximum := new(big.Rat).SetInt64(bounds[0]), new(big.Rat).SetInt64(bounds[1])
+	if _, isFloat := v.(float64); isFloat {
+		// a float (e.g. a decoded parameter) is only as precise as a float, so are the bounds it's held to.
+		minimum.SetFloat64(float64(bounds[0]))
+		maximum.SetFloat64(float64(bounds[1]))
+	}
+	if got.Cmp(minimum) < 0 || got.Cmp(maximum) > 0 {
+		ctx.AddError(&IntegerOutOfRange{Format: r.format, Got: display})
+	}
+}
+
+// notAnInteger returns the kind to report in place of a 'type' failure, if the failure is a number that is not
+// an integer. The value is looked up in the instance that was validated, by its location (a JSON pointer).
+func notAnInteger(k *kind.Type, instance any, location string) *NotAnInteger {
+	if k.Got != Number || !slices.Contains(k.Want, Integer) || slices.Contains(k.Want, Number) {
+		return nil
+	}
+	value := instance
+	if location != "" {
+		for _, segment := range strings.Split(strings.TrimPrefix(location, "/"), "/") {
+			segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+			switch v := value.(type) {
+			case map[string]any:
+				value = v[segment]
+			case []any:
+				i, err := strconv.Atoi(segment)
+				if err != nil || i < 0 || i >= len(v) {
+					return nil
+				}
+				value = v[i]
+			default:
+				return nil
+			}
+		}
+	}
+	if got, display := numberValue(value); got != nil && !got.IsInt() {
+		return &NotAnInteger{Got: display}
+	}
+	return nil
+}
+
+// numberValue returns the exact value of a number, and how it is displayed. Nil is returned for anything else.
+func numberValue(v any) (*big.Rat, string) {
+	switch n := v.(type) {
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(string(n)); ok {
+			return r, string(n)
+		}
+	case float64:
+		if r := new(big.Rat).SetFloat64(n); r != nil {
+			return r, strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	case float32:
+		if r := new(big.Rat).SetFloat64(float64(n)); r != nil {
+			return r, strconv.FormatFloat(float64(n), 'f', -1, 32)
+		}
+	case int:
+		return new(big.Rat).SetInt64(int64(n)), strconv.Itoa(n)
+	case int32:
+		return new(big.Rat).SetInt64(int64(n)), strconv.FormatInt(int64(n), 10)
+	case int64:
+		return new(big.Rat).SetInt64(n), strconv.FormatInt(n, 10)
+	}
+	return nil, ""
+}
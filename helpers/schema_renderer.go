@@ -0,0 +1,150 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package helpers
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// unmodeledKeywords are JSON schema keywords that are not modeled by libopenapi, so they are lost when a schema is
+// rendered. They are carried over from the specification, so they are still enforced when validating.
+var unmodeledKeywords = []string{"dependentRequired", "contentEncoding", "contentMediaType", "contentSchema"}
+
+// exclusiveBoundKeywords are modeled by libopenapi as a boolean in OpenAPI 3.0 documents, so the numeric form
+// (e.g. 'exclusiveMinimum: 0') is rendered as 'false'. The number is carried over from the specification instead.
+var exclusiveBoundKeywords = []string{"exclusiveMinimum", "exclusiveMaximum"}
+
+// RenderSchemaInline renders a schema with all references inlined, ready to be compiled. Keywords that are not
+// modeled by libopenapi (such as 'dependentRequired') are carried over from the specification.
+func RenderSchemaInline(schema *base.Schema) ([]byte, error) {
+	rendered, err := schema.MarshalYAMLInline()
+	if err != nil {
+		return nil, err
+	}
+	if node, ok := rendered.(*yaml.Node); ok {
+		RestoreSchemaKeywords(schema, node)
+	}
+	return yaml.Marshal(rendered)
+}
+
+// RenderSchema renders a schema (references are left as they are), ready to be compiled. Keywords that are not
+// modeled by libopenapi (such as 'dependentRequired') are carried over from the specification.
+func RenderSchema(schema *base.Schema) ([]byte, error) {
+	rendered, err := schema.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	if node, ok := rendered.(*yaml.Node); ok {
+		RestoreSchemaKeywords(schema, node)
+	}
+	return yaml.Marshal(rendered)
+}
+
+// RestoreSchemaKeywords copies the keywords that are not modeled by libopenapi from the specification into a
+// rendered schema node, for the schema and every schema nested in it. The rendered node is walked alongside the
+// schema, so only schemas that made it into the render (e.g. not the repeat of a circular reference) are visited.
+func RestoreSchemaKeywords(schema *base.Schema, rendered *yaml.Node) {
+	if schema == nil || rendered == nil || rendered.Kind != yaml.MappingNode {
+		return
+	}
+	// a reference that was not inlined is resolved when compiled, its keywords are restored there.
+	if mappingValue(rendered, "$ref") != nil {
+		return
+	}
+	if low := schema.GoLow(); low != nil && low.RootNode != nil && low.RootNode.Kind == yaml.MappingNode {
+		for _, keyword := range unmodeledKeywords {
+			if value := mappingValue(low.RootNode, keyword); value != nil && mappingValue(rendered, keyword) == nil {
+				rendered.Content = append(rendered.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyword}, value)
+			}
+		}
+		for _, keyword := range exclusiveBoundKeywords {
+			value := mappingValue(low.RootNode, keyword)
+			if value == nil || (value.ShortTag() != "!!int" && value.ShortTag() != "!!float") {
+				continue
+			}
+			if renderedValue := mappingValue(rendered, keyword); renderedValue != nil {
+				*renderedValue = *value
+			} else {
+				rendered.Content = append(rendered.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyword}, value)
+			}
+		}
+	}
+
+	restoreProxy := func(proxy *base.SchemaProxy, node *yaml.Node) {
+		if proxy != nil && node != nil {
+			RestoreSchemaKeywords(proxy.Schema(), node)
+		}
+	}
+	restoreList := func(proxies []*base.SchemaProxy, keyword string) {
+		node := mappingValue(rendered, keyword)
+		if node == nil || node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, proxy := range proxies {
+			if i < len(node.Content) {
+				restoreProxy(proxy, node.Content[i])
+			}
+		}
+	}
+
+	restoreList(schema.AllOf, "allOf")
+	restoreList(schema.AnyOf, "anyOf")
+	restoreList(schema.OneOf, "oneOf")
+	restoreList(schema.PrefixItems, "prefixItems")
+
+	if schema.Properties != nil {
+		restoreMap(schema.Properties.FromOldest(), mappingValue(rendered, "properties"))
+	}
+	if schema.PatternProperties != nil {
+		restoreMap(schema.PatternProperties.FromOldest(), mappingValue(rendered, "patternProperties"))
+	}
+	if schema.DependentSchemas != nil {
+		restoreMap(schema.DependentSchemas.FromOldest(), mappingValue(rendered, "dependentSchemas"))
+	}
+
+	restoreProxy(schema.Not, mappingValue(rendered, "not"))
+	restoreProxy(schema.If, mappingValue(rendered, "if"))
+	restoreProxy(schema.Then, mappingValue(rendered, "then"))
+	restoreProxy(schema.Else, mappingValue(rendered, "else"))
+	restoreProxy(schema.Contains, mappingValue(rendered, "contains"))
+	restoreProxy(schema.PropertyNames, mappingValue(rendered, "propertyNames"))
+	restoreProxy(schema.UnevaluatedItems, mappingValue(rendered, "unevaluatedItems"))
+	if schema.Items != nil && schema.Items.IsA() {
+		restoreProxy(schema.Items.A, mappingValue(rendered, "items"))
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+		restoreProxy(schema.AdditionalProperties.A, mappingValue(rendered, "additionalProperties"))
+	}
+	if schema.UnevaluatedProperties != nil && schema.UnevaluatedProperties.IsA() {
+		restoreProxy(schema.UnevaluatedProperties.A, mappingValue(rendered, "unevaluatedProperties"))
+	}
+}
+
+// restoreMap restores the keywords of each schema in a map of schemas (e.g. 'properties'), using the rendered map.
+func restoreMap(proxies func(func(string, *base.SchemaProxy) bool), rendered *yaml.Node) {
+	if rendered == nil || rendered.Kind != yaml.MappingNode {
+		return
+	}
+	for name, proxy := range proxies {
+		if node := mappingValue(rendered, name); node != nil && proxy != nil {
+			RestoreSchemaKeywords(proxy.Schema(), node)
+		}
+	}
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil if the key is not present.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
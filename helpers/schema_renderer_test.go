@@ -0,0 +1,137 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderSchemaInline_DependentRequired(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        payment:
+          $ref: '#/components/schemas/Payment'
+      dependentRequired:
+        payment: [total]
+    Payment:
+      type: object
+      dependentRequired:
+        card: [cvv, expiry]`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	schema := m.Model.Components.Schemas.GetOrZero("Order").Schema()
+	rendered, err := RenderSchemaInline(schema)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(rendered, &decoded))
+	assert.Equal(t, map[string]any{"payment": []any{"total"}}, decoded["dependentRequired"])
+
+	payment := decoded["properties"].(map[string]any)["payment"].(map[string]any)
+	assert.Equal(t, map[string]any{"card": []any{"cvv", "expiry"}}, payment["dependentRequired"])
+}
+
+func TestRenderSchemaInline_NumericExclusiveBounds30(t *testing.T) {
+	spec := `openapi: 3.0.3
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        price:
+          type: number
+          exclusiveMinimum: 0
+          exclusiveMaximum: 99.5
+        discount:
+          type: number
+          minimum: 0
+          exclusiveMinimum: true`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	rendered, err := RenderSchemaInline(m.Model.Components.Schemas.GetOrZero("Order").Schema())
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(rendered, &decoded))
+	properties := decoded["properties"].(map[string]any)
+
+	// the numeric form is read as a boolean by libopenapi in 3.0, the number is carried over instead.
+	price := properties["price"].(map[string]any)
+	assert.Equal(t, 0, price["exclusiveMinimum"])
+	assert.Equal(t, 99.5, price["exclusiveMaximum"])
+
+	discount := properties["discount"].(map[string]any)
+	assert.Equal(t, true, discount["exclusiveMinimum"])
+	assert.Equal(t, 0, discount["minimum"])
+}
+
+func TestRenderSchemaInline_ContentKeywords(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Upload:
+      type: string
+      contentEncoding: base64
+      contentMediaType: application/json
+      contentSchema:
+        type: object
+        required: [name]`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	rendered, err := RenderSchemaInline(m.Model.Components.Schemas.GetOrZero("Upload").Schema())
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(rendered, &decoded))
+	assert.Equal(t, "base64", decoded["contentEncoding"])
+	assert.Equal(t, "application/json", decoded["contentMediaType"])
+	assert.Equal(t, map[string]any{"type": "object", "required": []any{"name"}}, decoded["contentSchema"])
+}
+
+func TestRenderSchema_LeavesReferences(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        payment:
+          $ref: '#/components/schemas/Payment'
+    Payment:
+      type: object
+      dependentRequired:
+        card: [cvv]`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	rendered, err := RenderSchema(m.Model.Components.Schemas.GetOrZero("Order").Schema())
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(rendered, &decoded))
+	payment := decoded["properties"].(map[string]any)["payment"].(map[string]any)
+	assert.Equal(t, "#/components/schemas/Payment", payment["$ref"])
+	assert.NotContains(t, payment, "dependentRequired")
+}
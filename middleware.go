@@ -0,0 +1,219 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// ErrorRenderer is used by middleware to write a response to the client when validation fails.
+type ErrorRenderer func(w http.ResponseWriter, r *http.Request, validationErrors []*errors.ValidationError)
+
+// MiddlewareOption configures the behavior of the validation middleware.
+type MiddlewareOption func(*middlewareOptions)
+
+// ResponseViolationHandler is called by response middleware when a response fails validation.
+type ResponseViolationHandler func(r *http.Request, validationErrors []*errors.ValidationError)
+
+// ResponseValidationMode controls what response middleware does with a response that fails validation.
+type ResponseValidationMode int
+
+const (
+	// ResponseValidationLog reports violations to the ResponseViolationHandler and sends the response unchanged.
+	ResponseValidationLog ResponseValidationMode = iota
+
+	// ResponseValidationStrict reports violations to the ResponseViolationHandler and replaces any successful
+	// (2xx) response that fails validation with a 500 Internal Server Error.
+	ResponseValidationStrict
+)
+
+// DefaultMaxResponseBytes is the default number of response bytes buffered by response middleware.
+const DefaultMaxResponseBytes int64 = 1 << 20
+
+type middlewareOptions struct {
+	errorRenderer    ErrorRenderer
+	violationHandler ResponseViolationHandler
+	responseMode     ResponseValidationMode
+	maxResponseBytes int64
+}
+
+// WithErrorRenderer sets a custom ErrorRenderer, used to control the shape of the response written to the client
+// when validation fails. By default, an RFC 7807 'application/problem+json' response is written.
+func WithErrorRenderer(renderer ErrorRenderer) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.errorRenderer = renderer
+	}
+}
+
+// WithResponseViolationHandler sets a handler that is called (in every mode) when a response fails validation,
+// useful for logging violations.
+func WithResponseViolationHandler(handler ResponseViolationHandler) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.violationHandler = handler
+	}
+}
+
+// WithResponseValidationMode sets what response middleware does with a response that fails validation.
+// The default is ResponseValidationLog.
+func WithResponseValidationMode(mode ResponseValidationMode) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.responseMode = mode
+	}
+}
+
+// WithMaxResponseBytes sets the maximum number of response bytes buffered by response middleware. A response
+// larger than this is streamed to the client as-is, without being validated. Defaults to DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.maxResponseBytes = n
+	}
+}
+
+func newMiddlewareOptions(opts ...MiddlewareOption) *middlewareOptions {
+	o := &middlewareOptions{
+		errorRenderer:    RenderProblem,
+		responseMode:     ResponseValidationLog,
+		maxResponseBytes: DefaultMaxResponseBytes,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// Middleware returns net/http middleware that validates every request (parameters and body) before it reaches
+// the next handler. If validation fails, the error renderer writes the response and the chain is stopped.
+// The request body is restored after validation, so the next handler can read it as normal.
+//
+//	http.ListenAndServe(":8080", validator.Middleware(v)(mux))
+func Middleware(v Validator, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	options := newMiddlewareOptions(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if valid, validationErrors := v.ValidateHttpRequest(r); !valid {
+				options.errorRenderer(w, r, validationErrors)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ResponseMiddleware returns net/http middleware that buffers the response written by the next handler, and
+// validates it before it is sent to the client. When the response passes validation (or the mode is
+// ResponseValidationLog), the original status, headers and body are sent unchanged. Buffering is bounded
+// by WithMaxResponseBytes, responses that outgrow the buffer are streamed and not validated.
+func ResponseMiddleware(v Validator, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	options := newMiddlewareOptions(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bw := &bufferedResponseWriter{writer: w, header: http.Header{}, maxBytes: options.maxResponseBytes}
+			next.ServeHTTP(bw, r)
+			if bw.overflowed {
+				return
+			}
+			response := &http.Response{
+				StatusCode: bw.statusCode(),
+				Header:     bw.header,
+				Body:       io.NopCloser(bytes.NewReader(bw.buffer.Bytes())),
+			}
+			if valid, validationErrors := v.ValidateHttpResponse(r, response); !valid && !isRoutingFailure(validationErrors) {
+				if options.violationHandler != nil {
+					options.violationHandler(r, validationErrors)
+				}
+				if options.responseMode == ResponseValidationStrict && response.StatusCode/100 == 2 {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+			}
+			_ = bw.flush()
+		})
+	}
+}
+
+// isRoutingFailure returns true if the errors are caused by the request not matching the specification,
+// there is nothing in the specification to validate the response against in that case.
+func isRoutingFailure(validationErrors []*errors.ValidationError) bool {
+	for _, ve := range validationErrors {
+		if ve.IsPathMissingError() || ve.IsOperationMissingError() {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter holds on to the status, headers and body written by a handler, so the response can be
+// validated before it's sent. If the body grows beyond maxBytes, everything is flushed and the rest is streamed.
+type bufferedResponseWriter struct {
+	writer     http.ResponseWriter
+	header     http.Header
+	status     int
+	buffer     bytes.Buffer
+	maxBytes   int64
+	overflowed bool
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(statusCode int) {
+	if b.status == 0 {
+		b.status = statusCode
+	}
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if b.overflowed {
+		return b.writer.Write(p)
+	}
+	if b.maxBytes > 0 && int64(b.buffer.Len()+len(p)) > b.maxBytes {
+		b.overflowed = true
+		if err := b.flush(); err != nil {
+			return 0, err
+		}
+		return b.writer.Write(p)
+	}
+	return b.buffer.Write(p)
+}
+
+func (b *bufferedResponseWriter) statusCode() int {
+	if b.status == 0 {
+		return http.StatusOK
+	}
+	return b.status
+}
+
+// flush sends the buffered status, headers and body to the underlying writer.
+func (b *bufferedResponseWriter) flush() error {
+	header := b.writer.Header()
+	for k, v := range b.header {
+		header[k] = v
+	}
+	b.writer.WriteHeader(b.statusCode())
+	_, err := b.writer.Write(b.buffer.Bytes())
+	b.buffer.Reset()
+	return err
+}
+
+// RenderProblem is the default ErrorRenderer, it writes the validation errors as an RFC 7807
+// 'application/problem+json' document, using the status code of the problem.
+func RenderProblem(w http.ResponseWriter, _ *http.Request, validationErrors []*errors.ValidationError) {
+	problem := errors.ProblemFromErrors(validationErrors)
+	if problem == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set(helpers.ContentTypeHeader, helpers.ProblemJSONContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
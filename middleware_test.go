@@ -0,0 +1,231 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package validator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+var middlewareSpec = `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: chef
+          in: query
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: integer`
+
+func newMiddlewareValidator(t *testing.T) Validator {
+	doc, err := libopenapi.NewDocument([]byte(middlewareSpec))
+	require.NoError(t, err)
+	v, errs := NewValidator(doc)
+	require.Empty(t, errs)
+	return v
+}
+
+func TestMiddleware_Valid(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	var handlerBody string
+	handler := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		handlerBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "/burgers?chef=ronald", strings.NewReader(`{"name": "Big Mac"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, `{"name": "Big Mac"}`, handlerBody)
+}
+
+func TestMiddleware_Invalid(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	called := false
+	handler := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "/burgers", strings.NewReader(`{"name": 1}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, helpers.ProblemJSONContentType, recorder.Header().Get(helpers.ContentTypeHeader))
+
+	var problem errors.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Len(t, problem.Errors, 2)
+}
+
+func TestMiddleware_PathNotFound(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	handler := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/pizza", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestMiddleware_CustomErrorRenderer(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	renderer := func(w http.ResponseWriter, r *http.Request, validationErrors []*errors.ValidationError) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte(validationErrors[0].Message))
+	}
+	handler := Middleware(v, WithErrorRenderer(renderer))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := httptest.NewRequest(http.MethodPost, "/burgers?chef=ronald", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+	assert.Equal(t, "Request body is required", recorder.Body.String())
+}
+
+func newResponseHandler(status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		w.Header().Set("X-Burger", "big-mac")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestResponseMiddleware_Valid(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	var violations []*errors.ValidationError
+	handler := ResponseMiddleware(v, WithResponseValidationMode(ResponseValidationStrict),
+		WithResponseViolationHandler(func(r *http.Request, validationErrors []*errors.ValidationError) {
+			violations = append(violations, validationErrors...)
+		}))(newResponseHandler(http.StatusOK, `{"id": 1}`))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/burgers", nil))
+
+	assert.Empty(t, violations)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "big-mac", recorder.Header().Get("X-Burger"))
+	assert.Equal(t, `{"id": 1}`, recorder.Body.String())
+}
+
+func TestResponseMiddleware_LogMode(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	var violations []*errors.ValidationError
+	handler := ResponseMiddleware(v,
+		WithResponseViolationHandler(func(r *http.Request, validationErrors []*errors.ValidationError) {
+			violations = append(violations, validationErrors...)
+		}))(newResponseHandler(http.StatusOK, `{"id": "one"}`))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/burgers", nil))
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, helpers.ResponseBodyValidation, violations[0].ValidationType)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `{"id": "one"}`, recorder.Body.String())
+}
+
+func TestResponseMiddleware_StrictMode(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	handler := ResponseMiddleware(v, WithResponseValidationMode(ResponseValidationStrict))(
+		newResponseHandler(http.StatusOK, `{"id": "one"}`))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/burgers", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("X-Burger"))
+	assert.NotContains(t, recorder.Body.String(), "one")
+}
+
+func TestResponseMiddleware_UnknownPath(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	called := false
+	handler := ResponseMiddleware(v, WithResponseValidationMode(ResponseValidationStrict),
+		WithResponseViolationHandler(func(r *http.Request, validationErrors []*errors.ValidationError) {
+			called = true
+		}))(newResponseHandler(http.StatusOK, `{"id": "one"}`))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/pizza", nil))
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestResponseMiddleware_BufferBounded(t *testing.T) {
+	v := newMiddlewareValidator(t)
+
+	called := false
+	handler := ResponseMiddleware(v, WithResponseValidationMode(ResponseValidationStrict), WithMaxResponseBytes(8),
+		WithResponseViolationHandler(func(r *http.Request, validationErrors []*errors.ValidationError) {
+			called = true
+		}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		_, _ = w.Write([]byte(`{"id": `))
+		_, _ = w.Write([]byte(`"one"}`))
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/burgers", nil))
+
+	// too big to buffer, so the response is streamed without validation.
+	assert.False(t, called)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, helpers.JSONContentType, recorder.Header().Get(helpers.ContentTypeHeader))
+	assert.Equal(t, `{"id": "one"}`, recorder.Body.String())
+}
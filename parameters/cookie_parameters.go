@@ -19,7 +19,7 @@ import (
 )
 
 func (v *paramValidator) ValidateCookieParams(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
@@ -32,6 +32,8 @@ func (v *paramValidator) ValidateCookieParamsWithPathItem(request *http.Request,
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -42,11 +44,20 @@ func (v *paramValidator) ValidateCookieParamsWithPathItem(request *http.Request,
 	}
 	// extract params for the operation
 	params := helpers.ExtractParamsForOperation(request, pathItem)
+	cookies := helpers.ParseCookies(request)
 	var validationErrors []*errors.ValidationError
 	for _, p := range params {
 		if p.In == helpers.Cookie {
-			for _, cookie := range request.Cookies() {
+			// a style that is not defined by the specification cannot be decoded.
+			if !helpers.IsDefinedStyle(p.Style) {
+				validationErrors = append(validationErrors, errors.ParameterStyleUndefined(p))
+				continue
+			}
+
+			found := false
+			for _, cookie := range cookies {
 				if cookie.Name == p.Name { // cookies are case-sensitive, an exact match is required
+					found = true
 
 					var sch *base.Schema
 					if p.Schema != nil {
@@ -57,25 +68,32 @@ func (v *paramValidator) ValidateCookieParamsWithPathItem(request *http.Request,
 					for _, ty := range pType {
 						switch ty {
 						case helpers.Integer, helpers.Number:
-							if _, err := strconv.ParseFloat(cookie.Value, 64); err != nil {
+							cookieValue, err := strconv.ParseFloat(cookie.Value, 64)
+							if err != nil {
 								validationErrors = append(validationErrors,
 									errors.InvalidCookieParamNumber(p, strings.ToLower(cookie.Value), sch))
 								break
 							}
 							// check if enum is in range
 							if sch.Enum != nil {
-								matchFound := false
-								for _, enumVal := range sch.Enum {
-									if strings.TrimSpace(cookie.Value) == fmt.Sprint(enumVal.Value) {
-										matchFound = true
-										break
-									}
-								}
-								if !matchFound {
+								if !helpers.ParamMatchesEnum(cookie.Value, sch) {
 									validationErrors = append(validationErrors,
 										errors.IncorrectCookieParamEnum(p, strings.ToLower(cookie.Value), sch))
 								}
+								break
 							}
+							// check the rest of the schema (e.g. integers, 'format', 'minimum').
+							validationErrors = append(validationErrors,
+								ValidateSingleParameterSchema(
+									sch,
+									cookieValue,
+									"Cookie parameter",
+									"The cookie parameter",
+									p.Name,
+									helpers.ParameterValidation,
+									helpers.ParameterValidationCookie,
+									v.options,
+								)...)
 						case helpers.Boolean:
 							if _, err := strconv.ParseBool(cookie.Value); err != nil {
 								validationErrors = append(validationErrors,
@@ -98,38 +116,41 @@ func (v *paramValidator) ValidateCookieParamsWithPathItem(request *http.Request,
 								}
 							}
 						case helpers.Array:
-
-							if !p.IsExploded() {
-								// well we're already in an array, so we need to check the items schema
-								// to ensure this array items matches the type
-								// only check if items is a schema, not a boolean
-								if sch.Items.IsA() {
-									validationErrors = append(validationErrors,
-										ValidateCookieArray(sch, p, cookie.Value)...)
-								}
-							}
+							// a cookie is always sent as a single name=value pair, so the items are comma separated
+							// whether the parameter is exploded or not.
+							validationErrors = append(validationErrors,
+								ValidateCookieArrayWithOptions(sch, p, cookie.Value, v.options)...)
 
 						case helpers.String:
 
 							// check if the schema has an enum, and if so, match the value against one of
 							// the defined enum values.
 							if sch.Enum != nil {
-								matchFound := false
-								for _, enumVal := range sch.Enum {
-									if strings.TrimSpace(cookie.Value) == fmt.Sprint(enumVal.Value) {
-										matchFound = true
-										break
-									}
-								}
-								if !matchFound {
+								if !helpers.ParamMatchesEnum(cookie.Value, sch) {
 									validationErrors = append(validationErrors,
 										errors.IncorrectCookieParamEnum(p, strings.ToLower(cookie.Value), sch))
 								}
+								break
 							}
+							// check the rest of the schema (e.g. 'format', 'pattern', 'minLength').
+							validationErrors = append(validationErrors,
+								ValidateSingleParameterSchema(
+									sch,
+									cookie.Value,
+									"Cookie parameter",
+									"The cookie parameter",
+									p.Name,
+									helpers.ParameterValidation,
+									helpers.ParameterValidationCookie,
+									v.options,
+								)...)
 						}
 					}
 				}
 			}
+			if !found && p.Required != nil && *p.Required {
+				validationErrors = append(validationErrors, errors.CookieParameterMissing(p))
+			}
 		}
 	}
 
@@ -9,7 +9,9 @@ import (
 
 	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 )
 
@@ -323,6 +325,113 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
+func TestNewValidator_CookieParamArrayItemEnum(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: Toppings
+          in: cookie
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [pickles, onions]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "Toppings", Value: "pickles,pineapple"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "value must be one of 'pickles', 'onions'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_CookieParamArrayItemSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyIds
+          in: cookie
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer
+              minimum: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// a cookie is a single name=value pair, so an exploded array is still comma separated.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyIds", Value: "3,0,2,-1"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.ParameterValidationCookie, errors[0].ValidationSubType)
+	require.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Equal(t, "$[1]", errors[0].SchemaValidationErrors[0].FieldPath)
+	assert.Equal(t, "minimum: got 0, want 1", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "$[3]", errors[0].SchemaValidationErrors[1].FieldPath)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyIds", Value: "3,beef"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Cookie array parameter 'PattyIds' is not a valid number", errors[0].Message)
+}
+
+func TestNewValidator_CookieParamArrayNoItems(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyIds
+          in: cookie
+          schema:
+            type: array
+            maxItems: 2`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyIds", Value: "1,2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyIds", Value: "1,2,3"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/maxItems", errors[0].SchemaValidationErrors[0].Location)
+}
+
 func TestNewValidator_CookieParamArrayInvalidNumber(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -570,3 +679,92 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "GET Path '/pizza/beef' not found", errors[0].Message)
 }
+
+func TestNewValidator_CookieParamMissing(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number
+        - name: BunPreference
+          in: cookie
+          required: true
+          schema:
+            type: string
+        - name: SaucePreference
+          in: cookie
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' is missing", errors[0].Message)
+	assert.Equal(t, "Cookie parameter 'BunPreference' is missing", errors[1].Message)
+	assert.Equal(t, "/burgers/beef", errors[0].SpecPath)
+}
+
+func TestNewValidator_CookieParamEncodedValues(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: session
+          in: cookie
+          required: true
+          schema:
+            type: string
+            pattern: '^[A-Za-z0-9+/]+={0,2}$'
+        - name: sauce
+          in: cookie
+          required: true
+          schema:
+            type: string
+            enum: [ketchup mayo, mustard]
+        - name: patties
+          in: cookie
+          required: true
+          schema:
+            type: integer
+        - name: chef
+          in: cookie
+          required: true
+          schema:
+            type: string
+            minLength: 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// a base64 value containing '=', a quoted value, and cookies sent over more than one header.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.Header.Add("Cookie", `session=YWJj+/ZGVm==; sauce="ketchup mayo"`)
+	request.Header.Add("Cookie", `patties="2"; chef=Zoë`)
+
+	valid, errors := v.ValidateCookieParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// a value with characters a browser would not send is still validated, rather than reported as missing.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.Header.Add("Cookie", `session=not"base64; sauce="mustard"; patties=2; chef=Zoë`)
+
+	valid, errors = v.ValidateCookieParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'session' failed to validate", errors[0].Message)
+}
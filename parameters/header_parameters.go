@@ -21,19 +21,44 @@ import (
 )
 
 func (v *paramValidator) ValidateHeaderParams(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
 	return v.ValidateHeaderParamsWithPathItem(request, pathItem, foundPath)
 }
 
+func (v *paramValidator) ValidateHeaderParamsNamed(request *http.Request, names ...string) (bool, []*errors.ValidationError) {
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
+	if len(errs) > 0 {
+		return false, errs
+	}
+	named := make(map[string]bool, len(names))
+	for _, name := range names {
+		named[strings.ToLower(name)] = true
+	}
+	return v.validateHeaderParams(request, pathItem, foundPath, named)
+}
+
 func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
+	return v.validateHeaderParams(request, pathItem, pathValue, nil)
+}
+
+// validateHeaderParams validates the header parameters of the operation, if named is not nil, only the header
+// parameters with a (lower case) name in named are validated.
+func (v *paramValidator) validateHeaderParams(
+	request *http.Request,
+	pathItem *v3.PathItem,
+	pathValue string,
+	named map[string]bool,
+) (bool, []*errors.ValidationError) {
 	if pathItem == nil {
 		return false, []*errors.ValidationError{{
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -49,9 +74,23 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 	seenHeaders := make(map[string]bool)
 	for _, p := range params {
 		if p.In == helpers.Header {
+			if named != nil && !named[strings.ToLower(p.Name)] {
+				continue
+			}
 
 			seenHeaders[strings.ToLower(p.Name)] = true
-			if param := request.Header.Get(p.Name); param != "" {
+
+			// 'simple' is the only style a header can use, anything else is a mistake in the specification,
+			// and the header cannot be decoded.
+			if p.Style != "" && p.Style != helpers.SimpleStyle {
+				validationErrors = append(validationErrors, errors.HeaderParameterInvalidStyle(p))
+				continue
+			}
+
+			// repeated header lines are combined into a single comma separated value (RFC 7230, section 3.2.2),
+			// so a scalar sees every line, and an array gets the items from every line.
+			headerValues := request.Header.Values(p.Name)
+			if param := strings.Join(headerValues, helpers.Comma); param != "" {
 
 				var sch *base.Schema
 				if p.Schema != nil {
@@ -62,25 +101,32 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 				for _, ty := range pType {
 					switch ty {
 					case helpers.Integer, helpers.Number:
-						if _, err := strconv.ParseFloat(param, 64); err != nil {
+						paramValue, err := strconv.ParseFloat(param, 64)
+						if err != nil {
 							validationErrors = append(validationErrors,
 								errors.InvalidHeaderParamNumber(p, strings.ToLower(param), sch))
 							break
 						}
 						// check if the param is within the enum
 						if sch.Enum != nil {
-							matchFound := false
-							for _, enumVal := range sch.Enum {
-								if strings.TrimSpace(param) == fmt.Sprint(enumVal.Value) {
-									matchFound = true
-									break
-								}
-							}
-							if !matchFound {
+							if !helpers.ParamMatchesEnum(param, sch) {
 								validationErrors = append(validationErrors,
-									errors.IncorrectCookieParamEnum(p, strings.ToLower(param), sch))
+									errors.IncorrectHeaderParamEnum(p, strings.ToLower(param), sch))
 							}
+							break
 						}
+						// check the rest of the schema (e.g. integers, 'format', 'minimum').
+						validationErrors = append(validationErrors,
+							ValidateSingleParameterSchema(
+								sch,
+								paramValue,
+								"Header parameter",
+								"The header parameter",
+								p.Name,
+								helpers.ParameterValidation,
+								helpers.ParameterValidationHeader,
+								v.options,
+							)...)
 
 					case helpers.Boolean:
 						if _, err := strconv.ParseBool(param); err != nil {
@@ -92,16 +138,18 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 
 						// check if the header is default encoded or not
 						var encodedObj map[string]interface{}
+						decoded := false
 						// we have found our header, check the explode type.
 						if p.IsDefaultHeaderEncoding() {
-							encodedObj = helpers.ConstructMapFromCSV(param)
+							encodedObj, decoded = helpers.DecodeMapFromCSV(param)
 						} else {
 							if p.IsExploded() { // only option is to be exploded for KV extraction.
-								encodedObj = helpers.ConstructKVFromCSV(param)
+								encodedObj, decoded = helpers.DecodeKVFromCSV(param)
 							}
 						}
 
-						if len(encodedObj) == 0 {
+						// a malformed header (e.g. a key without a value) is reported, rather than partially decoded.
+						if !decoded || len(encodedObj) == 0 {
 							validationErrors = append(validationErrors,
 								errors.HeaderParameterCannotBeDecoded(p, strings.ToLower(param)))
 							break
@@ -121,10 +169,13 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 						}
 
 					case helpers.Array:
-						if !p.IsExploded() { // only unexploded arrays are supported for cookie params
-							if sch.Items.IsA() {
+						if sch.Items.IsA() {
+							if p.IsExploded() {
+								validationErrors = append(validationErrors,
+									ValidateExplodedHeaderArrayWithOptions(sch, p, headerValues, v.options)...)
+							} else {
 								validationErrors = append(validationErrors,
-									ValidateHeaderArray(sch, p, param)...)
+									ValidateHeaderArrayWithOptions(sch, p, param, v.options)...)
 							}
 						}
 
@@ -133,18 +184,24 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 						// check if the schema has an enum, and if so, match the value against one of
 						// the defined enum values.
 						if sch.Enum != nil {
-							matchFound := false
-							for _, enumVal := range sch.Enum {
-								if strings.TrimSpace(param) == fmt.Sprint(enumVal.Value) {
-									matchFound = true
-									break
-								}
-							}
-							if !matchFound {
+							if !helpers.ParamMatchesEnum(param, sch) {
 								validationErrors = append(validationErrors,
 									errors.IncorrectHeaderParamEnum(p, strings.ToLower(param), sch))
 							}
+							break
 						}
+						// check the rest of the schema (e.g. 'format', 'pattern', 'minLength').
+						validationErrors = append(validationErrors,
+							ValidateSingleParameterSchema(
+								sch,
+								param,
+								"Header parameter",
+								"The header parameter",
+								p.Name,
+								helpers.ParameterValidation,
+								helpers.ParameterValidationHeader,
+								v.options,
+							)...)
 					}
 				}
 				if len(pType) == 0 {
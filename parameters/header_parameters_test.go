@@ -9,6 +9,7 @@ import (
 
 	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/pb33f/libopenapi-validator/paths"
 )
@@ -475,6 +476,194 @@ paths:
 	assert.Len(t, errors, 3)
 }
 
+func TestNewValidator_HeaderParamExplodedArray_RepeatedHeaders(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          required: true
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Ids", "1")
+	request.Header.Add("X-Ids", "2,3")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// every header line is validated, not just the first.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Ids", "1")
+	request.Header.Add("X-Ids", "two")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "'two'")
+}
+
+func TestNewValidator_HeaderParamUnexplodedArray_FirstHeaderOnly(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          required: true
+          schema:
+            type: array
+            items:
+              type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Ids", "1,two")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "'two'")
+}
+
+func TestNewValidator_HeaderParamUnexplodedArray_RepeatedHeaders(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          required: true
+          schema:
+            type: array
+            maxItems: 3
+            items:
+              type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Ids", "1")
+	request.Header.Add("X-Ids", "2,3")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the lines are combined, so the items of every line are validated, and counted.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Ids", "1")
+	request.Header.Add("X-Ids", "two")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "'two'")
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Ids", "1,2")
+	request.Header.Add("X-Ids", "3,4")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maxItems: got 4, want 3", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_HeaderParamScalar_RepeatedHeaders(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: Accept
+          in: header
+          schema:
+            type: string
+            pattern: "^[a-z]+/[a-z]+(,[a-z]+/[a-z]+)*$"
+        - name: X-Count
+          in: header
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// a scalar header sent on two lines is the same as one comma separated line.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("Accept", "text/html")
+	request.Header.Add("Accept", "application/json")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the second line is not ignored, so a repeated number is no longer a number.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Add("X-Count", "1")
+	request.Header.Add("X-Count", "2")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "X-Count")
+	assert.Contains(t, errors[0].Reason, "'1,2'")
+}
+
+func TestNewValidator_HeaderParamInvalidStyle(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          style: form
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: X-Size
+          in: header
+          style: simple
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Ids", "1,2")
+	request.Header.Set("X-Size", "3")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Ids' has an invalid style 'form'", errors[0].Message)
+	assert.Equal(t, 8, errors[0].SpecLine)
+}
+
 func TestNewValidator_HeaderParamStringValidEnum(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -581,6 +770,7 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "Instead of '1200', "+
 		"use one of the allowed values: '1, 2, 99'", errors[0].HowToFix)
+	assert.Equal(t, "Header parameter 'coffeeCups' does not match allowed values", errors[0].Message)
 }
 
 func TestNewValidator_HeaderParamSetPath(t *testing.T) {
@@ -643,3 +833,199 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "GET Path '/buying/drinks' not found", errors[0].Message)
 }
+
+func TestNewValidator_HeaderParamMissing_Multiple(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /bish/bosh:
+    get:
+      parameters:
+        - name: bash
+          in: header
+          required: true
+          schema:
+            type: string
+        - name: bish
+          in: header
+          required: true
+          schema:
+            type: integer
+        - name: bosh
+          in: header
+          required: true
+          schema:
+            type: boolean
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/bish/bosh", nil)
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 3)
+	assert.Equal(t, "Header parameter 'bash' is missing", errors[0].Message)
+	assert.Equal(t, "Header parameter 'bish' is missing", errors[1].Message)
+	assert.Equal(t, "Header parameter 'bosh' is missing", errors[2].Message)
+}
+
+func TestNewValidator_HeaderParamsNamed(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: coffeeCups
+          in: header
+          required: true
+          schema:
+            type: integer
+        - name: X-Api-Key
+          in: header
+          required: true
+          schema:
+            type: string
+            minLength: 8`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Api-Key", "short")
+
+	// only the api key is validated, the missing coffeeCups header is skipped.
+	valid, errors := v.ValidateHeaderParamsNamed(request, "x-api-key")
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "X-Api-Key")
+
+	request.Header.Set("X-Api-Key", "a-long-enough-key")
+	valid, errors = v.ValidateHeaderParamsNamed(request, "X-Api-Key", "X-Unknown")
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// nothing is validated when no names are supplied.
+	valid, errors = v.ValidateHeaderParamsNamed(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "coffeeCups")
+}
+
+func TestNewValidator_HeaderParamArrayItemEnum(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Sizes
+          in: header
+          schema:
+            type: array
+            items:
+              type: integer
+              enum: [1, 2]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Sizes", "1,3")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header array parameter 'X-Sizes' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "value must be one of 1, 2", errors[0].SchemaValidationErrors[0].Reason)
+
+	request.Header.Set("X-Sizes", "2,1")
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestNewValidator_HeaderParamObjectMalformed(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Coffee
+          in: header
+          schema:
+            type: object
+        - name: X-Tea
+          in: header
+          explode: true
+          schema:
+            type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	for header, values := range map[string][]string{
+		"X-Coffee": {"milk,123,sugar", "milk,123,", ",123"},
+		"X-Tea":    {"milk=123,sugar", "milk=123,", "=123"},
+	} {
+		for _, value := range values {
+			request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+			request.Header.Set(header, value)
+
+			valid, errors := v.ValidateHeaderParams(request)
+			assert.False(t, valid, value)
+			if assert.Len(t, errors, 1, value) {
+				assert.Equal(t, "Header parameter '"+header+"' cannot be decoded", errors[0].Message)
+			}
+		}
+	}
+
+	// an empty value is decoded, it's up to the schema to decide if it's allowed.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Coffee", "milk,")
+	request.Header.Set("X-Tea", "milk=")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateHeaderArray_WithoutOptions(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          explode: true
+          schema:
+            type: array
+            minItems: 2
+            items:
+              type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	param := m.Model.Paths.PathItems.GetOrZero("/burgers").Get.Parameters[0]
+	sch := param.Schema.Schema()
+
+	// the functions without options still validate the array against the whole schema.
+	assert.Empty(t, ValidateHeaderArray(sch, param, "1,2"))
+	assert.Len(t, ValidateHeaderArray(sch, param, "1"), 1)
+	assert.Len(t, ValidateHeaderArray(sch, param, "1,two"), 1)
+	assert.Empty(t, ValidateExplodedHeaderArray(sch, param, []string{"1", "2"}))
+	assert.Len(t, ValidateExplodedHeaderArray(sch, param, []string{"1"}), 1)
+	assert.Empty(t, ValidateCookieArray(sch, param, "1,2"))
+	assert.Len(t, ValidateCookieArray(sch, param, "1"), 1)
+}
@@ -5,6 +5,7 @@ package parameters
 
 import (
 	"net/http"
+	"net/url"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 
@@ -33,6 +34,20 @@ type ParameterValidator interface {
 	// will be matched and validated against what has been supplied in the http.Request query string.
 	ValidateQueryParamsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 
+	// ValidateAndExtractQueryParams validates the query parameters contained within *http.Request, the same way as
+	// ValidateQueryParams does, and returns the values of the query parameters declared for the operation, keyed by
+	// name, so they don't need to be parsed again. The values are decoded according to the style of each parameter,
+	// and converted into the types defined by its schema (see helpers.DecodeQueryParam). Parameters that were not
+	// supplied are left out. The values are returned whether validation passed or not, a slice of errors is
+	// returned if validation failed.
+	ValidateAndExtractQueryParams(request *http.Request) (map[string]any, []*errors.ValidationError)
+
+	// ValidateQueryValues validates query parameters that have already been parsed into url.Values, for when there is
+	// no *http.Request to validate (like values received over a websocket). The operation is located by the path
+	// template as it is written in the specification (e.g. '/burgers/{burgerId}') and the method. It returns a
+	// boolean stating true if validation passed (false for failed), and a slice of errors if validation failed.
+	ValidateQueryValues(pathTemplate, method string, values url.Values) (bool, []*errors.ValidationError)
+
 	// ValidateHeaderParams validates the header parameters contained within *http.Request. It returns a boolean
 	// stating true if validation passed (false for failed), and a slice of errors if validation failed.
 	ValidateHeaderParams(request *http.Request) (bool, []*errors.ValidationError)
@@ -41,6 +56,12 @@ type ParameterValidator interface {
 	// stating true if validation passed (false for failed), and a slice of errors if validation failed.
 	ValidateHeaderParamsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 
+	// ValidateHeaderParamsNamed validates only the header parameters with the supplied names (matched case-insensitively)
+	// contained within *http.Request, the rest of the header parameters are skipped. Names that are not declared
+	// as header parameters for the operation are ignored. It returns a boolean stating true if validation passed
+	// (false for failed), and a slice of errors if validation failed.
+	ValidateHeaderParamsNamed(request *http.Request, names ...string) (bool, []*errors.ValidationError)
+
 	// ValidateCookieParams validates the cookie parameters contained within *http.Request.
 	// It returns a boolean stating true if validation passed (false for failed), and a slice of errors if validation failed.
 	ValidateCookieParams(request *http.Request) (bool, []*errors.ValidationError)
@@ -57,6 +78,22 @@ type ParameterValidator interface {
 	// if validation passed (false for failed), and a slice of errors if validation failed.
 	ValidatePathParamsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 
+	// FindWarningsWithPathItem returns the warnings for the parameters contained within *http.Request, things worth
+	// knowing about that do not make the request invalid: a parameter that is marked as 'deprecated' was supplied,
+	// or a query parameter that is not declared by the operation was supplied, and ignored (unless strict query
+	// parameters are enabled, then it's an error reported by ValidateQueryParams).
+	FindWarningsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) []*errors.ValidationError
+
+	// UndeclaredParameters returns the names of the headers and query parameters contained within *http.Request that
+	// are not declared by the operation, in order, without failing validation. This is intended for debugging clients
+	// (like spotting a misspelled parameter). Headers that are part of HTTP itself (like 'User-Agent') and api keys
+	// declared by security schemes are not reported. Nothing is returned if the operation cannot be found.
+	UndeclaredParameters(request *http.Request) (headers []string, query []string)
+
+	// UndeclaredParametersWithPathItem works the same as UndeclaredParameters, for a path item that has already
+	// been located.
+	UndeclaredParametersWithPathItem(request *http.Request, pathItem *v3.PathItem) (headers []string, query []string)
+
 	// ValidateSecurity validates the security requirements for the operation. It returns a boolean stating true
 	// if validation passed (false for failed), and a slice of errors if validation failed.
 	ValidateSecurity(request *http.Request) (bool, []*errors.ValidationError)
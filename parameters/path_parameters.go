@@ -5,7 +5,9 @@ package parameters
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -20,7 +22,7 @@ import (
 )
 
 func (v *paramValidator) ValidatePathParams(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
@@ -33,6 +35,8 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -42,14 +46,24 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 		}}
 	}
 	// split the path into segments
-	submittedSegments := strings.Split(paths.StripRequestPath(request, v.document), helpers.Slash)
+	submittedSegments := strings.Split(paths.StripRequestPathWithOptions(request, v.document, v.options), helpers.Slash)
 	pathSegments := strings.Split(pathValue, helpers.Slash)
 
+	// a catch-all parameter captures the rest of the submitted path as a single value.
+	catchAll := helpers.FindCatchAllParameter(request, pathItem)
+	submittedSegments = helpers.CollapseCatchAllSegments(pathSegments, submittedSegments, catchAll)
+
 	// extract params for the operation
 	params := helpers.ExtractParamsForOperation(request, pathItem)
 	var validationErrors []*errors.ValidationError
 	for _, p := range params {
 		if p.In == helpers.Path {
+			// a style that is not defined by the specification cannot be decoded.
+			if !helpers.IsDefinedStyle(p.Style) {
+				validationErrors = append(validationErrors, errors.ParameterStyleUndefined(p))
+				continue
+			}
+
 			// var paramTemplate string
 			for x := range pathSegments {
 				if pathSegments[x] == "" { // skip empty segments
@@ -61,9 +75,17 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 					continue
 				}
 
-				re := regexp.MustCompile(r.String())
-				matches := re.FindStringSubmatch(submittedSegments[x])
-				matches = matches[1:]
+				var matches []string
+				if x == len(pathSegments)-1 && helpers.IsCatchAllSegment(pathSegments[x], catchAll) {
+					matches = []string{submittedSegments[x]}
+				} else {
+					re := regexp.MustCompile(r.String())
+					matches = re.FindStringSubmatch(submittedSegments[x])
+					if len(matches) == 0 {
+						continue
+					}
+					matches = matches[1:]
+				}
 
 				// Check if it is well-formed.
 				idxs, errBraces := helpers.BraceIndices(pathSegments[x])
@@ -104,7 +126,11 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 						continue
 					}
 
+					// the submitted path is escaped, the value is validated as it was sent (e.g. '%20' is a space).
 					paramValue := match
+					if decoded, err := url.PathUnescape(match); err == nil {
+						paramValue = decoded
+					}
 
 					if paramValue == "" {
 						// Mandatory path parameter cannot be empty
@@ -120,14 +146,7 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 
 					// check enum (if present)
 					enumCheck := func(paramValue string) {
-						matchFound := false
-						for _, enumVal := range sch.Enum {
-							if strings.TrimSpace(paramValue) == fmt.Sprint(enumVal.Value) {
-								matchFound = true
-								break
-							}
-						}
-						if !matchFound {
+						if !helpers.ParamMatchesEnum(paramValue, sch) {
 							validationErrors = append(validationErrors,
 								errors.IncorrectPathParamEnum(p, strings.ToLower(paramValue), sch))
 						}
@@ -160,7 +179,7 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 
 							case helpers.Integer, helpers.Number:
 								// simple use case is already handled in find param.
-								rawParamValue, paramValueParsed, err := v.resolveNumber(sch, p, isLabel, isMatrix, paramValue)
+								rawParamValue, paramValueParsed, err := v.resolveNumber(sch, p, isLabel, isMatrix, paramValue, sch.Type[typ])
 								if err != nil {
 									validationErrors = append(validationErrors, err...)
 									break
@@ -247,28 +266,30 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 								// extract the items schema in order to validate the array items.
 								if sch.Items != nil && sch.Items.IsA() {
 									iSch := sch.Items.A.Schema()
-									for n := range iSch.Type {
-										// determine how to explode the array
-										var arrayValues []string
-										if isSimple {
-											arrayValues = strings.Split(paramValue, helpers.Comma)
-										}
-										if isLabel {
-											if !p.IsExploded() {
-												arrayValues = strings.Split(paramValue[1:], helpers.Comma)
-											} else {
-												arrayValues = strings.Split(paramValue[1:], helpers.Period)
-											}
+
+									// determine how to explode the array
+									var arrayValues []string
+									if isSimple {
+										arrayValues = strings.Split(paramValue, helpers.Comma)
+									}
+									if isLabel {
+										if !p.IsExploded() {
+											arrayValues = strings.Split(paramValue[1:], helpers.Comma)
+										} else {
+											arrayValues = strings.Split(paramValue[1:], helpers.Period)
 										}
-										if isMatrix {
-											if !p.IsExploded() {
-												paramValue = strings.Replace(paramValue[1:], fmt.Sprintf("%s=", p.Name), "", 1)
-												arrayValues = strings.Split(paramValue, helpers.Comma)
-											} else {
-												paramValue = strings.ReplaceAll(paramValue[1:], fmt.Sprintf("%s=", p.Name), "")
-												arrayValues = strings.Split(paramValue, helpers.SemiColon)
-											}
+									}
+									if isMatrix {
+										var ok bool
+										if arrayValues, ok = helpers.DecodeMatrixArray(paramValue, p.Name, p.IsExploded()); !ok {
+											validationErrors = append(validationErrors,
+												errors.IncorrectPathParamMatrixArray(p, paramValue, sch))
+											break
 										}
+									}
+
+									itemErrors := len(validationErrors)
+									for n := range iSch.Type {
 										switch iSch.Type[n] {
 										case helpers.Integer, helpers.Number:
 											for pv := range arrayValues {
@@ -297,6 +318,26 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 											}
 										}
 									}
+
+									// the items of a matrix array are validated against the schema once they are all the
+									// right type, so each item is checked for everything else (like an enum, or a minimum).
+									if isMatrix && len(validationErrors) == itemErrors {
+										items := make([]any, len(arrayValues))
+										for i, item := range arrayValues {
+											items[i] = helpers.CastParamValue(item, iSch)
+										}
+										validationErrors = append(validationErrors,
+											ValidateSingleParameterSchema(
+												sch,
+												items,
+												"Path parameter",
+												"The path parameter",
+												p.Name,
+												helpers.ParameterValidation,
+												helpers.ParameterValidationPath,
+												v.options,
+											)...)
+									}
 								}
 							}
 						}
@@ -314,26 +355,20 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 	return true, nil
 }
 
-func (v *paramValidator) resolveNumber(sch *base.Schema, p *v3.Parameter, isLabel bool, isMatrix bool, paramValue string) (string, float64, []*errors.ValidationError) {
+func (v *paramValidator) resolveNumber(sch *base.Schema, p *v3.Parameter, isLabel bool, isMatrix bool, paramValue string, typ string) (string, float64, []*errors.ValidationError) {
 	if isLabel && p.Style == helpers.LabelStyle {
-		paramValueParsed, err := strconv.ParseFloat(paramValue[1:], 64)
-		if err != nil {
-			return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue[1:], sch)}
-		}
-		return paramValue[1:], paramValueParsed, nil
+		paramValue = paramValue[1:]
 	}
 	if isMatrix && p.Style == helpers.MatrixStyle {
 		// strip off the colon and the parameter name
 		paramValue = strings.Replace(paramValue[1:], fmt.Sprintf("%s=", p.Name), "", 1)
-		paramValueParsed, err := strconv.ParseFloat(paramValue, 64)
-		if err != nil {
-			return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue[1:], sch)}
-		}
-		return paramValue, paramValueParsed, nil
 	}
 	paramValueParsed, err := strconv.ParseFloat(paramValue, 64)
+	if typ == helpers.Integer && (err != nil || paramValueParsed != math.Trunc(paramValueParsed)) {
+		return "", 0, []*errors.ValidationError{errors.IncorrectPathParamInteger(p, paramValue, sch)}
+	}
 	if err != nil {
-		return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue[1:], sch)}
+		return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue, sch)}
 	}
 	return paramValue, paramValueParsed, nil
 }
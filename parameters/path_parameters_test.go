@@ -41,6 +41,40 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
+func TestNewValidator_PathParamsDuplicateSlashes(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: locateBurger`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	// the segments of the request path line up with the path, once the duplicate slashes are collapsed.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com//burgers//123/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers//beef/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
+}
+
 func TestNewValidator_SimpleArrayEncodedPath_InvalidNumber(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -277,7 +311,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path parameter 'burgerId' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_SimpleEncodedPath_IntegerViolation(t *testing.T) {
@@ -385,7 +419,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path parameter 'burgerId' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_LabelEncodedPath_IntegerViolation(t *testing.T) {
@@ -750,7 +784,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path parameter 'burgerId' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_MatrixEncodedPath_PrimitiveNumberViolation(t *testing.T) {
@@ -1073,6 +1107,79 @@ paths:
 	assert.Equal(t, "Path array parameter 'burger' is not a valid number", errors[0].Message)
 }
 
+func TestNewValidator_MatrixEncodedPath_ArrayItems(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{;ids*}/locate:
+    parameters:
+      - name: ids
+        in: path
+        style: matrix
+        explode: true
+        schema:
+          type: array
+          maxItems: 3
+          items:
+            type: integer
+            maximum: 10
+    get:
+      operationId: locateBurgers
+  /sauces/{;names}:
+    parameters:
+      - name: names
+        in: path
+        style: matrix
+        schema:
+          type: array
+          items:
+            type: string
+            enum: [ketchup, mustard]
+    get:
+      operationId: locateSauces`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/;ids=3;ids=4;ids=5/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// each item is validated against the schema of the items.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/;ids=3;ids=40;ids=5;ids=6/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 2)
+	var reasons []string
+	for _, schemaError := range errors[0].SchemaValidationErrors {
+		reasons = append(reasons, schemaError.Reason)
+	}
+	assert.Contains(t, reasons, "maximum: got 40, want 10")
+	assert.Contains(t, reasons, "maxItems: got 4, want 3")
+
+	// an item that repeats a different name is not an exploded matrix array.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/;ids=3;names=4/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path array parameter 'ids' is not matrix encoded", errors[0].Message)
+	assert.Equal(t, "Encode the array using the 'matrix' style, for example: ';ids=1;ids=2'", errors[0].HowToFix)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/sauces/;names=ketchup,mustard", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/sauces/;names=ketchup,mayo", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "$[1]", errors[0].SchemaValidationErrors[0].FieldPath)
+}
+
 func TestNewValidator_PathParams_PathNotFound(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -1550,3 +1657,197 @@ paths:
 	assert.False(t, valid)
 	assert.NotEmpty(t, errors)
 }
+
+func TestNewValidator_PathParams_MultipleTemplatedSegments(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /users/{id}/orders/{orderId}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: string
+            maxLength: 5
+      operationId: getOrder
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/5/orders/abc", nil)
+	valid, errors := v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/abc/orders/abc", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'id' is not a valid integer", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, "the value 'abc' is not a valid integer")
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/1.5/orders/abc", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'id' is not a valid integer", errors[0].Message)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/0/orders/abcdefg", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Path parameter 'id' failed to validate", errors[0].Message)
+	assert.Equal(t, "Path parameter 'orderId' failed to validate", errors[1].Message)
+}
+
+func TestNewValidator_PathParams_OverlappingTemplates(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /files/{a}/{b}:
+    get:
+      parameters:
+        - name: a
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: b
+          in: path
+          required: true
+          schema:
+            type: integer
+      operationId: getFile
+  /files/{a}/latest:
+    get:
+      parameters:
+        - name: a
+          in: path
+          required: true
+          schema:
+            type: string
+      operationId: getLatestFile
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// 'latest' is not an integer, it must be matched against the static segment of the more specific template.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/x/latest", nil)
+	valid, errors := v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/files/x/oldest", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'b' is not a valid integer", errors[0].Message)
+}
+
+func TestNewValidator_PathParamCatchAll(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /files/{path}:
+    parameters:
+      - name: path
+        in: path
+        required: true
+        x-catch-all: true
+        schema:
+          type: string
+          pattern: '^[a-z]+(/[a-z]+)*$'
+    get:
+      operationId: getFile`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/docs/guides/intro", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// every captured segment is part of the value that is validated.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/files/docs/Guides/intro", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "/files/{path}", errors[0].SpecPath)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "does not match pattern")
+}
+
+func TestNewValidator_PathParamsPercentEncoded(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /users/{userId}:
+    get:
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: integer
+            maximum: 20
+  /tags/{tag}:
+    get:
+      parameters:
+        - name: tag
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: '^[a-z ]+$'`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	// the values are decoded before they are validated.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/%31%32", nil)
+	valid, errors := v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/tags/hello%20world", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the decoded value is still held to the schema.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/%32%31", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'userId' failed to validate", errors[0].Message)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/tags/hello%2Fworld", nil)
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "does not match pattern")
+
+	// an escaped '?' or '#' is part of the value, it's not cut off.
+	for _, tag := range []string{"what%3Fnot", "what%23not"} {
+		request, _ = http.NewRequest(http.MethodGet, "https://things.com/tags/"+tag, nil)
+		valid, errors = v.ValidatePathParams(request)
+		assert.False(t, valid, tag)
+		require.Len(t, errors, 1, tag)
+		assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "does not match pattern")
+	}
+}
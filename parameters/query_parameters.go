@@ -5,9 +5,12 @@ package parameters
 
 import (
 	"encoding/json"
+	stdError "errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -26,19 +29,57 @@ const rx = `[:\/\?#\[\]\@!\$&'\(\)\*\+,;=]`
 var rxRxp = regexp.MustCompile(rx)
 
 func (v *paramValidator) ValidateQueryParams(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
 	return v.ValidateQueryParamsWithPathItem(request, pathItem, foundPath)
 }
 
+func (v *paramValidator) ValidateAndExtractQueryParams(request *http.Request) (map[string]any, []*errors.ValidationError) {
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	_, validationErrors := v.ValidateQueryParamsWithPathItem(request, pathItem, foundPath)
+
+	queryParams := helpers.ExtractQueryParams(request.URL.Query())
+	values := make(map[string]any)
+	for _, param := range helpers.ExtractParamsForOperation(request, pathItem) {
+		if param.In != helpers.Query {
+			continue
+		}
+		if value := helpers.DecodeQueryParam(param, queryParams); value != nil {
+			values[param.Name] = value
+		}
+	}
+	return values, validationErrors
+}
+
+func (v *paramValidator) ValidateQueryValues(pathTemplate, method string, values url.Values) (bool, []*errors.ValidationError) {
+	// the values are validated as the query string of a request for the operation.
+	request := &http.Request{
+		Method: strings.ToUpper(method),
+		URL:    &url.URL{Path: pathTemplate, RawQuery: values.Encode()},
+	}
+	var pathItem *v3.PathItem
+	if v.document.Paths != nil {
+		pathItem = v.document.Paths.PathItems.GetOrZero(pathTemplate)
+	}
+	if pathItem != nil && helpers.ExtractOperation(request, pathItem) == nil {
+		pathItem = nil
+	}
+	return v.ValidateQueryParamsWithPathItem(request, pathItem, pathTemplate)
+}
+
 func (v *paramValidator) ValidateQueryParamsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
 	if pathItem == nil {
 		return false, []*errors.ValidationError{{
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -49,36 +90,37 @@ func (v *paramValidator) ValidateQueryParamsWithPathItem(request *http.Request,
 	}
 	// extract params for the operation
 	params := helpers.ExtractParamsForOperation(request, pathItem)
-	queryParams := make(map[string][]*helpers.QueryParam)
+	queryParams := helpers.ExtractQueryParams(request.URL.Query())
+	var reservedParams map[string][]*helpers.QueryParam
 	var validationErrors []*errors.ValidationError
 
-	for qKey, qVal := range request.URL.Query() {
-		// check if the param is encoded as a property / deepObject
-		if strings.IndexRune(qKey, '[') > 0 && strings.IndexRune(qKey, ']') > 0 {
-			stripped := qKey[:strings.IndexRune(qKey, '[')]
-			value := qKey[strings.IndexRune(qKey, '[')+1 : strings.IndexRune(qKey, ']')]
-			queryParams[stripped] = append(queryParams[stripped], &helpers.QueryParam{
-				Key:      stripped,
-				Values:   qVal,
-				Property: value,
-			})
-		} else {
-			queryParams[qKey] = append(queryParams[qKey], &helpers.QueryParam{
-				Key:    qKey,
-				Values: qVal,
-			})
-		}
-	}
-
 	// look through the params for the query key
-doneLooking:
 	for p := range params {
 		if params[p].In == helpers.Query {
+			// a style that is not defined by the specification cannot be decoded.
+			if !helpers.IsDefinedStyle(params[p].Style) {
+				validationErrors = append(validationErrors, errors.ParameterStyleUndefined(params[p]))
+				continue
+			}
 
 			contentWrapped := false
 			var contentType string
+
+			// parameters that allow reserved characters are decoded from the raw query, so the characters survive.
+			found := queryParams
+			if params[p].AllowReserved {
+				if reservedParams == nil {
+					reservedParams = helpers.ExtractQueryParams(helpers.ParseReservedQuery(request.URL.RawQuery))
+				}
+				found = reservedParams
+			}
+
 			// check if this param is found as a set of query strings
-			if jk, ok := queryParams[params[p].Name]; ok {
+			if jk, ok := found[params[p].Name]; ok {
+				if v.options.NestedDeepObjects && params[p].Style == helpers.DeepObject && params[p].Schema != nil {
+					validationErrors = append(validationErrors, v.validateNestedDeepObject(params[p], jk)...)
+					continue
+				}
 			skipValues:
 				for _, fp := range jk {
 					// let's check styles first.
@@ -103,9 +145,29 @@ doneLooking:
 					}
 					pType := sch.Type
 
+					// a scalar can only hold one value, so it cannot be supplied more than once.
+					if fp.Property == "" && len(fp.Values) > 1 && len(pType) > 0 &&
+						!slices.Contains(pType, helpers.Array) && !slices.Contains(pType, helpers.Object) {
+						validationErrors = append(validationErrors,
+							errors.QueryParameterMultipleValues(params[p], sch, fp.Values))
+						continue
+					}
+
 					// for each param, check each type
 					for _, ef := range fp.Values {
 
+						// an empty value is accepted as-is if the parameter allows it, otherwise typed (non-string)
+						// parameters fail with a clear error, rather than failing to convert the empty value.
+						if ef == "" && !slices.Contains(pType, helpers.String) {
+							if v.options.AllowEmptyValue && params[p].AllowEmptyValue {
+								continue
+							}
+							if len(pType) > 0 && !slices.Contains(pType, helpers.Object) {
+								validationErrors = append(validationErrors, errors.QueryParameterEmpty(params[p], sch))
+								continue
+							}
+						}
+
 						// check allowReserved values. If this is set to true, then we can allow the
 						// following characters
 						//  :/?#[]@!$&'()*+,;=
@@ -185,7 +247,7 @@ doneLooking:
 								// well we're already in an array, so we need to check the items schema
 								// to ensure this array items matches the type
 								// only check if items is a schema, not a boolean
-								if sch.Items != nil && sch.Items.IsA() {
+								if (sch.Items != nil && sch.Items.IsA()) || len(sch.PrefixItems) > 0 {
 									validationErrors = append(validationErrors,
 										ValidateQueryArray(sch, params[p], ef, contentWrapped, v.options)...)
 								}
@@ -212,7 +274,7 @@ doneLooking:
 								params[p].Name,
 								helpers.ParameterValidation,
 								helpers.ParameterValidationQuery, v.options)...)
-						break doneLooking
+						continue
 					}
 				}
 				// if there is no match, check if the param is required or not.
@@ -223,6 +285,14 @@ doneLooking:
 		}
 	}
 
+	// in strict mode, any query parameter that is not defined is rejected.
+	if v.options.StrictQueryParams {
+		operation := helpers.ExtractOperation(request, pathItem)
+		for _, qKey := range v.undeclaredQueryParams(request, pathItem, params, queryParams) {
+			validationErrors = append(validationErrors, errors.QueryParameterNotDefined(qKey, operation))
+		}
+	}
+
 	errors.PopulateValidationErrors(validationErrors, request, pathValue)
 
 	if len(validationErrors) > 0 {
@@ -234,14 +304,7 @@ doneLooking:
 func (v *paramValidator) validateSimpleParam(sch *base.Schema, rawParam string, parsedParam any, parameter *v3.Parameter) (validationErrors []*errors.ValidationError) {
 	// check if the param is within an enum
 	if sch.Enum != nil {
-		matchFound := false
-		for _, enumVal := range sch.Enum {
-			if strings.TrimSpace(rawParam) == fmt.Sprint(enumVal.Value) {
-				matchFound = true
-				break
-			}
-		}
-		if !matchFound {
+		if !helpers.ParamMatchesEnum(rawParam, sch) {
 			return []*errors.ValidationError{errors.IncorrectQueryParamEnum(parameter, rawParam, sch)}
 		}
 	}
@@ -257,3 +320,25 @@ func (v *paramValidator) validateSimpleParam(sch *base.Schema, rawParam string,
 		v.options,
 	)
 }
+
+// validateNestedDeepObject decodes a deepObject query parameter that nests arrays and objects using brackets (e.g.
+// 'filter[0][field]=name'), and validates it against the schema of the parameter.
+func (v *paramValidator) validateNestedDeepObject(param *v3.Parameter, values []*helpers.QueryParam) []*errors.ValidationError {
+	sch := param.Schema.Schema()
+	decoded, err := helpers.DecodeDeepObject(values, sch)
+	if err != nil {
+		var nestingErr *helpers.DeepObjectNestingError
+		if stdError.As(err, &nestingErr) {
+			return []*errors.ValidationError{errors.InvalidDeepObjectNesting(param, nestingErr)}
+		}
+		return nil
+	}
+	return ValidateSingleParameterSchema(sch,
+		decoded,
+		"Query parameter",
+		"The query parameter",
+		param.Name,
+		helpers.ParameterValidation,
+		helpers.ParameterValidationQuery,
+		v.options)
+}
@@ -6,6 +6,7 @@ package parameters
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 )
 
@@ -48,6 +50,91 @@ paths:
 	assert.Equal(t, "/a/fishy/on/a/dishy", errors[0].SpecPath)
 }
 
+func TestNewValidator_QueryParamMissing_AfterDefaultEncodedObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          schema:
+            type: object
+            properties:
+              vinegar:
+                type: boolean
+        - name: chips
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: peas
+          in: query
+          required: true
+          schema:
+            type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?vinegar=true", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Query parameter 'chips' is missing", errors[0].Message)
+	assert.Equal(t, "Query parameter 'peas' is missing", errors[1].Message)
+}
+
+func TestNewValidator_QueryParamMissing_MatchesHeader(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: fishy
+          in: header
+          required: true
+          schema:
+            type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model, config.WithOptionalQueryWarnings())
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy", nil)
+
+	valid, queryErrors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, queryErrors, 1)
+
+	valid, headerErrors := v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, headerErrors, 1)
+
+	// a missing required query parameter is reported in the same way as a missing required header.
+	assert.Equal(t, "Query parameter 'fishy' is missing", queryErrors[0].Message)
+	assert.Equal(t, "Header parameter 'fishy' is missing", headerErrors[0].Message)
+	assert.Equal(t, helpers.ParameterValidationQuery, queryErrors[0].ValidationSubType)
+	assert.Equal(t, headerErrors[0].Reason, strings.Replace(queryErrors[0].Reason, "query", "header", 1))
+	assert.Equal(t, headerErrors[0].HowToFix, queryErrors[0].HowToFix)
+	assert.Equal(t, 8, queryErrors[0].SpecLine)
+}
+
 func TestNewValidator_QueryParamNotMissing(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -677,7 +764,7 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=haddock", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
@@ -686,6 +773,95 @@ paths:
 	assert.Equal(t, "Instead of 'haddock', use one of the allowed values: 'cod, halibut'", errors[0].HowToFix)
 }
 
+func TestNewValidator_QueryParamMultipleValues(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: id
+          in: query
+          explode: false
+          schema:
+            type: integer
+        - name: fishy
+          in: query
+          schema:
+            type: array
+            items:
+              type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?id=1&id=2", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'id' received multiple values but expects a single value", errors[0].Message)
+	assert.Equal(t, "query_parameter_multiple_values", errors[0].MessageKey)
+
+	// arrays accept a value for each time the parameter is supplied.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?id=1&fishy=cod&fishy=haddock", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestNewValidator_QueryParamArrayFullSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              pattern: '^[a-z]+$'
+        - name: dishy
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              size:
+                type: string
+                enum: [small, large]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	// the items of the array are checked against the whole schema, not only their type.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod,HADDOCK", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query array parameter 'fishy' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "$[1]", errors[0].SchemaValidationErrors[0].FieldPath)
+
+	// as are the properties of a decoded object.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?dishy[size]=medium", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "value must be one of 'small', 'large'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
 func TestNewValidator_QueryParamInvalidEnumNumber(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -714,6 +890,46 @@ paths:
 	assert.Equal(t, "Instead of '22', use one of the allowed values: '1, 99'", errors[0].HowToFix)
 }
 
+func TestNewValidator_QueryParamEnumTypes(t *testing.T) {
+	tests := []struct {
+		schema string
+		value  string
+		valid  bool
+	}{
+		{"type: integer\n            enum: [1, 2, 3]", "2", true},
+		{"type: integer\n            enum: [1, 2, 3]", "4", false},
+		{"type: integer\n            enum: [1, 2, 3]", "02", true},
+		{"type: number\n            enum: [0.5, 1, 2.5]", "1.0", true},
+		{"type: number\n            enum: [0.5, 1, 2.5]", ".5", true},
+		{"type: number\n            enum: [0.5, 1, 2.5]", "1.5", false},
+		{"type: string\n            enum: ['1', '2', '3']", "2", true},
+		{"type: string\n            enum: ['1', '2', '3']", "2.0", false},
+	}
+	for _, tt := range tests {
+		spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: page
+          in: query
+          schema:
+            ` + tt.schema
+
+		doc, _ := libopenapi.NewDocument([]byte(spec))
+		m, _ := doc.BuildV3Model()
+		v := NewParameterValidator(&m.Model)
+
+		request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?page="+tt.value, nil)
+		valid, errors := v.ValidateQueryParams(request)
+		assert.Equal(t, tt.valid, valid, "%s: %s", tt.schema, tt.value)
+		if !tt.valid {
+			require.Len(t, errors, 1)
+			assert.Equal(t, "Query parameter 'page' does not match allowed values", errors[0].Message)
+		}
+	}
+}
+
 func TestNewValidator_QueryParamValidEnumNumber(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -740,6 +956,36 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
+func TestNewValidator_QueryParamIgnoredKeywords(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: string
+            maxLength: 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=haddock", nil)
+
+	v := NewParameterValidator(&m.Model)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+
+	v = NewParameterValidator(&m.Model, config.WithIgnoredKeywords([]string{"maxLength"}))
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
 func TestNewValidator_QueryParamValidDateFormat(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -2130,6 +2376,53 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
+func TestNewValidator_QueryParamDeepObjectAllowReserved(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          allowReserved: true
+          schema:
+            type: object
+            properties:
+              url:
+                type: string
+                const: "https://x.com/menu?size=large+extra;hot"
+              tag:
+                type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// reserved characters are sent as they are, and survive decoding.
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/burgers?filter[url]=https://x.com/menu?size=large+extra;hot&filter[tag]=a+b", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// percent-encoded values are still decoded.
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/burgers?filter[url]=https%3A%2F%2Fx.com%2Fmenu%3Fsize%3Dlarge%2Bextra%3Bhot", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// without allowReserved, a '+' is a space.
+	doc, _ = libopenapi.NewDocument([]byte(strings.Replace(spec, "allowReserved: true", "allowReserved: false", 1)))
+	m, _ = doc.BuildV3Model()
+	v = NewParameterValidator(&m.Model)
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/burgers?filter[url]=https://x.com/menu?size=large+extra", nil)
+	valid, _ = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+}
+
 func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesNoSchema(t *testing.T) {
 	spec := `---
 openapi: 3.1.0
@@ -3185,3 +3478,428 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, errors[0].Reason, "The query parameter (which is an array) 'id' contains the following duplicates: 'cake, meat'")
 }
+
+func TestNewValidator_QueryParamTuple(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: point
+          in: query
+          explode: false
+          schema:
+            type: array
+            prefixItems:
+              - type: string
+              - type: number
+            items: false`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?point=a,1", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// element 1 must be a number.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?point=a,b", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "$[1]", errors[0].SchemaValidationErrors[0].FieldPath)
+	assert.Equal(t, "got string, want number", errors[0].SchemaValidationErrors[0].Reason)
+
+	// element 0 must be a string, a number is not coerced.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?point=1,1", nil)
+	valid, _ = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	// no items beyond the prefix are allowed.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?point=a,1,2", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "$[2]", errors[0].SchemaValidationErrors[0].FieldPath)
+}
+
+func TestNewValidator_QueryParamTuple_AdditionalItems(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: point
+          in: query
+          schema:
+            type: array
+            prefixItems:
+              - type: string
+            items:
+              type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?point=a,true,false", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?point=a,true,nope", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "$[2]", errors[0].SchemaValidationErrors[0].FieldPath)
+	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_QueryParamEmptyValue(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          allowEmptyValue: true
+          schema:
+            type: integer
+        - name: chips
+          in: query
+          schema:
+            type: integer
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// without the option, empty values for typed parameters fail clearly.
+	v := NewParameterValidator(&m.Model)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=&chips=", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Query parameter 'fishy' has an empty value", errors[0].Message)
+	assert.Equal(t, "Query parameter 'chips' has an empty value", errors[1].Message)
+
+	// with the option, 'allowEmptyValue' is honored.
+	v = NewParameterValidator(&m.Model, config.WithAllowEmptyValue())
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'chips' has an empty value", errors[0].Message)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=&chips=1", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestNewValidator_QueryParamNotDefined_SpecLocation(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model, config.WithStrictQueryParams())
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?chips=large", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+
+	// the missing parameter points at its declaration.
+	assert.Equal(t, "Query parameter 'fishy' is missing", errors[0].Message)
+	assert.Equal(t, 8, errors[0].SpecLine)
+	assert.Equal(t, 11, errors[0].SpecCol)
+
+	// the undefined parameter points at the operation's parameters.
+	assert.Equal(t, "Query parameter 'chips' is not defined", errors[1].Message)
+	assert.Equal(t, 5, errors[1].SpecLine)
+	assert.Equal(t, 7, errors[1].SpecCol)
+}
+
+func TestNewValidator_QueryParamSchemaCompilationFailed(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          schema:
+            type: string
+            pattern: "^(?!cod).*$"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=haddock", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	// the specification is at fault, so the error points at the schema, not the request.
+	assert.True(t, errors[0].IsSchemaCompilationError())
+	assert.Equal(t, helpers.ParameterValidation, errors[0].ValidationSubType)
+	assert.Contains(t, errors[0].Message, "Schema cannot be compiled: ")
+	assert.Equal(t, 8, errors[0].SpecLine)
+}
+
+func TestNewValidator_QueryParamNestedDeepObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: array
+            items:
+              type: object
+              required: [field, op]
+              properties:
+                field:
+                  type: string
+                op:
+                  type: string
+                  enum: [eq, ne]
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model, config.WithNestedDeepObjects())
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?filter[0][field]=name&filter[0][op]=eq&filter[1][field]=size&filter[1][op]=ne", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the second filter has an operator that is not allowed.
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?filter[0][field]=name&filter[0][op]=eq&filter[1][field]=size&filter[1][op]=gt", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' failed to validate", errors[0].Message)
+
+	// the brackets nest deeper than the schema.
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?filter[0][field][name]=size&filter[0][op]=eq", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' is not structured like its schema", errors[0].Message)
+	assert.Equal(t, "The query parameter 'filter' has the 'deepObject' style defined, however the key "+
+		"'filter[0][field][name]' does not fit the schema, it nests deeper than the schema, which is a 'string'",
+		errors[0].Reason)
+	assert.Equal(t, 8, errors[0].SpecLine)
+}
+
+func TestNewValidator_ValidateAndExtractQueryParams(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+        - name: fishy
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: fresh
+          in: query
+          schema:
+            type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?limit=10&fishy=cod,haddock", nil)
+
+	values, errors := v.ValidateAndExtractQueryParams(request)
+	assert.Empty(t, errors)
+	assert.Equal(t, map[string]any{"limit": int64(10), "fishy": []any{"cod", "haddock"}}, values)
+
+	// the values are returned alongside any errors.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?limit=1000&fresh=true", nil)
+
+	values, errors = v.ValidateAndExtractQueryParams(request)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, map[string]any{"limit": int64(1000), "fresh": true}, values)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy/nope", nil)
+
+	values, errors = v.ValidateAndExtractQueryParams(request)
+	assert.Nil(t, values)
+	assert.Len(t, errors, 1)
+}
+
+func TestNewValidator_ValidateQueryValues(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/fries:
+    get:
+      parameters:
+        - name: size
+          in: query
+          required: true
+          schema:
+            type: string
+            enum: [small, large]
+        - name: count
+          in: query
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	valid, errors := v.ValidateQueryValues("/burgers/{burgerId}/fries", "get", url.Values{
+		"size": {"large"}, "count": {"2"},
+	})
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	valid, errors = v.ValidateQueryValues("/burgers/{burgerId}/fries", http.MethodGet, url.Values{"count": {"two"}})
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Query parameter 'size' is missing", errors[0].Message)
+	assert.Equal(t, "Query parameter 'count' is not a valid number", errors[1].Message)
+
+	// the path template, or the operation, does not exist.
+	valid, errors = v.ValidateQueryValues("/burgers/{burgerId}", http.MethodGet, url.Values{})
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "GET Path '/burgers/{burgerId}' not found", errors[0].Message)
+	valid, errors = v.ValidateQueryValues("/burgers/{burgerId}/fries", http.MethodPost, url.Values{})
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+}
+
+func TestNewValidator_ParamStyleUndefined(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          style: weird
+          schema:
+            type: string
+        - name: x
+          in: query
+          style: weird
+          schema:
+            type: integer
+        - name: session
+          in: cookie
+          style: weird
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1?x=not-a-number", nil)
+	request.AddCookie(&http.Cookie{Name: "session", Value: "not-a-number"})
+
+	// the values are not decoded, only the style is reported.
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Unsupported style 'weird' for query parameter 'x'", errors[0].Message)
+
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Unsupported style 'weird' for path parameter 'burgerId'", errors[0].Message)
+
+	valid, errors = v.ValidateCookieParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Unsupported style 'weird' for cookie parameter 'session'", errors[0].Message)
+}
+
+func TestNewValidator_QueryParamNotDefined_ApiKeyAndExplodedObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      security:
+        - ApiKey: []
+      parameters:
+        - name: filter
+          in: query
+          schema:
+            type: object
+            properties:
+              color:
+                type: string
+              size:
+                type: integer
+components:
+  securitySchemes:
+    ApiKey:
+      type: apiKey
+      in: query
+      name: api_key`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model, config.WithStrictQueryParams())
+
+	// the api key is declared by the security scheme, the properties of the object are exploded into the query.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?api_key=abc&color=red&size=2", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers?api_key=abc&color=red&fries=large", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fries' is not defined", errors[0].Message)
+}
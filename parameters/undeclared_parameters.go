@@ -0,0 +1,112 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package parameters
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi-validator/paths"
+)
+
+func (v *paramValidator) UndeclaredParameters(request *http.Request) ([]string, []string) {
+	pathItem, errs, _ := paths.FindPathWithOptions(request, v.document, v.options)
+	if len(errs) > 0 {
+		return nil, nil
+	}
+	return v.UndeclaredParametersWithPathItem(request, pathItem)
+}
+
+func (v *paramValidator) UndeclaredParametersWithPathItem(request *http.Request, pathItem *v3.PathItem) ([]string, []string) {
+	if pathItem == nil {
+		return nil, nil
+	}
+	params := helpers.ExtractParamsForOperation(request, pathItem)
+
+	query := v.undeclaredQueryParams(request, pathItem, params, helpers.ExtractQueryParams(request.URL.Query()))
+
+	// api keys are declared by the security schemes of the operation, rather than as parameters.
+	apiKeyHeaders, _ := v.apiKeyNames(request, pathItem)
+
+	declared := make(map[string]bool)
+	for _, param := range params {
+		if param.In == helpers.Header {
+			declared[strings.ToLower(param.Name)] = true
+		}
+	}
+	for _, name := range apiKeyHeaders {
+		declared[strings.ToLower(name)] = true
+	}
+	var headers []string
+	for name := range request.Header {
+		if !declared[strings.ToLower(name)] && !helpers.IsStandardRequestHeader(name) {
+			headers = append(headers, name)
+		}
+	}
+	sort.Strings(headers)
+	return headers, query
+}
+
+// undeclaredQueryParams returns the names of the query parameters of a request that are not declared by the
+// operation, in order. The api keys of the security schemes, and the properties of an object parameter that is
+// exploded with the default form encoding (e.g. '?color=red' for a 'filter' object), are declared too.
+func (v *paramValidator) undeclaredQueryParams(request *http.Request, pathItem *v3.PathItem, params []*v3.Parameter,
+	queryParams map[string][]*helpers.QueryParam,
+) []string {
+	declared := make(map[string]bool)
+	for _, param := range params {
+		if param.In != helpers.Query {
+			continue
+		}
+		declared[param.Name] = true
+		if param.Schema == nil || !param.IsDefaultFormEncoding() {
+			continue
+		}
+		if sch := param.Schema.Schema(); sch != nil && slices.Contains(sch.Type, helpers.Object) && sch.Properties != nil {
+			for name := range sch.Properties.KeysFromOldest() {
+				declared[name] = true
+			}
+		}
+	}
+	_, apiKeyQuery := v.apiKeyNames(request, pathItem)
+	for _, name := range apiKeyQuery {
+		declared[name] = true
+	}
+	var undeclared []string
+	for qKey := range queryParams {
+		if !declared[qKey] {
+			undeclared = append(undeclared, qKey)
+		}
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
+
+// apiKeyNames returns the names of the headers and query parameters that carry the api keys of the security schemes
+// of an operation.
+func (v *paramValidator) apiKeyNames(request *http.Request, pathItem *v3.PathItem) (headers []string, query []string) {
+	if v.document.Components == nil {
+		return nil, nil
+	}
+	for _, sec := range helpers.ExtractSecurityForOperation(request, pathItem) {
+		for secName := range sec.Requirements.KeysFromOldest() {
+			secScheme := v.document.Components.SecuritySchemes.GetOrZero(secName)
+			if secScheme == nil || !strings.EqualFold(secScheme.Type, "apiKey") {
+				continue
+			}
+			switch secScheme.In {
+			case helpers.Header:
+				headers = append(headers, secScheme.Name)
+			case helpers.Query:
+				query = append(query, secScheme.Name)
+			}
+		}
+	}
+	return headers, query
+}
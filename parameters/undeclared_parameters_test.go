@@ -0,0 +1,71 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package parameters
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndeclaredParameters(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      security:
+        - headerKey: []
+        - queryKey: []
+      parameters:
+        - name: coffeeCups
+          in: header
+          schema:
+            type: integer
+        - name: size
+          in: query
+          schema:
+            type: string
+components:
+  securitySchemes:
+    headerKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    queryKey:
+      type: apiKey
+      in: query
+      name: api_key`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/vending/drinks?size=large&sugar=2&api_key=abc&color[r]=1", nil)
+	request.Header.Set("CoffeeCups", "2")
+	request.Header.Set("Coffee-Cup", "2")
+	request.Header.Set("X-API-Key", "abc")
+	request.Header.Set("X-Trace", "abc")
+	request.Header.Set("User-Agent", "burger-client/1.0")
+	request.Header.Set("Accept", "application/json")
+
+	headers, query := v.UndeclaredParameters(request)
+	assert.Equal(t, []string{"Coffee-Cup", "X-Trace"}, headers)
+	assert.Equal(t, []string{"color", "sugar"}, query)
+
+	// nothing is undeclared.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/vending/drinks?size=large", nil)
+	request.Header.Set("coffeecups", "2")
+	headers, query = v.UndeclaredParameters(request)
+	assert.Empty(t, headers)
+	assert.Empty(t, query)
+
+	// an operation that cannot be found has nothing to compare to.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/vending/snacks?size=large", nil)
+	headers, query = v.UndeclaredParameters(request)
+	assert.Nil(t, headers)
+	assert.Nil(t, query)
+}
@@ -6,6 +6,7 @@ package parameters
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
 	"reflect"
 	"strings"
@@ -14,8 +15,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
 
 	stdError "errors"
 
@@ -34,6 +34,11 @@ func ValidateSingleParameterSchema(
 	subValType string,
 	o *config.ValidationOptions,
 ) (validationErrors []*errors.ValidationError) {
+	// a value that can't fail the schema does not need the schema to be compiled.
+	if isUnconstrainedScalar(schema, rawObject) {
+		return nil
+	}
+
 	// Get the JSON Schema for the parameter definition.
 	jsonSchema, err := buildJsonRender(schema)
 	if err != nil {
@@ -43,18 +48,68 @@ func ValidateSingleParameterSchema(
 	// Attempt to compile the JSON Schema
 	jsch, err := helpers.NewCompiledSchema(name, jsonSchema, o)
 	if err != nil {
-		return
+		return []*errors.ValidationError{
+			errors.SchemaCompilationFailed(schema, helpers.ParameterValidation, jsonSchema, err),
+		}
 	}
 
 	// Validate the object and report any errors.
 	scErrs := jsch.Validate(rawObject)
 	var werras *jsonschema.ValidationError
 	if stdError.As(scErrs, &werras) {
-		validationErrors = formatJsonSchemaValidationError(schema, werras, entity, reasonEntity, name, validationType, subValType)
+		validationErrors = formatJsonSchemaValidationError(schema, werras, rawObject, entity, reasonEntity, name, validationType, subValType)
 	}
 	return validationErrors
 }
 
+// annotationKeywords are the keywords of a schema that do not constrain a value.
+var annotationKeywords = map[string]bool{
+	"type": true, "title": true, "description": true, "default": true, "example": true, "examples": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true, "$comment": true,
+}
+
+// isUnconstrainedScalar returns true if a schema holds a scalar value to nothing other than its type (e.g. a plain
+// 'type: string'), and the value is already that type, so validating it against the schema cannot fail.
+func isUnconstrainedScalar(schema *base.Schema, value any) bool {
+	if schema == nil {
+		return false
+	}
+	low := schema.GoLow()
+	if low == nil || low.RootNode == nil || low.RootNode.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(low.RootNode.Content); i += 2 {
+		key := low.RootNode.Content[i].Value
+		if !annotationKeywords[key] && !strings.HasPrefix(key, "x-") {
+			return false
+		}
+	}
+	if len(schema.Type) == 0 {
+		return true
+	}
+	for _, t := range schema.Type {
+		switch v := value.(type) {
+		case string:
+			if t == helpers.String {
+				return true
+			}
+		case bool:
+			if t == helpers.Boolean {
+				return true
+			}
+		case int, int32, int64:
+			if t == helpers.Integer || t == helpers.Number {
+				return true
+			}
+		case float64:
+			if t == helpers.Number || (t == helpers.Integer && v == math.Trunc(v) && !math.IsInf(v, 0)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // buildJsonRender build a JSON render of the schema.
 func buildJsonRender(schema *base.Schema) ([]byte, error) {
 	if schema == nil {
@@ -62,7 +117,7 @@ func buildJsonRender(schema *base.Schema) ([]byte, error) {
 		return nil, stdError.New("buildJSONRender nil pointer")
 	}
 
-	renderedSchema, err := schema.Render()
+	renderedSchema, err := helpers.RenderSchema(schema)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +150,7 @@ func ValidateParameterSchema(
 	var validationErrors []*errors.ValidationError
 
 	// 1. build a JSON render of the schema.
-	renderedSchema, _ := schema.RenderInline()
+	renderedSchema, _ := helpers.RenderSchemaInline(schema)
 	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
 
 	// 2. decode the object into a json blob.
@@ -123,12 +178,17 @@ func ValidateParameterSchema(
 		}
 		validEncoding = true
 	}
-	// 3. create a new json schema compiler and add the schema to it
-	jsch, _ := helpers.NewCompiledSchema(name, jsonSchema, validationOptions)
+	// 3. create a new json schema compiler and add the schema to it.
+	jsch, err := helpers.NewCompiledSchema(name, jsonSchema, validationOptions)
+	if err != nil {
+		return []*errors.ValidationError{
+			errors.SchemaCompilationFailed(schema, helpers.ParameterValidation, jsonSchema, err),
+		}
+	}
 
 	// 4. validate the object against the schema
 	var scErrs error
-	if validEncoding {
+	if validEncoding && jsch != nil {
 		p := decodedObj
 		if rawIsMap {
 			if g, ko := rawObject.(map[string]interface{}); ko {
@@ -148,6 +208,8 @@ func ValidateParameterSchema(
 							ValidationType:    validationType,
 							ValidationSubType: subValType,
 							Message:           fmt.Sprintf("%s '%s' failed to validate", entity, name),
+							MessageKey:        errors.MessageKeyParameterSchemaInvalid,
+							MessageArgs:       map[string]any{"entity": entity, "name": name},
 							Reason: fmt.Sprintf("%s '%s' is defined as an object, "+
 								"however it failed to pass a schema validation", reasonEntity, name),
 							SpecLine:               schema.GoLow().Type.KeyNode.Line,
@@ -167,7 +229,7 @@ func ValidateParameterSchema(
 	}
 	var werras *jsonschema.ValidationError
 	if stdError.As(scErrs, &werras) {
-		validationErrors = formatJsonSchemaValidationError(schema, werras, entity, reasonEntity, name, validationType, subValType)
+		validationErrors = formatJsonSchemaValidationError(schema, werras, decodedObj, entity, reasonEntity, name, validationType, subValType)
 	}
 
 	// if there are no validationErrors, check that the supplied value is even JSON
@@ -179,6 +241,8 @@ func ValidateParameterSchema(
 					ValidationType:    validationType,
 					ValidationSubType: subValType,
 					Message:           fmt.Sprintf("%s '%s' cannot be decoded", entity, name),
+					MessageKey:        errors.MessageKeyParameterCannotBeDecoded,
+					MessageArgs:       map[string]any{"entity": entity, "name": name},
 					Reason: fmt.Sprintf("%s '%s' is defined as an object, "+
 						"however it failed to be decoded as an object", reasonEntity, name),
 					SpecLine: schema.GoLow().RootNode.Line,
@@ -191,30 +255,35 @@ func ValidateParameterSchema(
 	return validationErrors
 }
 
-func formatJsonSchemaValidationError(schema *base.Schema, scErrs *jsonschema.ValidationError, entity string, reasonEntity string, name string, validationType string, subValType string) (validationErrors []*errors.ValidationError) {
+func formatJsonSchemaValidationError(schema *base.Schema, scErrs *jsonschema.ValidationError, instance any, entity string, reasonEntity string, name string, validationType string, subValType string) (validationErrors []*errors.ValidationError) {
 	// flatten the validationErrors
-	schFlatErrs := scErrs.BasicOutput().Errors
+	schFlatErrs := helpers.FlattenSchemaErrors(scErrs, instance)
 	var schemaValidationErrors []*errors.SchemaValidationFailure
+
+	// render the schema once, it's attached to every failure and used to locate the fields that failed.
+	var renderedSchema string
+	var decodedSchema any
+	if schema != nil {
+		if rendered, err := helpers.RenderSchemaInline(schema); err == nil && rendered != nil {
+			renderedSchema = string(rendered)
+			_ = yaml.Unmarshal(rendered, &decodedSchema)
+		}
+	}
 	for q := range schFlatErrs {
 		er := schFlatErrs[q]
 
-		errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+		errMsg := helpers.SchemaErrorMessage(er.Error.Kind)
 		if er.KeywordLocation == "" || helpers.IgnoreRegex.MatchString(errMsg) {
 			continue // ignore this error, it's not useful
 		}
 
 		fail := &errors.SchemaValidationFailure{
-			Reason:        errMsg,
+			Reason:        helpers.ConditionalReason(er.KeywordLocation, errMsg),
 			Location:      er.KeywordLocation,
 			OriginalError: scErrs,
 		}
-		if schema != nil {
-			rendered, err := schema.RenderInline()
-			if err == nil && rendered != nil {
-				fail.ReferenceSchema = string(rendered)
-			}
-		}
-		schemaValidationErrors = append(schemaValidationErrors, fail)
+		fail.ReferenceSchema = renderedSchema
+		schemaValidationErrors = append(schemaValidationErrors, errors.ExpandSchemaValidationFailure(fail, er, decodedSchema)...)
 	}
 	schemaType := "undefined"
 	line := 0
@@ -271,6 +340,8 @@ func formatJsonSchemaValidationError(schema *base.Schema, scErrs *jsonschema.Val
 		ValidationType:    validationType,
 		ValidationSubType: subValType,
 		Message:           fmt.Sprintf("%s '%s' failed to validate", entity, name),
+		MessageKey:        errors.MessageKeyParameterSchemaInvalid,
+		MessageArgs:       map[string]any{"entity": entity, "name": name},
 		Reason: fmt.Sprintf("%s '%s' is defined as an %s, "+
 			"however it failed to pass a schema validation", reasonEntity, name, schemaType),
 		SpecLine:               line,
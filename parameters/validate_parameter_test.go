@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 
 	"github.com/pb33f/libopenapi"
@@ -278,3 +279,178 @@ func TestHeaderSchemaStringNoJSON(t *testing.T) {
 	assert.Len(t, headerErrors, 1)
 	assert.Equal(t, "response header 'chicken-nuggets' is defined as an boolean or integer, however it failed to pass a schema validation", headerErrors[0].Reason)
 }
+
+func scalarParamSchemas(t testing.TB) map[string]*base.Schema {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    plain:
+      type: string
+      description: a plain string
+      x-internal: true
+    pattern:
+      type: string
+      pattern: '^[a-z]+$'
+    integer:
+      type: integer
+    int32:
+      type: integer
+      format: int32
+    multi:
+      type: [boolean, number]
+    empty: {}`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	schemas := make(map[string]*base.Schema)
+	for name, proxy := range m.Model.Components.Schemas.FromOldest() {
+		schemas[name] = proxy.Schema()
+	}
+	return schemas
+}
+
+func TestIsUnconstrainedScalar(t *testing.T) {
+	schemas := scalarParamSchemas(t)
+
+	assert.True(t, isUnconstrainedScalar(schemas["plain"], "burger"))
+	assert.True(t, isUnconstrainedScalar(schemas["integer"], int64(2)))
+	assert.True(t, isUnconstrainedScalar(schemas["integer"], float64(2)))
+	assert.True(t, isUnconstrainedScalar(schemas["multi"], true))
+	assert.True(t, isUnconstrainedScalar(schemas["multi"], 1.5))
+	assert.True(t, isUnconstrainedScalar(schemas["empty"], "anything"))
+
+	// constrained schemas, or values of the wrong type, are validated against the schema.
+	assert.False(t, isUnconstrainedScalar(schemas["pattern"], "burger"))
+	assert.False(t, isUnconstrainedScalar(schemas["int32"], int64(2)))
+	assert.False(t, isUnconstrainedScalar(schemas["integer"], 1.5))
+	assert.False(t, isUnconstrainedScalar(schemas["integer"], "2"))
+	assert.False(t, isUnconstrainedScalar(schemas["plain"], true))
+	assert.False(t, isUnconstrainedScalar(nil, "burger"))
+
+	// the fast path gives the same result as the schema.
+	assert.Empty(t, ValidateSingleParameterSchema(schemas["plain"], "burger", "", "", "plain", "", "", nil))
+	assert.Len(t, ValidateSingleParameterSchema(schemas["integer"], 1.5, "", "", "integer", "", "", nil), 1)
+}
+
+func BenchmarkValidateSingleParameterSchema_Unconstrained(b *testing.B) {
+	sch := scalarParamSchemas(b)["plain"]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateSingleParameterSchema(sch, "burger", "", "", "plain", "", "", nil)
+	}
+}
+
+func BenchmarkValidateSingleParameterSchema_Constrained(b *testing.B) {
+	sch := scalarParamSchemas(b)["pattern"]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateSingleParameterSchema(sch, "burger", "", "", "pattern", "", "", nil)
+	}
+}
+
+func TestValidateParams_ComponentRefs(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - $ref: '#/components/parameters/BurgerId'
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+        - $ref: '#/components/parameters/Tracing'
+        - $ref: '#/components/parameters/Session'
+components:
+  parameters:
+    BurgerId:
+      name: burgerId
+      in: path
+      required: true
+      schema:
+        $ref: '#/components/schemas/Id'
+    Limit:
+      name: limit
+      in: query
+      required: true
+      schema:
+        $ref: '#/components/schemas/Limit'
+    Tracing:
+      name: X-Trace-Id
+      in: header
+      required: true
+      schema:
+        $ref: '#/components/schemas/Id'
+    Session:
+      $ref: '#/components/parameters/SessionCookie'
+    SessionCookie:
+      name: session
+      in: cookie
+      required: true
+      schema:
+        type: string
+        minLength: 4
+  schemas:
+    Id:
+      $ref: '#/components/schemas/Integer'
+    Integer:
+      type: integer
+      minimum: 1
+    Limit:
+      type: integer
+      maximum: 100`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/3?limit=10", nil)
+	request.Header.Set("X-Trace-Id", "7")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "abcd"})
+
+	valid, errors := v.ValidatePathParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+	valid, errors = v.ValidateCookieParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the name, location and schema of each parameter come from the component.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/0?limit=1000", nil)
+	request.Header.Set("X-Trace-Id", "0")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	valid, errors = v.ValidatePathParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
+	assert.Equal(t, "minimum: got 0, want 1", errors[0].SchemaValidationErrors[0].Reason)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "maximum: got 1,000, want 100", errors[0].SchemaValidationErrors[0].Reason)
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "minimum: got 0, want 1", errors[0].SchemaValidationErrors[0].Reason)
+
+	valid, errors = v.ValidateCookieParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "minLength: got 3, want 4", errors[0].SchemaValidationErrors[0].Reason)
+
+	// a missing parameter is reported with the name from the component.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/3", nil)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'limit' is missing", errors[0].Message)
+}
@@ -18,7 +18,7 @@ import (
 )
 
 func (v *paramValidator) ValidateSecurity(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
@@ -31,6 +31,8 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -60,10 +62,12 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 			if v.document.Components == nil || v.document.Components.SecuritySchemes.GetOrZero(secName) == nil {
 				validationErrors := []*errors.ValidationError{
 					{
-						Message: fmt.Sprintf("Security scheme '%s' is missing", secName),
+						Message:     fmt.Sprintf("Security scheme '%s' is missing", secName),
+						MessageKey:  errors.MessageKeySecuritySchemeMissing,
+						MessageArgs: map[string]any{"scheme": secName},
 						Reason: fmt.Sprintf("The security scheme '%s' is defined as being required, "+
 							"however it's missing from the components", secName),
-						ValidationType: "security",
+						ValidationType: helpers.SecurityValidation,
 						SpecLine:       sec.GoLow().Requirements.ValueNode.Line,
 						SpecCol:        sec.GoLow().Requirements.ValueNode.Column,
 						HowToFix:       "Add the missing security scheme to the components",
@@ -83,8 +87,10 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 						validationErrors := []*errors.ValidationError{
 							{
 								Message:           fmt.Sprintf("Authorization header for '%s' scheme", secScheme.Scheme),
+								MessageKey:        errors.MessageKeyAuthorizationHeaderMissing,
+								MessageArgs:       map[string]any{"scheme": secScheme.Scheme},
 								Reason:            "Authorization header was not found",
-								ValidationType:    "security",
+								ValidationType:    helpers.SecurityValidation,
 								ValidationSubType: secScheme.Scheme,
 								SpecLine:          sec.GoLow().Requirements.ValueNode.Line,
 								SpecCol:           sec.GoLow().Requirements.ValueNode.Column,
@@ -106,8 +112,10 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 						validationErrors := []*errors.ValidationError{
 							{
 								Message:           fmt.Sprintf("API Key %s not found in header", secScheme.Name),
+								MessageKey:        errors.MessageKeyAPIKeyHeaderMissing,
+								MessageArgs:       map[string]any{"name": secScheme.Name},
 								Reason:            "API Key not found in http header for security scheme 'apiKey' with type 'header'",
-								ValidationType:    "security",
+								ValidationType:    helpers.SecurityValidation,
 								ValidationSubType: "apiKey",
 								SpecLine:          sec.GoLow().Requirements.ValueNode.Line,
 								SpecCol:           sec.GoLow().Requirements.ValueNode.Column,
@@ -132,8 +140,10 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 						validationErrors := []*errors.ValidationError{
 							{
 								Message:           fmt.Sprintf("API Key %s not found in query", secScheme.Name),
+								MessageKey:        errors.MessageKeyAPIKeyQueryMissing,
+								MessageArgs:       map[string]any{"name": secScheme.Name},
 								Reason:            "API Key not found in URL query for security scheme 'apiKey' with type 'query'",
-								ValidationType:    "security",
+								ValidationType:    helpers.SecurityValidation,
 								ValidationSubType: "apiKey",
 								SpecLine:          sec.GoLow().Requirements.ValueNode.Line,
 								SpecCol:           sec.GoLow().Requirements.ValueNode.Column,
@@ -149,7 +159,7 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 					}
 				}
 				if secScheme.In == "cookie" {
-					cookies := request.Cookies()
+					cookies := helpers.ParseCookies(request)
 					cookieFound := false
 					for _, cookie := range cookies {
 						if cookie.Name == secScheme.Name {
@@ -161,8 +171,10 @@ func (v *paramValidator) ValidateSecurityWithPathItem(request *http.Request, pat
 						validationErrors := []*errors.ValidationError{
 							{
 								Message:           fmt.Sprintf("API Key %s not found in cookies", secScheme.Name),
+								MessageKey:        errors.MessageKeyAPIKeyCookieMissing,
+								MessageArgs:       map[string]any{"name": secScheme.Name},
 								Reason:            "API Key not found in http request cookies for security scheme 'apiKey' with type 'cookie'",
-								ValidationType:    "security",
+								ValidationType:    helpers.SecurityValidation,
 								ValidationSubType: "apiKey",
 								SpecLine:          sec.GoLow().Requirements.ValueNode.Line,
 								SpecCol:           sec.GoLow().Requirements.ValueNode.Column,
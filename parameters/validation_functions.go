@@ -4,7 +4,6 @@
 package parameters
 
 import (
-	"fmt"
 	"slices"
 	"strconv"
 	"strings"
@@ -20,17 +19,32 @@ import (
 // ValidateCookieArray will validate a cookie parameter that is an array
 func ValidateCookieArray(
 	sch *base.Schema, param *v3.Parameter, value string,
+) []*errors.ValidationError {
+	return ValidateCookieArrayWithOptions(sch, param, value, nil)
+}
+
+// ValidateCookieArrayWithOptions works the same as ValidateCookieArray, the options are used to validate the array
+// against the schema.
+func ValidateCookieArrayWithOptions(
+	sch *base.Schema, param *v3.Parameter, value string, validationOptions *config.ValidationOptions,
 ) []*errors.ValidationError {
 	var validationErrors []*errors.ValidationError
-	itemsSchema := sch.Items.A.Schema()
 
-	// header arrays can only be encoded as CSV
+	// cookie arrays can only be encoded as CSV
 	items := helpers.ExplodeQueryValue(value, helpers.DefaultDelimited)
 
+	// the type of each item can only be checked if items is a schema, not a boolean (or missing).
+	var itemsSchema *base.Schema
+	var itemTypes []string
+	if sch.Items != nil && sch.Items.IsA() {
+		itemsSchema = sch.Items.A.Schema()
+		itemTypes = itemsSchema.Type
+	}
+
 	// now check each item in the array
 	for _, item := range items {
 		// for each type defined in the item's schema, check the item
-		for _, itemType := range itemsSchema.Type {
+		for _, itemType := range itemTypes {
 			switch itemType {
 			case helpers.Integer, helpers.Number:
 				if _, err := strconv.ParseFloat(item, 64); err != nil {
@@ -54,18 +68,93 @@ func ValidateCookieArray(
 			}
 		}
 	}
+
+	// the items are the right type, so check the rest of the schema (e.g. item enums, 'pattern', 'minItems').
+	if len(validationErrors) == 0 {
+		validationErrors = validateDecodedArray(sch, items,
+			"Cookie array parameter",
+			"The cookie parameter (which is an array)",
+			param.Name,
+			helpers.ParameterValidationCookie,
+			validationOptions)
+	}
 	return validationErrors
 }
 
+// validateDecodedArray validates the items of a decoded array parameter against the whole schema of the parameter.
+// Each item is converted into the type defined by the 'items' schema first. Arrays of objects are skipped, as each
+// object is validated against the 'items' schema as it's decoded.
+func validateDecodedArray(
+	sch *base.Schema,
+	items []string,
+	entity string,
+	reasonEntity string,
+	name string,
+	subValType string,
+	validationOptions *config.ValidationOptions,
+) []*errors.ValidationError {
+	var itemsSchema *base.Schema
+	if sch.Items != nil && sch.Items.IsA() {
+		itemsSchema = sch.Items.A.Schema()
+	}
+	if itemsSchema != nil && slices.Contains(itemsSchema.Type, helpers.Object) {
+		return nil
+	}
+	decoded := make([]any, len(items))
+	for i, item := range items {
+		decoded[i] = helpers.CastParamValue(item, itemsSchema)
+	}
+	return ValidateSingleParameterSchema(sch,
+		decoded,
+		entity,
+		reasonEntity,
+		name,
+		helpers.ParameterValidation,
+		subValType,
+		validationOptions)
+}
+
 // ValidateHeaderArray will validate a header parameter that is an array
 func ValidateHeaderArray(
 	sch *base.Schema, param *v3.Parameter, value string,
 ) []*errors.ValidationError {
-	var validationErrors []*errors.ValidationError
-	itemsSchema := sch.Items.A.Schema()
+	return ValidateHeaderArrayWithOptions(sch, param, value, nil)
+}
 
+// ValidateHeaderArrayWithOptions works the same as ValidateHeaderArray, the options are used to validate the array
+// against the schema.
+func ValidateHeaderArrayWithOptions(
+	sch *base.Schema, param *v3.Parameter, value string, validationOptions *config.ValidationOptions,
+) []*errors.ValidationError {
 	// header arrays can only be encoded as CSV
-	items := helpers.ExplodeQueryValue(value, helpers.DefaultDelimited)
+	return validateHeaderArrayItems(sch, param, helpers.ExplodeQueryValue(value, helpers.DefaultDelimited), validationOptions)
+}
+
+// ValidateExplodedHeaderArray will validate a header parameter that is an exploded array, the items can be
+// sent as repeated header lines (e.g. 'X-Ids: 1' and 'X-Ids: 2'), each line may also hold CSV encoded items.
+func ValidateExplodedHeaderArray(
+	sch *base.Schema, param *v3.Parameter, values []string,
+) []*errors.ValidationError {
+	return ValidateExplodedHeaderArrayWithOptions(sch, param, values, nil)
+}
+
+// ValidateExplodedHeaderArrayWithOptions works the same as ValidateExplodedHeaderArray, the options are used to
+// validate the array against the schema.
+func ValidateExplodedHeaderArrayWithOptions(
+	sch *base.Schema, param *v3.Parameter, values []string, validationOptions *config.ValidationOptions,
+) []*errors.ValidationError {
+	var items []string
+	for _, value := range values {
+		items = append(items, helpers.ExplodeQueryValue(value, helpers.DefaultDelimited)...)
+	}
+	return validateHeaderArrayItems(sch, param, items, validationOptions)
+}
+
+func validateHeaderArrayItems(
+	sch *base.Schema, param *v3.Parameter, items []string, validationOptions *config.ValidationOptions,
+) []*errors.ValidationError {
+	var validationErrors []*errors.ValidationError
+	itemsSchema := sch.Items.A.Schema()
 
 	// now check each item in the array
 	for _, item := range items {
@@ -94,6 +183,16 @@ func ValidateHeaderArray(
 			}
 		}
 	}
+
+	// the items are the right type, so check the rest of the schema (e.g. item enums, 'pattern', 'minItems').
+	if len(validationErrors) == 0 {
+		validationErrors = validateDecodedArray(sch, items,
+			"Header array parameter",
+			"The header parameter (which is an array)",
+			param.Name,
+			helpers.ParameterValidationHeader,
+			validationOptions)
+	}
 	return validationErrors
 }
 
@@ -126,20 +225,18 @@ func ValidateQueryArray(
 		}
 	}
 
+	// tuples (prefixItems) are validated positionally.
+	if len(sch.PrefixItems) > 0 {
+		return validateQueryTuple(sch, param, items, validationOptions)
+	}
+
 	// check if the param is within an enum
 	checkEnum := func(item string) {
 		// check if the array param is within an enum
 		if sch.Items.IsA() {
 			itemsSch := sch.Items.A.Schema()
 			if itemsSch.Enum != nil {
-				matchFound := false
-				for _, enumVal := range itemsSch.Enum {
-					if strings.TrimSpace(item) == fmt.Sprint(enumVal.Value) {
-						matchFound = true
-						break
-					}
-				}
-				if !matchFound {
+				if !helpers.ParamMatchesEnum(item, itemsSch) {
 					validationErrors = append(validationErrors,
 						errors.IncorrectQueryParamEnumArray(param, item, sch))
 				}
@@ -217,9 +314,47 @@ func ValidateQueryArray(
 				errors.IncorrectParamArrayUniqueItems(param, sch, strings.Join(duplicates, ", ")))
 		}
 	}
+
+	// the items passed the checks above, so check the rest of the schema (e.g. 'pattern', 'format', 'enum').
+	if len(validationErrors) == 0 {
+		validationErrors = validateDecodedArray(sch, items,
+			"Query array parameter",
+			"The query parameter (which is an array)",
+			param.Name,
+			helpers.ParameterValidationQuery,
+			validationOptions)
+	}
 	return validationErrors
 }
 
+// validateQueryTuple validates an array parameter with 'prefixItems' (a tuple). Each item is converted into the
+// type defined by the schema for its position (falling back to 'items' for anything beyond the prefix), and then
+// the whole array is validated against the schema, so each failure is reported against the index of the item.
+func validateQueryTuple(
+	sch *base.Schema, param *v3.Parameter, items []string, validationOptions *config.ValidationOptions,
+) []*errors.ValidationError {
+	var itemsSchema *base.Schema
+	if sch.Items != nil && sch.Items.IsA() {
+		itemsSchema = sch.Items.A.Schema()
+	}
+	tuple := make([]any, len(items))
+	for i, item := range items {
+		positionSchema := itemsSchema
+		if i < len(sch.PrefixItems) {
+			positionSchema = sch.PrefixItems[i].Schema()
+		}
+		tuple[i] = helpers.CastParamValue(item, positionSchema)
+	}
+	return ValidateSingleParameterSchema(sch,
+		tuple,
+		"Query array parameter",
+		"The query parameter (which is an array)",
+		param.Name,
+		helpers.ParameterValidation,
+		helpers.ParameterValidationQuery,
+		validationOptions)
+}
+
 // ValidateQueryParamStyle will validate a query parameter by style
 func ValidateQueryParamStyle(param *v3.Parameter, as []*helpers.QueryParam) []*errors.ValidationError {
 	var validationErrors []*errors.ValidationError
@@ -0,0 +1,84 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package parameters
+
+import (
+	"net/http"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func (v *paramValidator) FindWarningsWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) []*errors.ValidationError {
+	if pathItem == nil {
+		return nil
+	}
+	params := helpers.ExtractParamsForOperation(request, pathItem)
+	queryParams := helpers.ExtractQueryParams(request.URL.Query())
+	cookies := helpers.ParseCookies(request)
+
+	var warnings []*errors.ValidationError
+	for _, param := range params {
+		if param == nil {
+			continue
+		}
+		if v.options.WarnOptionalQuery && param.In == helpers.Query && !optionalQueryParamSupplied(param, queryParams) {
+			warnings = append(warnings, errors.QueryParameterOmitted(param))
+		}
+		if !param.Deprecated {
+			continue
+		}
+		supplied := false
+		switch param.In {
+		case helpers.Path:
+			// the path matched, so every path parameter was supplied.
+			supplied = true
+		case helpers.Query:
+			_, supplied = queryParams[param.Name]
+		case helpers.Header:
+			supplied = request.Header.Get(param.Name) != ""
+		case helpers.Cookie:
+			for _, cookie := range cookies {
+				if cookie.Name == param.Name {
+					supplied = true
+					break
+				}
+			}
+		}
+		if supplied {
+			warnings = append(warnings, errors.ParameterDeprecated(param))
+		}
+	}
+
+	// in strict mode, a query parameter that is not defined is an error, not a warning.
+	// the same parameters are undeclared here as in strict mode, and for UndeclaredParameters.
+	if !v.options.StrictQueryParams {
+		operation := helpers.ExtractOperation(request, pathItem)
+		for _, qKey := range v.undeclaredQueryParams(request, pathItem, params, queryParams) {
+			warnings = append(warnings, errors.QueryParameterIgnored(qKey, operation))
+		}
+	}
+
+	errors.PopulateValidationErrors(warnings, request, pathValue)
+	return warnings
+}
+
+// optionalQueryParamSupplied returns true if the query parameter is required (a missing required parameter is an
+// error, not a warning), or if it was supplied by the request. An object using the default form encoding is
+// exploded into its properties, so it's never reported as missing.
+func optionalQueryParamSupplied(param *v3.Parameter, queryParams map[string][]*helpers.QueryParam) bool {
+	if param.Required != nil && *param.Required {
+		return true
+	}
+	if _, ok := queryParams[param.Name]; ok {
+		return true
+	}
+	if param.Schema != nil && param.IsDefaultFormEncoding() {
+		sch := param.Schema.Schema()
+		return sch != nil && len(sch.Type) > 0 && sch.Type[0] == helpers.Object
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package parameters
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi-validator/paths"
+)
+
+func TestFindWarningsWithPathItem(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: limit
+          in: query
+          deprecated: true
+          schema:
+            type: integer
+        - name: sort
+          in: query
+          deprecated: true
+          schema:
+            type: string
+        - name: X-Legacy
+          in: header
+          deprecated: true
+          schema:
+            type: string
+        - name: session
+          in: cookie
+          deprecated: true
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1?limit=10&fries=large&cheese=yes", nil)
+	request.Header.Set("X-Legacy", "true")
+	pathItem, _, pathValue := paths.FindPath(request, &m.Model)
+
+	v := NewParameterValidator(&m.Model)
+	warnings := v.FindWarningsWithPathItem(request, pathItem, pathValue)
+	require.Len(t, warnings, 4)
+	assert.Equal(t, "The query parameter 'limit' is deprecated", warnings[0].Message)
+	assert.Equal(t, helpers.ParameterValidationQuery, warnings[0].ValidationSubType)
+	assert.Equal(t, 13, warnings[0].SpecLine)
+	assert.Equal(t, "/burgers/{burgerId}", warnings[0].SpecPath)
+	assert.Equal(t, "The header parameter 'X-Legacy' is deprecated", warnings[1].Message)
+	assert.Equal(t, "Query parameter 'cheese' is not defined, and was ignored", warnings[2].Message)
+	assert.Equal(t, "Query parameter 'fries' is not defined, and was ignored", warnings[3].Message)
+
+	// in strict mode, an undeclared query parameter is an error, not a warning.
+	v = NewParameterValidator(&m.Model, config.WithStrictQueryParams())
+	warnings = v.FindWarningsWithPathItem(request, pathItem, pathValue)
+	require.Len(t, warnings, 2)
+
+	assert.Empty(t, v.FindWarningsWithPathItem(request, nil, ""))
+}
+
+func TestFindWarningsWithPathItem_PathLevelAndCookie(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        deprecated: true
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: session
+          in: cookie
+          deprecated: true
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+	request.Header.Set(helpers.CookieHeader, "session=abc")
+	pathItem, _, pathValue := paths.FindPath(request, &m.Model)
+
+	v := NewParameterValidator(&m.Model)
+	warnings := v.FindWarningsWithPathItem(request, pathItem, pathValue)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "The path parameter 'burgerId' is deprecated", warnings[0].Message)
+	assert.Equal(t, helpers.ParameterValidationPath, warnings[0].ValidationSubType)
+	assert.Equal(t, "The cookie parameter 'session' is deprecated", warnings[1].Message)
+	assert.Equal(t, helpers.ParameterValidationCookie, warnings[1].ValidationSubType)
+
+	// a deprecated parameter that was not supplied is not reported, and deprecation never fails validation.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+	warnings = v.FindWarningsWithPathItem(request, pathItem, pathValue)
+	require.Len(t, warnings, 1)
+	valid, errs := v.ValidateCookieParamsWithPathItem(request, pathItem, pathValue)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+}
+
+func TestFindWarningsWithPathItem_OptionalQueryParams(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    parameters:
+      - name: sauce
+        in: query
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: sort
+          in: query
+          required: false
+          schema:
+            type: string
+        - name: size
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: filter
+          in: query
+          schema:
+            type: object
+            properties:
+              vegan:
+                type: boolean
+        - name: X-Sauce
+          in: header
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?limit=10", nil)
+	pathItem, _, pathValue := paths.FindPath(request, &m.Model)
+
+	// without the option, an optional query parameter that was not supplied is not reported.
+	v := NewParameterValidator(&m.Model)
+	assert.Empty(t, v.FindWarningsWithPathItem(request, pathItem, pathValue))
+
+	// required parameters are errors, and default encoded objects are exploded into their properties, so
+	// neither is reported as a warning.
+	v = NewParameterValidator(&m.Model, config.WithOptionalQueryWarnings())
+	warnings := v.FindWarningsWithPathItem(request, pathItem, pathValue)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "Query parameter 'sauce' is optional, and was not supplied", warnings[0].Message)
+	assert.Equal(t, helpers.ParameterValidationQuery, warnings[0].ValidationSubType)
+	assert.Equal(t, 5, warnings[0].SpecLine)
+	assert.Equal(t, "/burgers", warnings[0].SpecPath)
+	assert.Equal(t, "Query parameter 'sort' is optional, and was not supplied", warnings[1].Message)
+	assert.Equal(t, 15, warnings[1].SpecLine)
+
+	// the option only adds warnings, the only error is the missing required parameter.
+	valid, errs := v.ValidateQueryParamsWithPathItem(request, pathItem, pathValue)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Query parameter 'size' is missing", errs[0].Message)
+}
+
+func TestFindWarningsWithPathItem_ApiKeyAndExplodedObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      security:
+        - ApiKey: []
+      parameters:
+        - name: filter
+          in: query
+          schema:
+            type: object
+            properties:
+              color:
+                type: string
+              size:
+                type: integer
+components:
+  securitySchemes:
+    ApiKey:
+      type: apiKey
+      in: query
+      name: api_key`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?api_key=abc&color=red&fries=large", nil)
+	pathItem, _, pathValue := paths.FindPath(request, &m.Model)
+
+	// the warnings agree with strict mode, and with the undeclared parameters.
+	v := NewParameterValidator(&m.Model)
+	warnings := v.FindWarningsWithPathItem(request, pathItem, pathValue)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "Query parameter 'fries' is not defined, and was ignored", warnings[0].Message)
+
+	_, query := v.UndeclaredParametersWithPathItem(request, pathItem)
+	assert.Equal(t, []string{"fries"}, query)
+}
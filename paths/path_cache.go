@@ -0,0 +1,91 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package paths
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// PathCache remembers the paths of a document that the last request path resolved to, so validating the same URL
+// again (like a GET followed by a POST in a CRUD handler) reuses the match, and only the operation for the method
+// is looked up. A PathCache is safe for concurrent use, and belongs to a single document.
+type PathCache struct {
+	document *v3.Document
+	// a catch-all path parameter is declared per operation, so which paths match can depend on the method. the
+	// paths of a document with catch-all parameters are matched for every request.
+	catchAll bool
+
+	lock       sync.Mutex
+	key        string
+	candidates []pathCandidate
+}
+
+// NewPathCache will create a new PathCache for the paths of an OpenAPI 3+ document.
+func NewPathCache(document *v3.Document) *PathCache {
+	return &PathCache{document: document, catchAll: hasCatchAllParameter(document)}
+}
+
+// FindPathWithCache works the same as FindPathWithOptions, the paths that match the request path are looked up in
+// the cache first, and stored in the cache when they are not. The same options must be supplied each time a cache is
+// used, as the options influence which paths match.
+func FindPathWithCache(request *http.Request, cache *PathCache, options *config.ValidationOptions) (*v3.PathItem, []*errors.ValidationError, string) {
+	basePaths := getBasePaths(request, cache.document, options)
+	stripped := StripRequestPathWithOptions(request, cache.document, options)
+	if cache.catchAll {
+		return resolveOperation(request, matchCandidates(request, cache.document, options, basePaths, stripped))
+	}
+
+	// the base paths are part of the key, as a forwarded prefix can differ from request to request.
+	key := stripped + "\n" + strings.Join(basePaths, "\n")
+	cache.lock.Lock()
+	candidates, found := cache.candidates, cache.key == key && cache.candidates != nil
+	cache.lock.Unlock()
+	if !found {
+		candidates = matchCandidates(request, cache.document, options, basePaths, stripped)
+		if candidates == nil {
+			candidates = []pathCandidate{}
+		}
+		cache.lock.Lock()
+		cache.key, cache.candidates = key, candidates
+		cache.lock.Unlock()
+	}
+	return resolveOperation(request, candidates)
+}
+
+// hasCatchAllParameter returns true if any path of the document declares a catch-all path parameter, for any
+// operation.
+func hasCatchAllParameter(document *v3.Document) bool {
+	if document == nil || document.Paths == nil {
+		return false
+	}
+	for pair := orderedmap.First(document.Paths.PathItems); pair != nil; pair = pair.Next() {
+		params := slices.Clone(pair.Value().Parameters)
+		for op := orderedmap.First(pair.Value().GetOperations()); op != nil; op = op.Next() {
+			params = append(params, op.Value().Parameters...)
+		}
+		for _, param := range params {
+			if param == nil || param.In != helpers.Path || param.Extensions == nil {
+				continue
+			}
+			if ext := param.Extensions.GetOrZero(helpers.CatchAllExtension); ext != nil {
+				if catchAll, err := strconv.ParseBool(ext.Value); err == nil && catchAll {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
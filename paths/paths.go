@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/pb33f/libopenapi/orderedmap"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 )
@@ -23,20 +26,49 @@ import (
 // that were picked up when locating the path.
 // The third return value will be the path that was found in the document, as it pertains to the contract, so all path
 // parameters will not have been replaced with their values from the request - allowing model lookups.
+//
+// When more than one path matches the request, the path with the most static segments is picked, so
+// '/users/me' is picked over '/users/{id}', and '/files/{a}/latest' over '/files/{a}/{b}'. Ties are broken
+// by the left-most static segment, and then by declaration order. A trailing slash is ignored unless both
+// forms are declared.
+//
+// A path parameter in the last segment of a path can capture the rest of the request path (so '/files/{path}'
+// matches '/files/a/b/c', with 'a/b/c' as the value of 'path'), by marking the parameter with the
+// 'x-catch-all: true' extension.
 func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*errors.ValidationError, string) {
-	basePaths := getBasePaths(document)
-	stripped := StripRequestPath(request, document)
+	return FindPathWithOptions(request, document, nil)
+}
+
+// FindPathWithOptions works the same as FindPath, however the supplied options are used to influence how the path
+// is matched (for example, a base path set using config.WithBasePath is stripped from the request path).
+func FindPathWithOptions(request *http.Request, document *v3.Document, options *config.ValidationOptions) (*v3.PathItem, []*errors.ValidationError, string) {
+	basePaths := getBasePaths(request, document, options)
+	stripped := StripRequestPathWithOptions(request, document, options)
+	return resolveOperation(request, matchCandidates(request, document, options, basePaths, stripped))
+}
 
+// matchCandidates collects every (allowed) path of the document that matches the stripped request path, ordered
+// from the most specific match to the least specific.
+func matchCandidates(request *http.Request, document *v3.Document, options *config.ValidationOptions,
+	basePaths []string, stripped string,
+) []pathCandidate {
 	reqPathSegments := strings.Split(stripped, "/")
 	if reqPathSegments[0] == "" {
 		reqPathSegments = reqPathSegments[1:]
 	}
+	reqPathSegments, reqTrailingSlash := trimTrailingSlash(reqPathSegments)
+	for i := range reqPathSegments {
+		reqPathSegments[i] = unescapeSegment(reqPathSegments[i])
+	}
 
-	var pItem *v3.PathItem
-	var foundPath string
+	// collect every path that matches the request, the most specific match is picked below.
+	var candidates []pathCandidate
 	for pair := orderedmap.First(document.Paths.PathItems); pair != nil; pair = pair.Next() {
 		path := pair.Key()
 		pathItem := pair.Value()
+		if options != nil && len(options.PathAllowlist) > 0 && !isAllowedPath(path, options.PathAllowlist) {
+			continue
+		}
 
 		// if the stripped path has a fragment, then use that as part of the lookup
 		// if not, then strip off any fragments from the pathItem
@@ -50,59 +82,45 @@ func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*er
 		if segs[0] == "" {
 			segs = segs[1:]
 		}
+		segs, trailingSlash := trimTrailingSlash(segs)
 
-		ok := comparePaths(segs, reqPathSegments, basePaths)
+		ok := comparePaths(segs, reqPathSegments, basePaths, helpers.FindCatchAllParameter(request, pathItem))
 		if !ok {
 			continue
 		}
-		pItem = pathItem
-		foundPath = path
-		switch request.Method {
-		case http.MethodGet:
-			if pathItem.Get != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodPost:
-			if pathItem.Post != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodPut:
-			if pathItem.Put != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodDelete:
-			if pathItem.Delete != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodOptions:
-			if pathItem.Options != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodHead:
-			if pathItem.Head != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodPatch:
-			if pathItem.Patch != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodTrace:
-			if pathItem.Trace != nil {
-				return pathItem, nil, path
-			}
+		candidates = append(candidates, pathCandidate{
+			path:          path,
+			pathItem:      pathItem,
+			segments:      segs,
+			trailingMatch: trailingSlash == reqTrailingSlash,
+		})
+	}
+
+	// static segments are preferred over templated segments (so '/users/me' wins over '/users/{id}'), and
+	// an exact trailing slash match is preferred over a match that ignores the trailing slash.
+	// see moreSpecificThan for the complete set of rules.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].moreSpecificThan(candidates[j])
+	})
+	return candidates
+}
+
+// resolveOperation picks the most specific candidate that has an operation for the method of the request.
+func resolveOperation(request *http.Request, candidates []pathCandidate) (*v3.PathItem, []*errors.ValidationError, string) {
+	var pItem *v3.PathItem
+	var foundPath string
+	for _, candidate := range candidates {
+		if helpers.ExtractOperation(request, candidate.pathItem) != nil {
+			return candidate.pathItem, nil, candidate.path
 		}
 	}
+	if len(candidates) > 0 {
+		pItem = candidates[0].pathItem
+		foundPath = candidates[0].path
+	}
+	// the path was found, but not the method, that's a method that is not allowed (a 405, not a 404).
 	if pItem != nil {
-		validationErrors := []*errors.ValidationError{{
-			ValidationType:    helpers.ParameterValidationPath,
-			ValidationSubType: "missingOperation",
-			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
-			Reason: fmt.Sprintf("The %s method for that path does not exist in the specification",
-				request.Method),
-			SpecLine: -1,
-			SpecCol:  -1,
-			HowToFix: errors.HowToFixPath,
-		}}
+		validationErrors := []*errors.ValidationError{errors.MethodNotAllowed(pItem, request, foundPath)}
 		errors.PopulateValidationErrors(validationErrors, request, foundPath)
 		return pItem, validationErrors, foundPath
 	}
@@ -111,6 +129,8 @@ func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*er
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -123,9 +143,92 @@ func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*er
 	return nil, validationErrors, ""
 }
 
-func getBasePaths(document *v3.Document) []string {
+// isAllowedPath returns true if a path of the specification matches an entry of the allowlist, either exactly, as
+// a glob, or as a prefix when the entry ends with '/**'.
+func isAllowedPath(specPath string, allowlist []string) bool {
+	for _, allowed := range allowlist {
+		if prefix, ok := strings.CutSuffix(allowed, "/**"); ok {
+			if specPath == prefix || strings.HasPrefix(specPath, prefix+helpers.Slash) {
+				return true
+			}
+			continue
+		}
+		if specPath == allowed {
+			return true
+		}
+		if matched, err := path.Match(allowed, specPath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// pathCandidate is a path from the specification that matches the request path.
+type pathCandidate struct {
+	path          string
+	pathItem      *v3.PathItem
+	segments      []string
+	trailingMatch bool
+}
+
+// moreSpecificThan returns true if the candidate should be preferred over another candidate. Candidates are
+// scored by the number of static (non-templated) segments they have, the highest score wins. Ties are broken by:
+//
+//  1. the left-most static segment, segments are compared from left to right, and the first static segment
+//     that is matched against a templated segment wins.
+//  2. an exact trailing slash match.
+//
+// Candidates that are still tied keep the order in which they are declared in the specification.
+func (c pathCandidate) moreSpecificThan(other pathCandidate) bool {
+	if score, otherScore := staticSegments(c.segments), staticSegments(other.segments); score != otherScore {
+		return score > otherScore
+	}
+	for i := range c.segments {
+		if i >= len(other.segments) {
+			break
+		}
+		templated, otherTemplated := isTemplatedSegment(c.segments[i]), isTemplatedSegment(other.segments[i])
+		if templated != otherTemplated {
+			return !templated
+		}
+	}
+	return c.trailingMatch && !other.trailingMatch
+}
+
+func staticSegments(segments []string) int {
+	count := 0
+	for _, seg := range segments {
+		if !isTemplatedSegment(seg) {
+			count++
+		}
+	}
+	return count
+}
+
+func isTemplatedSegment(segment string) bool {
+	return strings.Contains(segment, "{")
+}
+
+// trimTrailingSlash removes the empty segment left behind by a trailing slash, so '/users/' and '/users' are
+// compared the same way. The second return value is true if there was a trailing slash.
+func trimTrailingSlash(segments []string) ([]string, bool) {
+	if len(segments) > 1 && segments[len(segments)-1] == "" {
+		return segments[:len(segments)-1], true
+	}
+	return segments, false
+}
+
+func getBasePaths(request *http.Request, document *v3.Document, options *config.ValidationOptions) []string {
 	// extract base path from document to check against paths.
 	var basePaths []string
+	if options != nil && options.ForwardedPrefix {
+		if prefix := forwardedPrefix(request); prefix != "" {
+			basePaths = append(basePaths, prefix)
+		}
+	}
+	if options != nil && options.BasePath != "" && options.BasePath != "/" {
+		basePaths = append(basePaths, options.BasePath)
+	}
 	for _, s := range document.Servers {
 		u, err := url.Parse(s.URL)
 		// if the host contains special characters, we should attempt to split and parse only the relative path
@@ -148,12 +251,36 @@ func getBasePaths(document *v3.Document) []string {
 	return basePaths
 }
 
+// forwardedPrefix returns the prefix a reverse proxy mounts the service under, from the 'X-Forwarded-Prefix' header.
+// A proxy behind another proxy may send more than one (comma separated) prefix, they are joined in order.
+func forwardedPrefix(request *http.Request) string {
+	var prefix string
+	for _, value := range request.Header.Values(helpers.ForwardedPrefixHeader) {
+		for _, p := range strings.Split(value, helpers.Comma) {
+			p = strings.Trim(strings.TrimSpace(p), helpers.Slash)
+			if p != "" {
+				prefix += helpers.Slash + p
+			}
+		}
+	}
+	return prefix
+}
+
 // StripRequestPath strips the base path from the request path, based on the server paths provided in the specification
 func StripRequestPath(request *http.Request, document *v3.Document) string {
-	basePaths := getBasePaths(document)
+	return StripRequestPathWithOptions(request, document, nil)
+}
+
+// StripRequestPathWithOptions works the same as StripRequestPath, the base path set in the options (if any) is
+// stripped as well.
+//
+// The request path is normalized first, a query string or fragment left in the path (rather than the query and
+// fragment of the URL) is dropped, and duplicate slashes are collapsed.
+func StripRequestPathWithOptions(request *http.Request, document *v3.Document, options *config.ValidationOptions) string {
+	basePaths := getBasePaths(request, document, options)
 
 	// strip any base path
-	stripped := stripBaseFromPath(request.URL.EscapedPath(), basePaths)
+	stripped := stripBaseFromPath(normalizeRequestPath(request.URL), basePaths)
 	if request.URL.Fragment != "" {
 		stripped = fmt.Sprintf("%s#%s", stripped, request.URL.Fragment)
 	}
@@ -181,19 +308,60 @@ func checkPathAgainstBase(docPath, urlPath string, basePaths []string) bool {
 
 func stripBaseFromPath(path string, basePaths []string) string {
 	for i := range basePaths {
-		if strings.HasPrefix(path, basePaths[i]) {
-			return path[len(basePaths[i]):]
+		// the request path has its duplicate slashes collapsed, so the base path must too.
+		basePath := collapseSlashes(basePaths[i])
+		if strings.HasPrefix(path, basePath) {
+			return path[len(basePath):]
 		}
 	}
 	return path
 }
 
-func comparePaths(mapped, requested, basePaths []string) bool {
+// normalizeRequestPath returns the escaped path of the URL, without any query string or fragment that was left in
+// the path by mistake (e.g. a URL built with a RawPath of '/burgers?limit=1'), and with duplicate slashes collapsed.
+// Only a literal '?' or '#' in the raw path is a remnant, an escaped one ('%3F' or '%23') is part of its segment.
+// The decoded path cannot tell the two apart, so it's not searched.
+func normalizeRequestPath(u *url.URL) string {
+	escaped := u.EscapedPath()
+	if i := strings.IndexAny(u.RawPath, "?#"); i >= 0 {
+		if path, err := url.PathUnescape(u.RawPath[:i]); err == nil {
+			escaped = (&url.URL{Path: path, RawPath: u.RawPath[:i]}).EscapedPath()
+		}
+	}
+	return collapseSlashes(escaped)
+}
+
+// collapseSlashes replaces each run of slashes in the path with a single slash.
+func collapseSlashes(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return path
+}
+
+// unescapeSegment decodes the percent-encoded characters of a segment of the request path, so it can be compared
+// with the path of the specification (e.g. '%7Bid%7D' is compared as '{id}'). An encoded slash is kept encoded, as
+// it would otherwise split the segment in two.
+func unescapeSegment(segment string) string {
+	unescaped, err := url.PathUnescape(segment)
+	if err != nil || strings.Contains(unescaped, "/") {
+		return segment
+	}
+	return unescaped
+}
+
+func comparePaths(mapped, requested, basePaths []string, catchAll string) bool {
+	requested = helpers.CollapseCatchAllSegments(mapped, requested, catchAll)
 	if len(mapped) != len(requested) {
 		return false // short circuit out
 	}
 	var imploded []string
 	for i, seg := range mapped {
+		// a catch-all captures the rest of the path, slashes and all.
+		if i == len(mapped)-1 && helpers.IsCatchAllSegment(seg, catchAll) {
+			imploded = append(imploded, requested[i])
+			continue
+		}
 		s := seg
 		r, err := helpers.GetRegexForPath(seg)
 		if err != nil {
@@ -4,12 +4,19 @@
 package paths
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
 )
 
 func TestNewValidator_BadParam(t *testing.T) {
@@ -164,6 +171,33 @@ paths:
 	assert.Equal(t, "locateBurger", pathItem.Options.OperationId)
 }
 
+func TestNewValidator_FindPathOptionsHead_NotDeclared(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger
+    delete:
+      operationId: deleteBurger
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	for _, method := range []string{http.MethodOptions, http.MethodHead} {
+		request, _ := http.NewRequest(method, "https://things.com/burgers/12345", nil)
+
+		pathItem, errs, foundPath := FindPath(request, &m.Model)
+		assert.NotNil(t, pathItem)
+		assert.Equal(t, "/burgers/{burgerId}", foundPath)
+		require.Len(t, errs, 1)
+		assert.Equal(t, fmt.Sprintf("Method %s not allowed on path '/burgers/12345'", method), errs[0].Message)
+		assert.Equal(t, []string{http.MethodGet, http.MethodDelete}, errs[0].MessageArgs["allowed"])
+		assert.True(t, errs[0].IsOperationMissingError())
+		assert.Equal(t, "/burgers/{burgerId}", errs[0].SpecPath)
+	}
+}
+
 func TestNewValidator_FindPathTrace(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -310,7 +344,7 @@ paths:
 	pathItem, errs, _ := FindPath(request, &m.Model)
 	assert.NotNil(t, pathItem)
 	assert.NotNil(t, errs)
-	assert.Equal(t, "PUT Path '/burgers/12345' not found", errs[0].Message)
+	assert.Equal(t, "Method PUT not allowed on path '/burgers/12345'", errs[0].Message)
 	assert.True(t, errs[0].IsOperationMissingError())
 }
 
@@ -380,7 +414,7 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 
 	assert.Len(t, errs, 1)
-	assert.Equal(t, "POST Path '/pizza/1234' not found", errs[0].Message)
+	assert.Equal(t, "Method POST not allowed on path '/pizza/1234'", errs[0].Message)
 }
 
 func TestNewValidator_OptionsMatch_Error(t *testing.T) {
@@ -404,7 +438,7 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 
 	assert.Len(t, errs, 1)
-	assert.Equal(t, "POST Path '/pizza/1234' not found", errs[0].Message)
+	assert.Equal(t, "Method POST not allowed on path '/pizza/1234'", errs[0].Message)
 }
 
 func TestNewValidator_PatchLiteralMatch(t *testing.T) {
@@ -445,7 +479,7 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 
 	assert.Len(t, errs, 1)
-	assert.Equal(t, "POST Path '/pizza/1234' not found", errs[0].Message)
+	assert.Equal(t, "Method POST not allowed on path '/pizza/1234'", errs[0].Message)
 }
 
 func TestNewValidator_DeleteLiteralMatch(t *testing.T) {
@@ -537,7 +571,7 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 
 	assert.Len(t, errs, 1)
-	assert.Equal(t, "POST Path '/pizza/1234' not found", errs[0].Message)
+	assert.Equal(t, "Method POST not allowed on path '/pizza/1234'", errs[0].Message)
 }
 
 func TestNewValidator_DeleteMatch_Error(t *testing.T) {
@@ -561,7 +595,7 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 
 	assert.Len(t, errs, 1)
-	assert.Equal(t, "POST Path '/pizza/1234' not found", errs[0].Message)
+	assert.Equal(t, "Method POST not allowed on path '/pizza/1234'", errs[0].Message)
 }
 
 func TestNewValidator_PostMatch_Error(t *testing.T) {
@@ -585,7 +619,7 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 
 	assert.Len(t, errs, 1)
-	assert.Equal(t, "PUT Path '/pizza/1234' not found", errs[0].Message)
+	assert.Equal(t, "Method PUT not allowed on path '/pizza/1234'", errs[0].Message)
 }
 
 func TestNewValidator_FindPathWithFragment(t *testing.T) {
@@ -661,7 +695,7 @@ paths:
 	}
 	m, _ := doc.BuildV3Model()
 
-	basePaths := getBasePaths(&m.Model)
+	basePaths := getBasePaths(&http.Request{}, &m.Model, nil)
 
 	expectedPaths := []string{
 		"/",
@@ -676,6 +710,149 @@ paths:
 	assert.Equal(t, expectedPaths, basePaths)
 }
 
+func TestFindPathWithOptions_BasePath(t *testing.T) {
+	spec := `openapi: 3.1.0
+servers:
+  - url: https://things.com/v2
+paths:
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/api/v1/burgers/1234", nil)
+
+	pathItem, errs, _ := FindPath(request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+
+	options := config.NewValidationOptions(config.WithBasePath("/api/v1"))
+	pathItem, errs, pathValue := FindPathWithOptions(request, &m.Model, options)
+	assert.Empty(t, errs)
+	assert.NotNil(t, pathItem)
+	assert.Equal(t, "/burgers/{burgerId}", pathValue)
+	assert.Equal(t, "/burgers/1234", StripRequestPathWithOptions(request, &m.Model, options))
+
+	// the server base path still works.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/burgers/1234", nil)
+	pathItem, errs, _ = FindPathWithOptions(request, &m.Model, options)
+	assert.Empty(t, errs)
+	assert.NotNil(t, pathItem)
+}
+
+func TestFindPath_CatchAll(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /files/{path}:
+    get:
+      parameters:
+        - name: path
+          in: path
+          required: true
+          x-catch-all: true
+          schema:
+            type: string
+  /files/{path}/meta:
+    get:
+      parameters:
+        - name: path
+          in: path
+          required: true
+          schema:
+            type: string
+  /images/{name}:
+    get:
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	for _, requested := range []string{"/files/a", "/files/a/b/c", "/files/a/b/c/"} {
+		request, _ := http.NewRequest(http.MethodGet, "https://things.com"+requested, nil)
+		pathItem, errs, pathValue := FindPath(request, &m.Model)
+		assert.Empty(t, errs, requested)
+		assert.NotNil(t, pathItem, requested)
+		assert.Equal(t, "/files/{path}", pathValue, requested)
+	}
+
+	// a more specific path still wins over the catch-all.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/a/meta", nil)
+	_, errs, pathValue := FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/files/{path}/meta", pathValue)
+
+	// the catch-all needs at least one segment.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/files", nil)
+	pathItem, errs, _ := FindPath(request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+
+	// without the extension, a parameter is a single segment.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/images/a/b", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+}
+
+func TestFindPath_ForwardedPrefix(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// the proxy did not strip the prefix.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/service-a/burgers/1234", nil)
+	request.Header.Set(helpers.ForwardedPrefixHeader, "/service-a")
+
+	// the header is ignored unless enabled.
+	pathItem, errs, _ := FindPath(request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+
+	options := config.NewValidationOptions(config.WithForwardedPrefix())
+	pathItem, errs, pathValue := FindPathWithOptions(request, &m.Model, options)
+	assert.Empty(t, errs)
+	assert.NotNil(t, pathItem)
+	assert.Equal(t, "/burgers/{burgerId}", pathValue)
+	assert.Equal(t, "/burgers/1234", StripRequestPathWithOptions(request, &m.Model, options))
+
+	// the proxy stripped the prefix.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/1234", nil)
+	request.Header.Set(helpers.ForwardedPrefixHeader, "/service-a")
+	pathItem, errs, pathValue = FindPathWithOptions(request, &m.Model, options)
+	assert.Empty(t, errs)
+	assert.NotNil(t, pathItem)
+	assert.Equal(t, "/burgers/{burgerId}", pathValue)
+
+	// a prefix without slashes is normalized.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/service-a/burgers/1234", nil)
+	request.Header.Set(helpers.ForwardedPrefixHeader, "service-a/")
+	pathItem, errs, _ = FindPathWithOptions(request, &m.Model, options)
+	assert.Empty(t, errs)
+	assert.NotNil(t, pathItem)
+
+	// prefixes of chained proxies are joined.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/edge/service-a/burgers/1234", nil)
+	request.Header.Set(helpers.ForwardedPrefixHeader, "/edge, /service-a")
+	pathItem, errs, _ = FindPathWithOptions(request, &m.Model, options)
+	assert.Empty(t, errs)
+	assert.NotNil(t, pathItem)
+}
+
 func TestNewValidator_FindPathWithEncodedArg(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -696,6 +873,89 @@ paths:
 	assert.NotNil(t, pathItem)
 }
 
+func TestFindPath_NormalizedRequestPath(t *testing.T) {
+	spec := `openapi: 3.1.0
+servers:
+  - url: https://things.com/v2
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+  /users/me:
+    get:
+      operationId: getMe
+  /menu/fish & chips:
+    get:
+      operationId: getFishAndChips
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	find := func(u *url.URL) (string, string) {
+		request := &http.Request{Method: http.MethodGet, URL: u, Header: http.Header{}}
+		pathItem, errs, pathValue := FindPath(request, &m.Model)
+		require.Empty(t, errs, "%s", u.Path)
+		return pathValue, pathItem.Get.OperationId
+	}
+
+	// a query string or fragment left (unescaped) in the path is dropped.
+	pathValue, operationId := find(&url.URL{Path: "/v2/users/123?expand=true", RawPath: "/v2/users/123?expand=true"})
+	assert.Equal(t, "/users/{id}", pathValue)
+	assert.Equal(t, "getUser", operationId)
+
+	pathValue, _ = find(&url.URL{Path: "/v2/users/me#profile", RawPath: "/v2/users/me#profile"})
+	assert.Equal(t, "/users/me", pathValue)
+
+	// an escaped '?' or '#' is part of the segment, nothing is dropped.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/v2/users/what%3Fnot", nil)
+	_, errs, pathValue := FindPath(request, &m.Model)
+	require.Empty(t, errs)
+	assert.Equal(t, "/users/{id}", pathValue)
+	assert.Equal(t, "/users/what%3Fnot", StripRequestPath(request, &m.Model))
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/users/me%23profile", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	require.Empty(t, errs)
+	assert.Equal(t, "/users/{id}", pathValue)
+	assert.Equal(t, "/users/me%23profile", StripRequestPath(request, &m.Model))
+
+	// duplicate slashes are collapsed, around the base path too.
+	pathValue, _ = find(&url.URL{Path: "/v2//users///123"})
+	assert.Equal(t, "/users/{id}", pathValue)
+
+	pathValue, _ = find(&url.URL{Path: "//v2/users/me/"})
+	assert.Equal(t, "/users/me", pathValue)
+
+	// percent-encoded segments are decoded for comparison.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/users/%6De", nil)
+	pathItem, errs, pathValue := FindPath(request, &m.Model)
+	require.Empty(t, errs)
+	assert.Equal(t, "/users/me", pathValue)
+	assert.Equal(t, "getMe", pathItem.Get.OperationId)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/users/%7Bid%7D", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	require.Empty(t, errs)
+	assert.Equal(t, "/users/{id}", pathValue)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/menu/fish%20%26%20chips", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	require.Empty(t, errs)
+	assert.Equal(t, "/menu/fish & chips", pathValue)
+
+	// an encoded slash stays within its segment.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/users/a%2Fb", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	require.Empty(t, errs)
+	assert.Equal(t, "/users/{id}", pathValue)
+	assert.Equal(t, "/users/a%2Fb", StripRequestPath(request, &m.Model))
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/v2/users/me/again", nil)
+	_, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 1)
+}
+
 func TestNewValidator_ODataFormattedOpenAPISpecs(t *testing.T) {
 	spec := `openapi: 3.0.0
 paths:
@@ -769,3 +1029,272 @@ paths:
 	_, errs, _ := FindPath(request, &m.Model)
 	assert.NotEmpty(t, errs)
 }
+
+func TestFindPath_PreferStaticSegments(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+    delete:
+      operationId: deleteUser
+  /users/me:
+    get:
+      operationId: getMe
+  /users/{id}/orders/latest:
+    get:
+      operationId: getLatestOrder
+  /users/me/orders/{orderId}:
+    get:
+      operationId: getMyOrder
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/me", nil)
+	pathItem, errs, pathValue := FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/users/me", pathValue)
+	assert.Equal(t, "getMe", pathItem.Get.OperationId)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/1234", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/users/{id}", pathValue)
+
+	// '/users/me' has no DELETE operation, so the templated path is used.
+	request, _ = http.NewRequest(http.MethodDelete, "https://things.com/users/me", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/users/{id}", pathValue)
+
+	// the left-most static segment wins.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/me/orders/latest", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/users/me/orders/{orderId}", pathValue)
+}
+
+func TestFindPath_TrailingSlash(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+  /burgers:
+    get:
+      operationId: listBurgers
+  /burgers/:
+    get:
+      operationId: listBurgersSlash
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/", nil)
+	_, errs, pathValue := FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/users", pathValue)
+
+	// both forms are declared, so each resolves to its own path item.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/burgers", pathValue)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/burgers/", pathValue)
+}
+
+func TestFindPath_OverlappingTemplates(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /files/{a}/{b}:
+    get:
+      operationId: getFile
+  /files/{a}/latest:
+    get:
+      operationId: getLatestFile
+  /shops/{shop}/burgers/menu/{item}:
+    get:
+      operationId: getMenuItem
+  /shops/local/{category}/{menu}/{item}:
+    get:
+      operationId: getLocalItem
+  /docs/{a}/{b}:
+    get:
+      operationId: getDocA
+  /docs/{c}/{d}:
+    get:
+      operationId: getDocC
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/x/latest", nil)
+	pathItem, errs, pathValue := FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/files/{a}/latest", pathValue)
+	assert.Equal(t, "getLatestFile", pathItem.Get.OperationId)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/files/x/y", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/files/{a}/{b}", pathValue)
+
+	// more static segments win, even if the other path has a static segment further to the left.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/shops/local/burgers/menu/fries", nil)
+	_, errs, pathValue = FindPath(request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/shops/{shop}/burgers/menu/{item}", pathValue)
+
+	// a complete tie is resolved by declaration order.
+	for i := 0; i < 10; i++ {
+		request, _ = http.NewRequest(http.MethodGet, "https://things.com/docs/x/y", nil)
+		_, errs, pathValue = FindPath(request, &m.Model)
+		assert.Empty(t, errs)
+		assert.Equal(t, "/docs/{a}/{b}", pathValue)
+	}
+}
+
+func TestFindPath_PathAllowlist(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger
+  /burgers/{burgerId}/toppings/{toppingId}:
+    get:
+      operationId: getTopping
+  /admin/users:
+    get:
+      operationId: listUsers
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	find := func(allowlist []string, url string) string {
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		_, errs, pathValue := FindPathWithOptions(request, &m.Model, config.NewValidationOptions(config.WithPathAllowlist(allowlist)))
+		if len(errs) > 0 {
+			return errs[0].Message
+		}
+		return pathValue
+	}
+
+	allowlist := []string{"/burgers", "/burgers/*"}
+	assert.Equal(t, "/burgers", find(allowlist, "https://things.com/burgers"))
+	assert.Equal(t, "/burgers/{burgerId}", find(allowlist, "https://things.com/burgers/1"))
+	assert.Equal(t, "GET Path '/burgers/1/toppings/2' not found", find(allowlist, "https://things.com/burgers/1/toppings/2"))
+	assert.Equal(t, "GET Path '/admin/users' not found", find(allowlist, "https://things.com/admin/users"))
+
+	allowlist = []string{"/burgers/**"}
+	assert.Equal(t, "/burgers", find(allowlist, "https://things.com/burgers"))
+	assert.Equal(t, "/burgers/{burgerId}/toppings/{toppingId}", find(allowlist, "https://things.com/burgers/1/toppings/2"))
+	assert.Equal(t, "GET Path '/admin/users' not found", find(allowlist, "https://things.com/admin/users"))
+
+	// without an allowlist, every path is matched.
+	assert.Equal(t, "/admin/users", find(nil, "https://things.com/admin/users"))
+}
+
+func TestFindPathWithCache(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger
+    delete:
+      operationId: deleteBurger
+  /burgers/special:
+    post:
+      operationId: createSpecial
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	cache := NewPathCache(&m.Model)
+	assert.False(t, cache.catchAll)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/special", nil)
+	pathItem, errs, pathValue := FindPathWithCache(request, cache, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/burgers/{burgerId}", pathValue)
+	assert.Equal(t, "getBurger", pathItem.Get.OperationId)
+	assert.Len(t, cache.candidates, 2)
+
+	// the same URL with another method reuses the match, the operation is still picked for the method.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/special", nil)
+	pathItem, errs, pathValue = FindPathWithCache(request, cache, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/burgers/special", pathValue)
+	assert.Equal(t, "createSpecial", pathItem.Post.OperationId)
+
+	request, _ = http.NewRequest(http.MethodPut, "https://things.com/burgers/special", nil)
+	_, errs, pathValue = FindPathWithCache(request, cache, nil)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "missingOperation", errs[0].ValidationSubType)
+	assert.Equal(t, "/burgers/special", pathValue)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/fries", nil)
+	pathItem, errs, _ = FindPathWithCache(request, cache, nil)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "missing", errs[0].ValidationSubType)
+
+	// the cache is shared between goroutines.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url, expected := "https://things.com/burgers/1", "/burgers/{burgerId}"
+			if i%2 == 0 {
+				url, expected = "https://things.com/fries", ""
+			}
+			request, _ := http.NewRequest(http.MethodDelete, url, nil)
+			_, _, pathValue := FindPathWithCache(request, cache, nil)
+			assert.Equal(t, expected, pathValue)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestFindPathWithCache_CatchAll(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /files/{path}:
+    get:
+      parameters:
+        - name: path
+          in: path
+          required: true
+          x-catch-all: true
+          schema:
+            type: string
+    delete:
+      parameters:
+        - name: path
+          in: path
+          required: true
+          schema:
+            type: string
+`
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	cache := NewPathCache(&m.Model)
+	assert.True(t, cache.catchAll)
+
+	// only the GET operation captures the rest of the path.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/a/b", nil)
+	_, errs, pathValue := FindPathWithCache(request, cache, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "/files/{path}", pathValue)
+
+	request, _ = http.NewRequest(http.MethodDelete, "https://things.com/files/a/b", nil)
+	_, errs, _ = FindPathWithCache(request, cache, nil)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// RequestValidationReport is the outcome of validating a request, with the errors grouped by where they occurred,
+// rather than as a flat slice. It's built by ValidateHttpRequestStructured.
+type RequestValidationReport struct {
+	// Valid is true if there are no errors in any section (warnings do not count).
+	Valid bool `json:"valid" yaml:"valid"`
+
+	// Routing holds the errors for a request that could not be matched to an operation (path or method not found).
+	Routing []*errors.ValidationError `json:"routing,omitempty" yaml:"routing,omitempty"`
+
+	// PathParameters, QueryParameters, HeaderParameters and CookieParameters hold the errors for the parameters of
+	// each location.
+	PathParameters   []*errors.ValidationError `json:"pathParameters,omitempty" yaml:"pathParameters,omitempty"`
+	QueryParameters  []*errors.ValidationError `json:"queryParameters,omitempty" yaml:"queryParameters,omitempty"`
+	HeaderParameters []*errors.ValidationError `json:"headerParameters,omitempty" yaml:"headerParameters,omitempty"`
+	CookieParameters []*errors.ValidationError `json:"cookieParameters,omitempty" yaml:"cookieParameters,omitempty"`
+
+	// Security holds the errors for the security requirements of the operation.
+	Security []*errors.ValidationError `json:"security,omitempty" yaml:"security,omitempty"`
+
+	// Body holds the errors for the request body (e.g. an unsupported content type, or a body that failed its schema).
+	Body []*errors.ValidationError `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// BodyTree holds the schema violations of the request body, arranged by the JSON path of the field they
+	// occurred at. It's nil if the body has no schema violations.
+	BodyTree *BodyErrorNode `json:"bodyTree,omitempty" yaml:"bodyTree,omitempty"`
+
+	// Other holds any errors that do not belong to one of the sections above (such as a schema that could not be
+	// compiled).
+	Other []*errors.ValidationError `json:"other,omitempty" yaml:"other,omitempty"`
+
+	// Warnings holds the warnings for the request, see ValidateHttpRequestWithWarnings.
+	Warnings []*errors.ValidationError `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// BodyErrorNode is a field of a request body in the tree of schema violations held by a RequestValidationReport.
+// The root of the tree is the body itself. A node only exists if the field, or one of its children, failed.
+type BodyErrorNode struct {
+	// Name is the name of the field (or the index of an array item), it's empty for the root of the body.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Path is the JSONPath of the field (e.g. '$.pets[0].name'), the root of the body is '$'.
+	Path string `json:"path" yaml:"path"`
+
+	// Failures holds the schema violations that occurred at the field itself.
+	Failures []*errors.SchemaValidationFailure `json:"failures,omitempty" yaml:"failures,omitempty"`
+
+	// Children holds the fields of the field that failed, in the order they were first reported.
+	Children []*BodyErrorNode `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// child returns the child of the node with the supplied name, creating it if it does not exist.
+func (n *BodyErrorNode) child(name string, segments []string) *BodyErrorNode {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	c := &BodyErrorNode{Name: name, Path: helpers.JSONPathFromSegments(segments)}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// add places a schema violation in the tree, beneath the root, at the field it occurred at.
+func (n *BodyErrorNode) add(failure *errors.SchemaValidationFailure) {
+	node := n
+	segments := helpers.JSONPathSegments(failure.FieldPath)
+	for i := range segments {
+		node = node.child(segments[i], segments[:i+1])
+	}
+	node.Failures = append(node.Failures, failure)
+}
+
+func (v *validator) ValidateHttpRequestStructured(request *http.Request) *RequestValidationReport {
+	valid, validationErrors, warnings := v.ValidateHttpRequestWithWarnings(request)
+	report := &RequestValidationReport{Valid: valid, Warnings: warnings}
+	for _, validationError := range validationErrors {
+		switch {
+		case validationError.IsPathMissingError(), validationError.IsOperationMissingError(),
+			validationError.ValidationSubType == helpers.RequestMissingOperation:
+			report.Routing = append(report.Routing, validationError)
+
+		case validationError.ValidationType == helpers.ParameterValidation:
+			switch validationError.ValidationSubType {
+			case helpers.ParameterValidationPath:
+				report.PathParameters = append(report.PathParameters, validationError)
+			case helpers.ParameterValidationQuery:
+				report.QueryParameters = append(report.QueryParameters, validationError)
+			case helpers.ParameterValidationHeader:
+				report.HeaderParameters = append(report.HeaderParameters, validationError)
+			case helpers.ParameterValidationCookie:
+				report.CookieParameters = append(report.CookieParameters, validationError)
+			default:
+				report.Other = append(report.Other, validationError)
+			}
+
+		case validationError.ValidationType == helpers.SecurityValidation:
+			report.Security = append(report.Security, validationError)
+
+		case validationError.ValidationType == helpers.RequestBodyValidation:
+			report.Body = append(report.Body, validationError)
+			for _, failure := range validationError.SchemaValidationErrors {
+				if report.BodyTree == nil {
+					report.BodyTree = &BodyErrorNode{Path: "$"}
+				}
+				report.BodyTree.add(failure)
+			}
+
+		default:
+			report.Other = append(report.Other, validationError)
+		}
+	}
+	return report
+}
@@ -4,11 +4,13 @@
 package requests
 
 import (
+	"io"
 	"net/http"
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 
 	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
@@ -28,6 +30,16 @@ type RequestBodyValidator interface {
 	// request body is valid, false if it is not. The second return value will be a slice of ValidationError pointers if
 	// the body is not valid.
 	ValidateRequestBodyWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
+
+	// ValidateRequestBodyByOperationId will validate a body against the request body of the operation with a matching
+	// operationId, without the need for an *http.Request (useful for message consumers). The content type is used
+	// to select the media type of the request body. If no operation can be found, a validation error is returned.
+	ValidateRequestBodyByOperationId(operationId string, body io.Reader, contentType string) (bool, []*errors.ValidationError)
+
+	// ValidateRequestBodyAs will validate the request body for an operation as the given media type, whatever the
+	// Content-Type header of the request says (useful for clients that send the wrong content type, and for testing).
+	// If the media type is not declared by the request body of the operation, a validation error is returned.
+	ValidateRequestBodyAs(request *http.Request, mediaType string) (bool, []*errors.ValidationError)
 }
 
 // NewRequestBodyValidator will create a new RequestBodyValidator from an OpenAPI 3+ document
@@ -41,6 +53,7 @@ type schemaCache struct {
 	schema         *base.Schema
 	renderedInline []byte
 	renderedJSON   []byte
+	compiledSchema *jsonschema.Schema
 }
 
 type requestBodyValidator struct {
@@ -4,35 +4,90 @@
 package requests
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"strings"
+	"net/url"
+	"slices"
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/utils"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 
-	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 )
 
 func (v *requestBodyValidator) ValidateRequestBody(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
 	return v.ValidateRequestBodyWithPathItem(request, pathItem, foundPath)
 }
 
+func (v *requestBodyValidator) ValidateRequestBodyByOperationId(operationId string, body io.Reader, contentType string) (bool, []*errors.ValidationError) {
+	path, method, pathItem, _ := helpers.FindOperationByOperationId(v.document, operationId)
+	if pathItem == nil {
+		return false, []*errors.ValidationError{errors.OperationIdNotFound(operationId)}
+	}
+
+	// there is no real request, so build one that carries the body and content type to the operation.
+	if body == nil {
+		body = http.NoBody
+	}
+	request := &http.Request{
+		Method: method,
+		URL:    &url.URL{Path: path},
+		Header: http.Header{},
+		Body:   io.NopCloser(body),
+	}
+	if contentType != "" {
+		request.Header.Set(helpers.ContentTypeHeader, contentType)
+	}
+	return v.ValidateRequestBodyWithPathItem(request, pathItem, path)
+}
+
+func (v *requestBodyValidator) ValidateRequestBodyAs(request *http.Request, mediaType string) (bool, []*errors.ValidationError) {
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
+	if len(errs) > 0 {
+		return false, errs
+	}
+
+	// the request is left as it is, a copy carries the media type in place of the content type that was sent.
+	forced := request.Clone(request.Context())
+	forced.Header.Set(helpers.ContentTypeHeader, mediaType)
+
+	operation := helpers.ExtractOperation(forced, pathItem)
+	if operation == nil {
+		return false, []*errors.ValidationError{errors.OperationNotFound(pathItem, forced, forced.Method, foundPath)}
+	}
+	if operation.RequestBody == nil {
+		return false, []*errors.ValidationError{errors.RequestBodyNotAccepted(operation, forced, foundPath)}
+	}
+	if _, ok := v.extractContentType(mediaType, operation); !ok {
+		return false, []*errors.ValidationError{errors.RequestContentTypeNotFound(operation, forced, foundPath)}
+	}
+
+	valid, validationErrors := v.ValidateRequestBodyWithPathItem(forced, pathItem, foundPath)
+
+	// the body was read from the copy, hand it back so it can be read again.
+	request.Body = forced.Body
+	return valid, validationErrors
+}
+
 func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
 	if pathItem == nil {
 		return false, []*errors.ValidationError{{
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -46,15 +101,53 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 		return false, []*errors.ValidationError{errors.OperationNotFound(pathItem, request, request.Method, pathValue)}
 	}
 	if operation.RequestBody == nil {
+		if v.options.RejectBodies {
+			if body, tooLarge := readRequestBody(request, v.options.MaxBodyBytes); tooLarge || len(bytes.TrimSpace(body)) > 0 {
+				return false, []*errors.ValidationError{errors.RequestBodyNotAccepted(operation, request, pathValue)}
+			}
+		}
 		return true, nil
 	}
 
-	// extract the content type from the request
-	contentType := request.Header.Get(helpers.ContentTypeHeader)
 	required := false
 	if operation.RequestBody.Required != nil {
 		required = *operation.RequestBody.Required
 	}
+
+	// check the body is present before anything else, a missing body is not the same thing as a malformed one.
+	requestBody, tooLarge := readRequestBody(request, v.options.MaxBodyBytes)
+	if tooLarge {
+		return false, []*errors.ValidationError{errors.RequestBodyTooLarge(request, pathValue, v.options.MaxBodyBytes)}
+	}
+
+	// a compressed body is decoded to be validated, the request keeps the compressed body for the handlers that
+	// follow. A body with an encoding that is not supported cannot be validated, so it fails.
+	if encoding := request.Header.Get(helpers.ContentEncodingHeader); encoding != "" && len(requestBody) > 0 {
+		decoded, decodedTooLarge, err := helpers.DecodeContentEncoding(requestBody, encoding, v.options.MaxDecodedBytes)
+		if err != nil {
+			return false, []*errors.ValidationError{errors.RequestBodyEncodingInvalid(request, encoding, pathValue, err)}
+		}
+		if decodedTooLarge {
+			return false, []*errors.ValidationError{errors.RequestBodyTooLarge(request, pathValue, v.options.MaxDecodedBytes)}
+		}
+		requestBody = decoded
+	}
+
+	// a content type that is sent must be declared by the request body, even if the body is empty.
+	if contentType := request.Header.Get(helpers.ContentTypeHeader); contentType != "" {
+		if _, ok := v.extractContentType(contentType, operation); !ok {
+			return false, []*errors.ValidationError{errors.RequestContentTypeNotFound(operation, request, pathValue)}
+		}
+	}
+	if len(bytes.TrimSpace(requestBody)) == 0 {
+		if !required {
+			return true, nil
+		}
+		return false, []*errors.ValidationError{errors.RequestBodyMissing(operation, request, pathValue)}
+	}
+
+	// extract the content type from the request
+	contentType := request.Header.Get(helpers.ContentTypeHeader)
 	if contentType == "" {
 		if !required {
 			// request body is not required, the validation stop there.
@@ -69,9 +162,9 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 		return false, []*errors.ValidationError{errors.RequestContentTypeNotFound(operation, request, pathValue)}
 	}
 
-	// we currently only support JSON validation for request bodies
-	// this will capture *everything* that contains some form of 'json' in the content type
-	if !strings.Contains(strings.ToLower(contentType), helpers.JSONType) {
+	// only bodies there is a codec for can be validated, that is JSON (including '+json' types and sequences of
+	// JSON records, like 'application/x-ndjson'), XML and form data. Anything else is not validated.
+	if !helpers.CanDecodeBody(contentType) {
 		return true, nil
 	}
 
@@ -80,54 +173,98 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 		return true, nil
 	}
 
-	// extract schema from media type
-	var schema *base.Schema
-	var renderedInline, renderedJSON []byte
+	// extract schema from media type, the work is only performed once per schema and cached in the validator.
+	cached := v.cachedSchema(mediaType.Schema)
 
-	// have we seen this schema before? let's hash it and check the cache.
-	hash := mediaType.GoLow().Schema.Value.Hash()
-
-	// perform work only once and cache the result in the validator.
-	if cacheHit, ch := v.schemaCache.Load(hash); ch {
-		// got a hit, use cached values
-		schema = cacheHit.(*schemaCache).schema
-		renderedInline = cacheHit.(*schemaCache).renderedInline
-		renderedJSON = cacheHit.(*schemaCache).renderedJSON
-
-	} else {
+	// XML and form data are decoded by their codec (with the help of the schema), and validated like JSON.
+	if helpers.IsXMLMediaType(contentType) || helpers.IsFormMediaType(contentType) {
+		decoded, err := helpers.DecodeBody(requestBody, contentType, cached.schema)
+		if err != nil {
+			return false, []*errors.ValidationError{errors.RequestBodyCannotBeDecoded(request, contentType, pathValue, err)}
+		}
+		requestBody, _ = json.Marshal(decoded)
+	}
 
-		// render the schema inline and perform the intensive work of rendering and converting
-		// this is only performed once per schema and cached in the validator.
-		schema = mediaType.Schema.Schema()
-		renderedInline, _ = schema.RenderInline()
-		renderedJSON, _ = utils.ConvertYAMLtoJSON(renderedInline)
-		v.schemaCache.Store(hash, &schemaCache{
-			schema:         schema,
-			renderedInline: renderedInline,
-			renderedJSON:   renderedJSON,
-		})
+	// a JSON sequence (e.g. 'application/x-ndjson') is validated one record at a time.
+	if helpers.IsJSONSequenceMediaType(contentType) {
+		validationSucceeded, validationErrors := v.validateRequestRecords(request, requestBody, contentType, cached)
+		errors.PopulateValidationErrors(validationErrors, request, pathValue)
+		return validationSucceeded, validationErrors
 	}
 
-	validationSucceeded, validationErrors := ValidateRequestSchema(request, schema, renderedInline, renderedJSON, config.WithExistingOpts(v.options))
+	// the body has already been read, so validate it directly rather than reading it all over again.
+	validationSucceeded, validationErrors := validateRequestSchema(request, requestBody, cached.schema,
+		cached.renderedInline, cached.renderedJSON, cached.compiledSchema, v.options)
 
 	errors.PopulateValidationErrors(validationErrors, request, pathValue)
 
 	return validationSucceeded, validationErrors
 }
 
-func (v *requestBodyValidator) extractContentType(contentType string, operation *v3.Operation) (*v3.MediaType, bool) {
-	ct, _, _ := helpers.ExtractContentType(contentType)
-	mediaType, ok := operation.RequestBody.Content.Get(ct)
-	if ok {
-		return mediaType, true
-	}
-	ctMediaRange := strings.SplitN(ct, "/", 2)
-	for s, mediaTypeValue := range operation.RequestBody.Content.FromOldest() {
-		opMediaRange := strings.SplitN(s, "/", 2)
-		if (opMediaRange[0] == "*" || opMediaRange[0] == ctMediaRange[0]) &&
-			(opMediaRange[1] == "*" || opMediaRange[1] == ctMediaRange[1]) {
-			return mediaTypeValue, true
+// cachedSchema renders and compiles a schema, the result is cached by the hash of the schema, so the intensive work of
+// rendering and compiling is only performed once per schema.
+func (v *requestBodyValidator) cachedSchema(proxy *base.SchemaProxy) *schemaCache {
+	// have we seen this schema before? let's hash it and check the cache.
+	hash := proxy.GoLow().Hash()
+	if cacheHit, ch := v.schemaCache.Load(hash); ch {
+		// got a hit, use cached values
+		return cacheHit.(*schemaCache)
+	}
+
+	// render the schema inline and perform the intensive work of rendering and converting
+	schema := proxy.Schema()
+	renderedInline, _ := helpers.RenderSchemaInline(schema)
+	renderedJSON, _ := utils.ConvertYAMLtoJSON(renderedInline)
+	// a schema that fails to compile is left out, so the failure is reported for every request.
+	compiledSchema, _ := helpers.NewCompiledBodySchema("requestBody", renderedJSON, v.options)
+	cached := &schemaCache{
+		schema:         schema,
+		renderedInline: renderedInline,
+		renderedJSON:   renderedJSON,
+		compiledSchema: compiledSchema,
+	}
+	v.schemaCache.Store(hash, cached)
+	return cached
+}
+
+// validateRequestRecords validates each record of a JSON sequence body (like 'application/x-ndjson'). If the schema
+// describes an array, each record is validated against the schema of its items, otherwise against the schema itself.
+// The index of the failing record (starting at zero) is added to each error.
+func (v *requestBodyValidator) validateRequestRecords(
+	request *http.Request, requestBody []byte, contentType string, cached *schemaCache,
+) (bool, []*errors.ValidationError) {
+	recordSchema := cached
+	if sch := cached.schema; sch != nil && slices.Contains(sch.Type, helpers.Array) && sch.Items != nil && sch.Items.IsA() {
+		recordSchema = v.cachedSchema(sch.Items.A)
+	}
+
+	var validationErrors []*errors.ValidationError
+	for i, record := range helpers.SplitJSONSequence(requestBody, contentType) {
+		valid, recordErrors := validateRequestSchema(request, record, recordSchema.schema,
+			recordSchema.renderedInline, recordSchema.renderedJSON, recordSchema.compiledSchema, v.options)
+		if valid {
+			continue
+		}
+		for _, recordError := range recordErrors {
+			recordError.Message = fmt.Sprintf("%s (record %d)", recordError.Message, i)
+			if recordError.MessageArgs == nil {
+				recordError.MessageArgs = make(map[string]any)
+			}
+			recordError.MessageArgs["record"] = i
 		}
+		validationErrors = append(validationErrors, recordErrors...)
+		if v.options.StopOnFirstRecord {
+			break
+		}
+	}
+	return len(validationErrors) == 0, validationErrors
+}
+
+func (v *requestBodyValidator) extractContentType(contentType string, operation *v3.Operation) (*v3.MediaType, bool) {
+	// media types are case-insensitive, the declared type may be a range (like 'application/*').
+	declared, ok := helpers.MatchMediaType(operation.RequestBody.Content.KeysFromOldest(), contentType)
+	if !ok {
+		return nil, false
 	}
-	return nil, false
+	return operation.RequestBody.Content.GetOrZero(declared), true
 }
@@ -5,13 +5,18 @@ package requests
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
 	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -92,6 +97,38 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
+func TestValidateBody_VendorJSONContentType(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/vnd.myco.v2+json:
+            schema:
+              type: object
+              properties:
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"patties": false}`))
+	request.Header.Set("Content-Type", "Application/VND.MyCo.v2+JSON; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "$.patties", errors[0].SchemaValidationErrors[0].FieldPath)
+}
+
 func TestValidateBody_MediaRangeContentType_Wildcards(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -135,6 +172,98 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
+func TestValidateBody_MediaRangeContentType_Wildcards_MalformedContentType(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          "*/*":
+            schema:
+              type: object`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBuffer([]byte(`{}`)))
+	request.Header.Set("Content-Type", "tank-engine") // no subtype, cannot be matched against a range.
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Content type 'tank-engine' is not supported", errors[0].Message)
+}
+
+func TestValidateBody_UnsupportedContentType(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBuffer([]byte("not json at all")))
+	request.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Content type 'text/plain' is not supported", errors[0].Message)
+	assert.Equal(t, "The content type is invalid, Use one of the 1 "+
+		"supported types for this operation: application/json", errors[0].HowToFix)
+}
+
+func TestValidateBody_SupportedContentType_WithCharset(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBuffer([]byte(`{"name": 1}`)))
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+}
+
 func TestValidateBody_InvalidBasicSchema_MediaRangeContentType_Wildcard_Required(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -224,7 +353,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "POST operation request content type 'thomas/tank-engine' does not exist", errors[0].Message)
+	assert.Equal(t, "Content type 'thomas/tank-engine' is not supported", errors[0].Message)
 	assert.Equal(t, "The content type is invalid, Use one of the 1 "+
 		"supported types for this operation: application/json", errors[0].HowToFix)
 	assert.Equal(t, request.Method, errors[0].RequestMethod)
@@ -1151,6 +1280,7 @@ paths:
   /burgers/createBurger:
     post:
       requestBody:
+        required: true
         content:
           application/json:
             schema:
@@ -1305,10 +1435,51 @@ components:
 
 	valid, errors := v.ValidateRequestBody(request)
 
+	// a syntax error is reported on its own, rather than as a schema violation.
 	assert.False(t, valid)
-	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 1)
-	assert.Equal(t, "invalid character '}' looking for beginning of object key string", errors[0].SchemaValidationErrors[0].Reason)
+	require.Len(t, errors, 1)
+	assert.Empty(t, errors[0].SchemaValidationErrors)
+	assert.Equal(t, "POST request body is not valid JSON for '/burgers/createBurger'", errors[0].Message)
+	assert.Equal(t, helpers.RequestBodyInvalidJSON, errors[0].ValidationSubType)
+	assert.Equal(t, "The request body cannot be parsed as JSON, the syntax error is at byte offset 16: "+
+		"invalid character '}' looking for beginning of object key string", errors[0].Reason)
+	assert.Equal(t, int64(16), errors[0].MessageArgs["offset"])
+}
+
+func TestValidateBody_TruncatedJSON(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "Big Ma`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	// the syntax error is reported before (and instead of) the schema, so 'name' is not reported as missing.
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body is not valid JSON for '/burgers/createBurger'", errors[0].Message)
+	assert.Equal(t, liberrors.MessageKeyRequestBodyInvalidJSON, errors[0].MessageKey)
+	assert.Equal(t, int64(16), errors[0].MessageArgs["offset"])
+	assert.Equal(t, "/burgers/createBurger", errors[0].SpecPath)
+	assert.Empty(t, errors[0].SchemaValidationErrors)
 }
 
 func TestValidateBody_SchemaNoType_Issue75(t *testing.T) {
@@ -1442,7 +1613,7 @@ func TestValidateBody_SchemaNoType_Issue75(t *testing.T) {
 
 	assert.False(t, isSuccess)
 	assert.Len(t, valErrs, 1)
-	assert.Equal(t, "PUT request body is empty for '/path1'", valErrs[0].Message)
+	assert.Equal(t, "Request body is required", valErrs[0].Message)
 }
 
 // https://github.com/pb33f/libopenapi-validator/issues/144
@@ -1493,3 +1664,1672 @@ components:
 	assert.Len(t, errors[0].SchemaValidationErrors, 1)
 	assert.Equal(t, "'test' is not valid email: missing @", errors[0].SchemaValidationErrors[0].Reason)
 }
+
+func TestValidateBody_AdditionalPropertiesFalse(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                name:
+                  type: string
+                sauce:
+                  type: object
+                  additionalProperties: false
+                  properties:
+                    heat:
+                      type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := map[string]interface{}{
+		"name": "Big Mac",
+		"foo":  "bar",
+		"sauce": map[string]interface{}{
+			"heat":   3,
+			"colour": "red",
+		},
+	}
+
+	bodyBytes, _ := json.Marshal(body)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBuffer(bodyBytes))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	failures := map[string]string{}
+	for _, sve := range errors[0].SchemaValidationErrors {
+		failures[sve.FieldPath] = sve.Reason
+	}
+	assert.Equal(t, "additional properties 'foo' not allowed", failures["$.foo"])
+	assert.Equal(t, "additional properties 'colour' not allowed", failures["$.sauce.colour"])
+}
+
+func TestValidateBody_AdditionalPropertiesFalse_MultipleProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "Big Mac", "foo": 1, "bar": 2}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 2)
+	var fields []string
+	for _, sve := range errors[0].SchemaValidationErrors {
+		fields = append(fields, sve.FieldName+"="+sve.FieldPath)
+	}
+	assert.ElementsMatch(t, []string{"foo=$.foo", "bar=$.bar"}, fields)
+}
+
+var discriminatorSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              oneOf:
+                - $ref: '#/components/schemas/Cat'
+                - $ref: '#/components/schemas/Dog'
+              discriminator:
+                propertyName: petType
+                mapping:
+                  kitty: '#/components/schemas/Cat'
+components:
+  schemas:
+    Cat:
+      type: object
+      required: [petType, meow]
+      properties:
+        petType:
+          type: string
+        meow:
+          type: boolean
+    Dog:
+      type: object
+      required: [petType, bark]
+      properties:
+        petType:
+          type: string
+        bark:
+          type: integer`
+
+func TestValidateBody_Discriminator_SelectsBranch(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"petType": "Dog", "bark": "loud"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "discriminator 'petType' is 'Dog': got string, want integer",
+		errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "$.bark", errors[0].SchemaValidationErrors[0].FieldPath)
+}
+
+func TestValidateBody_Discriminator_Mapping(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"petType": "kitty"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "discriminator 'petType' is 'kitty': missing property 'meow'",
+		errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_Discriminator_UnknownValue(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"petType": "Lizard"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "discriminator value 'Lizard' does not map to a known schema",
+		errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "petType", errors[0].SchemaValidationErrors[0].FieldName)
+}
+
+func TestValidateBody_Discriminator_Valid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"petType": "Cat", "meow": true}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_Discriminator_ArrayItems(t *testing.T) {
+	spec := strings.Replace(discriminatorSpec, `            schema:
+              oneOf:
+                - $ref: '#/components/schemas/Cat'
+                - $ref: '#/components/schemas/Dog'
+              discriminator:
+                propertyName: petType
+                mapping:
+                  kitty: '#/components/schemas/Cat'`, `            schema:
+              type: object
+              properties:
+                pets:
+                  type: array
+                  items:
+                    oneOf:
+                      - $ref: '#/components/schemas/Cat'
+                      - $ref: '#/components/schemas/Dog'
+                    discriminator:
+                      propertyName: petType`, 1)
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"pets": [{"petType": "Cat", "meow": true}, {"petType": "Dog", "bark": "loud"},
+			{"petType": "Cat"}, {"petType": "Lizard"}]}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	// each item only reports the errors of its own branch, at its own index.
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	failures := map[string]string{}
+	for _, sve := range errors[0].SchemaValidationErrors {
+		failures[sve.FieldPath] = sve.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"$.pets[1].bark":    "discriminator 'petType' is 'Dog': got string, want integer",
+		"$.pets[2].meow":    "discriminator 'petType' is 'Cat': missing property 'meow'",
+		"$.pets[3].petType": "discriminator value 'Lizard' does not map to a known schema",
+	}, failures)
+}
+
+func TestValidateBody_ByOperationId(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+  /burgers/{burgerId}:
+    put:
+      operationId: updateBurger
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	valid, errors := v.ValidateRequestBodyByOperationId("updateBurger",
+		bytes.NewBufferString(`{"name": "Big Mac", "patties": 2}`), "application/json")
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = v.ValidateRequestBodyByOperationId("updateBurger",
+		bytes.NewBufferString(`{"patties": "two"}`), "application/json")
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "PUT request body for '/burgers/{burgerId}' failed to validate schema", errors[0].Message)
+	assert.Equal(t, "/burgers/{burgerId}", errors[0].SpecPath)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	valid, errors = v.ValidateRequestBodyByOperationId("updateBurger", bytes.NewBufferString(`{}`), "")
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyContentType, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_ByOperationId_NotFound(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	valid, errors := v.ValidateRequestBodyByOperationId("createBurger",
+		bytes.NewBufferString(`{}`), "application/json")
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Operation with operationId 'createBurger' not found", errors[0].Message)
+}
+
+func TestValidateBody_ByteAndBinaryFormats(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                photo:
+                  type: string
+                  format: byte
+                recipe:
+                  type: string
+                  format: binary`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	v := NewRequestBodyValidator(&m.Model, config.WithFormatAssertions())
+
+	valid, errors := v.ValidateRequestBody(newRequest(`{"photo": "YnVyZ2Vy", "recipe": "anything at all"}`))
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = v.ValidateRequestBody(newRequest(`{"photo": "not base64!"}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "value is not valid base64")
+	assert.Equal(t, "$.photo", errors[0].SchemaValidationErrors[0].FieldPath)
+
+	// without format assertions, the value is not checked.
+	v = NewRequestBodyValidator(&m.Model)
+	valid, errors = v.ValidateRequestBody(newRequest(`{"photo": "not base64!"}`))
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_ContentAssertions(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                recipe:
+                  type: string
+                  contentEncoding: base64
+                  contentMediaType: application/json
+                  contentSchema:
+                    type: object
+                    properties:
+                      name:
+                        type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	v := NewRequestBodyValidator(&m.Model, config.WithContentAssertions())
+
+	// {"name":"Big Mac"}
+	valid, errors := v.ValidateRequestBody(newRequest(`{"recipe": "eyJuYW1lIjoiQmlnIE1hYyJ9"}`))
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = v.ValidateRequestBody(newRequest(`{"recipe": "not base64!"}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "value is not 'base64' encoded")
+	assert.Equal(t, "$.recipe", errors[0].SchemaValidationErrors[0].FieldPath)
+
+	// "not json"
+	valid, errors = v.ValidateRequestBody(newRequest(`{"recipe": "bm90IGpzb24="}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "value is not valid 'application/json' content")
+
+	// {"name":1}, the failure inside the embedded document is reported beneath the property that holds it.
+	valid, errors = v.ValidateRequestBody(newRequest(`{"recipe": "eyJuYW1lIjoxfQ=="}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	fieldPaths := map[string]string{}
+	for _, sve := range errors[0].SchemaValidationErrors {
+		fieldPaths[sve.Reason] = sve.FieldPath
+	}
+	assert.Equal(t, "$.recipe", fieldPaths["the embedded content does not match the 'contentSchema'"])
+	assert.Equal(t, "$.recipe.name", fieldPaths["got number, want string"])
+
+	// without content assertions, the value is not checked.
+	v = NewRequestBodyValidator(&m.Model)
+	valid, errors = v.ValidateRequestBody(newRequest(`{"recipe": "not base64!"}`))
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_IgnoredKeywords(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                  maxLength: 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	v := NewRequestBodyValidator(&m.Model, config.WithIgnoredKeywords([]string{"maxLength"}))
+
+	valid, errors := v.ValidateRequestBody(newRequest(`{"name": "Big Mac"}`))
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// keywords that are not ignored are still checked.
+	valid, errors = v.ValidateRequestBody(newRequest(`{}`))
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+
+	v = NewRequestBodyValidator(&m.Model)
+	valid, errors = v.ValidateRequestBody(newRequest(`{"name": "Big Mac"}`))
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
+
+func TestValidateBody_IgnoreAdditionalProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	v := NewRequestBodyValidator(&m.Model)
+	valid, errors := v.ValidateRequestBody(newRequest(`{"name": "Big Mac", "fries": true}`))
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithIgnoreAdditionalProperties(true))
+	valid, errors = v.ValidateRequestBody(newRequest(`{"name": "Big Mac", "fries": true}`))
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// declared properties are still checked.
+	valid, errors = v.ValidateRequestBody(newRequest(`{"name": 1, "fries": true}`))
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
+
+func TestValidateBody_RequestBodyAs(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// the content type sent is wrong, the body is validated as JSON anyway.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "Big Mac", "patties": 2}`))
+	request.Header.Set(helpers.ContentTypeHeader, "text/plain")
+
+	valid, errors := v.ValidateRequestBodyAs(request, helpers.JSONContentType)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+	assert.Equal(t, "text/plain", request.Header.Get(helpers.ContentTypeHeader))
+	body, _ := io.ReadAll(request.Body)
+	assert.Equal(t, `{"name": "Big Mac", "patties": 2}`, string(body))
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"patties": "two"}`))
+
+	valid, errors = v.ValidateRequestBodyAs(request, helpers.JSONContentType)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	// the media type must be declared by the operation.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`<burger/>`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors = v.ValidateRequestBodyAs(request, "application/xml")
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Content type 'application/xml' is not supported", errors[0].Message)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+
+	valid, errors = v.ValidateRequestBodyAs(request, helpers.JSONContentType)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyNotAccepted, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_RequiredBodyMissing(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// no content type, no body.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Request body is required", errors[0].Message)
+	assert.Equal(t, helpers.RequestBodyMissing, errors[0].ValidationSubType)
+	assert.Equal(t, 6, errors[0].SpecLine)
+
+	// content type, but only whitespace.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString("  \n"))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Request body is required", errors[0].Message)
+
+	// malformed body is not reported as missing.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString("{"))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyInvalidJSON, errors[0].ValidationSubType)
+	assert.Equal(t, int64(1), errors[0].MessageArgs["offset"])
+}
+
+func TestValidateBody_OptionalBodyMissing(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", http.NoBody)
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// an empty body is still sent with a content type, which must be declared.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", http.NoBody)
+	request.Header.Set("Content-Type", "application/xml")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Content type 'application/xml' is not supported", errors[0].Message)
+}
+
+func TestValidateBody_MaxBodyBytes(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithMaxBodyBytes(20))
+
+	// under the limit.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "Big Mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// over the limit.
+	payload := `{"name": "Big Mac with extra cheese"}`
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(payload))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body exceeds maximum size of 20 bytes", errors[0].Message)
+	assert.Equal(t, helpers.RequestBodyTooLarge, errors[0].ValidationSubType)
+
+	// the body must still be readable, in full.
+	remaining, _ := io.ReadAll(request.Body)
+	assert.Equal(t, payload, string(remaining))
+}
+
+func TestValidateRequestSchema_MaxBodyBytes(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "Big Mac"}`))
+
+	valid, errors := ValidateRequestSchema(request, nil, nil, []byte(`{"type": "object"}`),
+		config.WithMaxBodyBytes(5))
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyTooLarge, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_BodyCanBeReadAfterValidation(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	payload := `{"name": 123}`
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(payload))
+	request.Header.Set("Content-Type", "application/json")
+
+	// a failed validation must also leave the body intact.
+	valid, _ := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+
+	read, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(read))
+}
+
+func TestValidateBody_Const(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /events:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                type:
+                  type: string
+                  const: order.created
+                version:
+                  type: number
+                  const: 2
+                live:
+                  type: boolean
+                  const: true`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/events",
+		bytes.NewBufferString(`{"type": "order.created", "version": 2, "live": true}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/events",
+		bytes.NewBufferString(`{"type": "order.updated", "version": 3, "live": false}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	reasons := map[string]string{}
+	for _, sve := range errors[0].SchemaValidationErrors {
+		reasons[sve.FieldName] = sve.Reason
+	}
+	assert.Equal(t, "value must be constant 'order.created'", reasons["type"])
+	assert.Equal(t, "value must be constant 2", reasons["version"])
+	assert.Equal(t, "value must be constant true", reasons["live"])
+}
+
+func TestValidateBody_ExclusiveBounds(t *testing.T) {
+	specs := map[string]string{
+		"3.0.3": `
+                  minimum: 0
+                  exclusiveMinimum: true
+                  maximum: 100
+                  exclusiveMaximum: true`,
+		"3.1.0": `
+                  exclusiveMinimum: 0
+                  exclusiveMaximum: 100`,
+	}
+
+	for version, bounds := range specs {
+		t.Run(version, func(t *testing.T) {
+			spec := `openapi: ` + version + `
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                price:
+                  type: number` + bounds
+
+			doc, _ := libopenapi.NewDocument([]byte(spec))
+
+			m, _ := doc.BuildV3Model()
+			v := NewRequestBodyValidator(&m.Model)
+
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+				bytes.NewBufferString(`{"price": 0.5}`))
+			request.Header.Set("Content-Type", "application/json")
+
+			valid, errors := v.ValidateRequestBody(request)
+			assert.True(t, valid)
+			assert.Len(t, errors, 0)
+
+			request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+				bytes.NewBufferString(`{"price": 0}`))
+			request.Header.Set("Content-Type", "application/json")
+
+			valid, errors = v.ValidateRequestBody(request)
+			assert.False(t, valid)
+			require.Len(t, errors, 1)
+			require.Len(t, errors[0].SchemaValidationErrors, 1)
+			assert.Equal(t, "value must be greater than 0", errors[0].SchemaValidationErrors[0].Reason)
+
+			request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+				bytes.NewBufferString(`{"price": 100}`))
+			request.Header.Set("Content-Type", "application/json")
+
+			valid, errors = v.ValidateRequestBody(request)
+			assert.False(t, valid)
+			require.Len(t, errors, 1)
+			require.Len(t, errors[0].SchemaValidationErrors, 1)
+			assert.Equal(t, "value must be less than 100", errors[0].SchemaValidationErrors[0].Reason)
+		})
+	}
+}
+
+func TestValidateBody_PatternAndPatternProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  pattern: '^[a-z]+$'
+                metadata:
+                  type: object
+                  patternProperties:
+                    '^x-':
+                      type: string
+                  additionalProperties: false`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"name": "bigmac", "metadata": {"x-sauce": "special"}}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"name": "Big Mac", "metadata": {"x-sauce": 1, "pickles": "yes"}}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	failures := map[string]*liberrors.SchemaValidationFailure{}
+	for _, sve := range errors[0].SchemaValidationErrors {
+		failures[sve.FieldPath] = sve
+	}
+	require.Len(t, failures, 3)
+	assert.Equal(t, "value does not match pattern '^[a-z]+$'", failures["$.name"].Reason)
+	assert.Equal(t, "Big Mac", failures["$.name"].Value)
+	assert.Equal(t, "got number, want string", failures["$.metadata['x-sauce']"].Reason)
+	assert.Equal(t, "additional properties 'pickles' not allowed", failures["$.metadata.pickles"].Reason)
+}
+
+func TestValidateBody_PrefixItems(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /points:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: array
+              prefixItems:
+                - type: string
+                - type: number
+              items: false`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/points",
+		bytes.NewBufferString(`["a", 1]`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/points",
+		bytes.NewBufferString(`[1, "b", true]`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	var paths []string
+	for _, sve := range errors[0].SchemaValidationErrors {
+		paths = append(paths, sve.FieldPath)
+	}
+	assert.ElementsMatch(t, []string{"$[0]", "$[1]", "$[2]"}, paths)
+}
+
+func TestValidateBody_AllOf(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Base'
+                - type: object
+                  required: [name]
+                  properties:
+                    name:
+                      type: string
+                - type: object
+                  required: [name]
+components:
+  schemas:
+    Base:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"id": 1, "name": "big mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the base is satisfied, but the extension is not.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"id": 1}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+
+	// every branch is validated, failures are aggregated.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	var reasons []string
+	for _, sve := range errors[0].SchemaValidationErrors {
+		reasons = append(reasons, sve.Reason)
+	}
+	assert.Equal(t, []string{"missing property 'id'", "missing property 'name'"}, reasons)
+}
+
+func TestValidateBody_Not(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+              not:
+                required: [legacyId]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"name": "big mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the legacy format is forbidden.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"name": "big mac", "legacyId": 12}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "value must not match the 'not' schema", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/not", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestValidateBody_DependentRequired(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /payments:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Payment'
+components:
+  schemas:
+    Payment:
+      type: object
+      properties:
+        card:
+          type: string
+        cvv:
+          type: string
+      dependentRequired:
+        card: [cvv]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/payments",
+		bytes.NewBufferString(`{"card": "4111111111111111", "cvv": "123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/payments",
+		bytes.NewBufferString(`{"card": "4111111111111111"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "property 'cvv' is required when 'card' is present", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/dependentRequired/card", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestValidateBody_DependentSchemas(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /payments:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                card:
+                  type: string
+              dependentSchemas:
+                card:
+                  required: [cvv]
+                  properties:
+                    cvv:
+                      type: string
+                      pattern: "^[0-9]{3,4}$"`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/payments",
+		bytes.NewBufferString(`{"card": "4111111111111111", "cvv": "123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/payments",
+		bytes.NewBufferString(`{"card": "4111111111111111", "cvv": "twelve"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "value does not match pattern '^[0-9]{3,4}$'", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/dependentSchemas/card/properties/cvv/pattern", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestValidateBody_CompiledSchemaCached(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"name": "big mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the schema is compiled once, and re-used for the requests that follow.
+	var cached *schemaCache
+	v.(*requestBodyValidator).schemaCache.Range(func(_, value any) bool {
+		cached = value.(*schemaCache)
+		return false
+	})
+	require.NotNil(t, cached)
+	require.NotNil(t, cached.compiledSchema)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"patties": 2}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_NestedRequired(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /customers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                address:
+                  type: object
+                  required: [street]
+                  properties:
+                    street:
+                      type: string
+                    geo:
+                      type: object
+                      required: [lat]
+                      properties:
+                        lat:
+                          type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/customers",
+		bytes.NewBufferString(`{"address": {"geo": {}}}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	// the failures point at the missing properties, at each level of nesting.
+	street := errors[0].SchemaValidationErrors[0]
+	assert.Equal(t, "missing property 'street'", street.Reason)
+	assert.Equal(t, "street", street.FieldName)
+	assert.Equal(t, "$.address.street", street.FieldPath)
+
+	lat := errors[0].SchemaValidationErrors[1]
+	assert.Equal(t, "missing property 'lat'", lat.Reason)
+	assert.Equal(t, "lat", lat.FieldName)
+	assert.Equal(t, "$.address.geo.lat", lat.FieldPath)
+}
+
+func TestValidateBody_IfThenElse(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [type]
+              properties:
+                type:
+                  type: string
+              if:
+                properties:
+                  type:
+                    const: dog
+              then:
+                required: [bark]
+              else:
+                required: [meow]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*liberrors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	valid, errs := validate(`{"type": "dog", "bark": true}`)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = validate(`{"type": "cat", "meow": true}`)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// a dog must bark.
+	valid, errs = validate(`{"type": "dog", "meow": true}`)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	require.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "'if' matched, so 'then' applies: missing property 'bark'", errs[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/then/required", errs[0].SchemaValidationErrors[0].Location)
+	assert.Equal(t, "$.bark", errs[0].SchemaValidationErrors[0].FieldPath)
+
+	// everything else must meow.
+	valid, errs = validate(`{"type": "cat", "bark": true}`)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	require.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "'if' did not match, so 'else' applies: missing property 'meow'", errs[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/else/required", errs[0].SchemaValidationErrors[0].Location)
+}
+
+func TestValidateBody_ContentEncoding(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	compress := func(body string) []byte {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		_, _ = gw.Write([]byte(body))
+		_ = gw.Close()
+		return buf.Bytes()
+	}
+	newRequest := func(body []byte, encoding string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers", bytes.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Content-Encoding", encoding)
+		return request
+	}
+
+	v := NewRequestBodyValidator(&m.Model)
+
+	// the body is decoded to be validated, and is still compressed for the handlers that follow.
+	compressed := compress(`{"name": "big mac"}`)
+	request := newRequest(compressed, "gzip")
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+	restored, _ := io.ReadAll(request.Body)
+	assert.Equal(t, compressed, restored)
+
+	valid, errors = v.ValidateRequestBody(newRequest(compress(`{"patties": 2}`), "gzip"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+
+	// corrupt compressed data.
+	valid, errors = v.ValidateRequestBody(newRequest([]byte(`{"name": "big mac"}`), "gzip"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body for '/burgers' failed to decode content encoding 'gzip'", errors[0].Message)
+
+	// an encoding that is not supported cannot be validated, so it fails rather than being let through.
+	valid, errors = v.ValidateRequestBody(newRequest([]byte("garbage"), "br"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body for '/burgers' failed to decode content encoding 'br'", errors[0].Message)
+	assert.Equal(t, "The request body failed to decode content encoding 'br': "+
+		"the content encoding 'br' is not supported", errors[0].Reason)
+	assert.Equal(t, helpers.RequestBodyEncoding, errors[0].ValidationSubType)
+
+	// the decoded body is capped.
+	v = NewRequestBodyValidator(&m.Model, config.WithMaxDecodedBytes(10))
+	valid, errors = v.ValidateRequestBody(newRequest(compressed, "gzip"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyTooLarge, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_RejectUndeclaredBodies(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// by default, the body is ignored.
+	v := NewRequestBodyValidator(&m.Model)
+	valid, errors := v.ValidateRequestBody(newRequest(`{"name": "big mac"}`))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithRejectUndeclaredBodies())
+	request := newRequest(`{"name": "big mac"}`)
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyNotAccepted, errors[0].ValidationSubType)
+	assert.Equal(t, "GET operation does not accept a request body", errors[0].Message)
+	assert.Equal(t, "/burgers", errors[0].SpecPath)
+	assert.Equal(t, 4, errors[0].SpecLine)
+
+	// the body can still be read by the handlers that follow.
+	restored, _ := io.ReadAll(request.Body)
+	assert.Equal(t, `{"name": "big mac"}`, string(restored))
+
+	// an empty body is not a body.
+	valid, errors = v.ValidateRequestBody(newRequest(" "))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateBody_RefRequestBody(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+    put:
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+components:
+  requestBodies:
+    Pet:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	newRequest := func(method, body string) *http.Request {
+		request, _ := http.NewRequest(method, "https://things.com/pets", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// every operation that references the request body is validated against it.
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		valid, errors := v.ValidateRequestBody(newRequest(method, `{"name": "bobby"}`))
+		assert.True(t, valid)
+		assert.Empty(t, errors)
+
+		valid, errors = v.ValidateRequestBody(newRequest(method, `{"age": 3}`))
+		assert.False(t, valid)
+		require.Len(t, errors, 1)
+		assert.Equal(t, method+" request body for '/pets' failed to validate schema", errors[0].Message)
+		assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+
+		// 'required' comes from the referenced request body.
+		valid, errors = v.ValidateRequestBody(newRequest(method, ""))
+		assert.False(t, valid)
+		require.Len(t, errors, 1)
+		assert.Equal(t, "Request body is required", errors[0].Message)
+		assert.Equal(t, 13, errors[0].SpecLine)
+	}
+}
+
+func TestValidateBody_NDJSON(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/stream:
+    post:
+      requestBody:
+        content:
+          application/x-ndjson:
+            schema:
+              type: array
+              items:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+          application/json-seq:
+            schema:
+              type: object
+              required: [name]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := "{\"name\": \"big mac\"}\n{\"patties\": 2}\n\n{\"name\": \"whopper\"}\n{\"name\": 3}\n"
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/stream",
+		bytes.NewBufferString(body))
+	request.Header.Set("Content-Type", "application/x-ndjson")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "POST request body for '/burgers/stream' failed to validate schema (record 1)", errors[0].Message)
+	assert.Equal(t, 1, errors[0].MessageArgs["record"])
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, 3, errors[1].MessageArgs["record"])
+
+	// stop at the first record that fails.
+	v = NewRequestBodyValidator(&m.Model, config.WithStopOnFirstRecord())
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/stream",
+		bytes.NewBufferString(body))
+	request.Header.Set("Content-Type", "application/x-ndjson")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, 1, errors[0].MessageArgs["record"])
+
+	// a JSON text sequence, validated against a schema that is not an array.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/stream",
+		bytes.NewBufferString("\x1e{\"name\": \"big mac\"}\n\x1e{\"name\": \"whopper\"}\n"))
+	request.Header.Set("Content-Type", "application/json-seq")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_ContentTypeCaseAndCharset(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json; charset=iso-8859-1:
+            schema:
+              type: object
+              required: [name]
+          application/json; charset=utf-8:
+            schema:
+              type: object
+              required: [patties]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"patties": 2}`))
+	request.Header.Set("Content-Type", "Application/JSON; charset=UTF-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"patties": 2}`))
+	request.Header.Set("Content-Type", "application/json; charset=ISO-8859-1")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"patties": 2}`))
+	request.Header.Set("Content-Type", "application/json; charset=utf-16")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyContentType, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_ContentTypeCodecs(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Burger'
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Burger'
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/Burger'
+components:
+  schemas:
+    Burger:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        patties:
+          type: integer
+          maximum: 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	post := func(contentType, body string) (bool, []*liberrors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", contentType)
+		return v.ValidateRequestBody(request)
+	}
+
+	valid, errors := post("application/xml", `<burger><name>big mac</name><patties>2</patties></burger>`)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = post("application/xml", `<burger><patties>4</patties></burger>`)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	valid, errors = post("application/xml", `<burger><name>big mac</burger>`)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body for '/burgers' cannot be decoded as 'application/xml'", errors[0].Message)
+
+	valid, errors = post("application/x-www-form-urlencoded", "name=whopper&patties=1")
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = post("application/x-www-form-urlencoded", "name=whopper&patties=lots")
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "got string, want integer", errors[0].SchemaValidationErrors[0].Reason)
+
+	valid, errors = post("text/csv", "name,patties")
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Content type 'text/csv' is not supported", errors[0].Message)
+}
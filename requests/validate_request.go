@@ -15,8 +15,6 @@ import (
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"gopkg.in/yaml.v3"
 
 	"github.com/pb33f/libopenapi-validator/config"
@@ -38,43 +36,65 @@ func ValidateRequestSchema(
 ) (bool, []*errors.ValidationError) {
 	validationOptions := config.NewValidationOptions(opts...)
 
-	var validationErrors []*errors.ValidationError
+	requestBody, tooLarge := readRequestBody(request, validationOptions.MaxBodyBytes)
+	if tooLarge {
+		return false, []*errors.ValidationError{
+			errors.RequestBodyTooLarge(request, "", validationOptions.MaxBodyBytes),
+		}
+	}
+	return validateRequestSchema(request, requestBody, schema, renderedSchema, jsonSchema, nil, validationOptions)
+}
 
-	var requestBody []byte
-	if request != nil && request.Body != nil {
-		requestBody, _ = io.ReadAll(request.Body)
+// readRequestBody reads the request body and then restores it, so it can be re-read later by another player in
+// the chain. If maxBytes is greater than zero, no more than maxBytes+1 bytes are read, and the second return value
+// will be true if the body is larger than maxBytes (nothing that was read is lost, it's put back in front of the
+// unread remainder of the body).
+func readRequestBody(request *http.Request, maxBytes int64) ([]byte, bool) {
+	if request == nil || request.Body == nil {
+		return nil, false
+	}
+	original := request.Body
+	var reader io.Reader = original
+	if maxBytes > 0 {
+		reader = io.LimitReader(original, maxBytes+1)
+	}
+	requestBody, _ := io.ReadAll(reader)
+	if maxBytes > 0 && int64(len(requestBody)) > maxBytes {
+		request.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(requestBody), original), original}
+		return nil, true
+	}
 
-		// close the request body, so it can be re-read later by another player in the chain
-		_ = request.Body.Close()
-		request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+	// close the request body, so it can be re-read later by another player in the chain
+	_ = original.Close()
+	request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	return requestBody, false
+}
 
-	}
+// validateRequestSchema validates a request body that has already been read. If the schema has already been
+// compiled (e.g. it was cached), the compiled schema is used, otherwise the JSON schema is compiled.
+func validateRequestSchema(
+	request *http.Request,
+	requestBody []byte,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	jsch *jsonschema.Schema,
+	validationOptions *config.ValidationOptions,
+) (bool, []*errors.ValidationError) {
+	var validationErrors []*errors.ValidationError
 
 	var decodedObj interface{}
 
 	if len(requestBody) > 0 {
 		err := json.Unmarshal(requestBody, &decodedObj)
 		if err != nil {
-			// cannot decode the request body, so it's not valid
-			violation := &errors.SchemaValidationFailure{
-				Reason:          err.Error(),
-				Location:        "unavailable",
-				ReferenceSchema: string(renderedSchema),
-				ReferenceObject: string(requestBody),
-			}
-			validationErrors = append(validationErrors, &errors.ValidationError{
-				ValidationType:    helpers.RequestBodyValidation,
-				ValidationSubType: helpers.Schema,
-				Message: fmt.Sprintf("%s request body for '%s' failed to validate schema",
-					request.Method, request.URL.Path),
-				Reason:                 fmt.Sprintf("The request body cannot be decoded: %s", err.Error()),
-				SpecLine:               1,
-				SpecCol:                0,
-				SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
-				HowToFix:               errors.HowToFixInvalidSchema,
-				Context:                string(renderedSchema), // attach the rendered schema to the error
-			})
-			return false, validationErrors
+			// the body is not well-formed JSON, that's a syntax error, there is nothing to validate the schema against.
+			invalidJSON := errors.RequestBodyInvalidJSON(request, err)
+			invalidJSON.Context = string(renderedSchema) // attach the rendered schema to the error
+			return false, append(validationErrors, invalidJSON)
 		}
 	}
 
@@ -82,7 +102,7 @@ func ValidateRequestSchema(
 	if len(requestBody) == 0 && len(jsonSchema) > 0 {
 
 		line := schema.ParentProxy.GetSchemaKeyNode().Line
-		col := schema.ParentProxy.GetSchemaKeyNode().Line
+		col := schema.ParentProxy.GetSchemaKeyNode().Column
 		if schema.Type != nil {
 			line = schema.GoLow().Type.KeyNode.Line
 			col = schema.GoLow().Type.KeyNode.Column
@@ -99,6 +119,8 @@ func ValidateRequestSchema(
 			ValidationSubType: helpers.Schema,
 			Message: fmt.Sprintf("%s request body is empty for '%s'",
 				request.Method, request.URL.Path),
+			MessageKey:             errors.MessageKeyRequestBodyEmpty,
+			MessageArgs:            map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason:                 "The request body is empty but there is a schema defined",
 			SpecLine:               line,
 			SpecCol:                col,
@@ -110,15 +132,13 @@ func ValidateRequestSchema(
 	}
 
 	// Attempt to compile the JSON schema
-	jsch, err := helpers.NewCompiledSchema("requestBody", jsonSchema, validationOptions)
+	var err error
+	if jsch == nil {
+		jsch, err = helpers.NewCompiledBodySchema("requestBody", jsonSchema, validationOptions)
+	}
 	if err != nil {
-		validationErrors = append(validationErrors, &errors.ValidationError{
-			ValidationType:    helpers.RequestBodyValidation,
-			ValidationSubType: helpers.Schema,
-			Message:           err.Error(),
-			Reason:            "Failed to compile the request body schema.",
-			Context:           string(jsonSchema),
-		})
+		validationErrors = append(validationErrors,
+			errors.SchemaCompilationFailed(schema, helpers.RequestBodyValidation, jsonSchema, err))
 		return false, validationErrors
 	}
 
@@ -129,12 +149,36 @@ func ValidateRequestSchema(
 		jk := scErrs.(*jsonschema.ValidationError)
 
 		// flatten the validationErrors
-		schFlatErrs := jk.BasicOutput().Errors
+		schFlatErrs := helpers.FlattenSchemaErrors(jk, decodedObj)
 		var schemaValidationErrors []*errors.SchemaValidationFailure
+
+		// decode the schema, so the fields that failed can be located.
+		var decodedSchema any
+		_ = json.Unmarshal(jsonSchema, &decodedSchema)
+
+		// polymorphic schemas with a discriminator only report the errors of the branch each object (the body, or
+		// something within it, like the items of an array) intended to match.
+		discriminators := schema_validation.SelectDiscriminatorBranches(schema, decodedObj)
+		for _, discriminator := range discriminators {
+			if discriminator.Mapped() {
+				schFlatErrs = discriminator.FilterErrors(schFlatErrs)
+				continue
+			}
+			schFlatErrs = discriminator.DiscardErrors(schFlatErrs)
+			schemaValidationErrors = append(schemaValidationErrors, &errors.SchemaValidationFailure{
+				Reason:          discriminator.UnmappedReason(),
+				Location:        discriminator.KeywordLocation + "/discriminator",
+				FieldName:       discriminator.PropertyName,
+				FieldPath:       helpers.JSONPathFromSegments(append(discriminator.InstanceSegments(), discriminator.PropertyName)),
+				ReferenceSchema: string(renderedSchema),
+				ReferenceObject: string(requestBody),
+				OriginalError:   jk,
+			})
+		}
 		for q := range schFlatErrs {
 			er := schFlatErrs[q]
 
-			errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+			errMsg := helpers.SchemaErrorMessage(er.Error.Kind)
 
 			if er.KeywordLocation == "" || helpers.IgnoreRegex.MatchString(errMsg) {
 				continue // ignore this error, it's useless tbh, utter noise.
@@ -164,7 +208,10 @@ func ValidateRequestSchema(
 					referenceObject = string(requestBody)
 				}
 
-				errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+				errMsg := helpers.ConditionalReason(er.KeywordLocation, helpers.SchemaErrorMessage(er.Error.Kind))
+				if discriminator := schema_validation.SelectionFor(discriminators, er); discriminator != nil {
+					errMsg = discriminator.PrefixReason(errMsg)
+				}
 
 				violation := &errors.SchemaValidationFailure{
 					Reason:          errMsg,
@@ -189,13 +236,16 @@ func ValidateRequestSchema(
 					violation.Line = line
 					violation.Column = located.Column
 				}
-				schemaValidationErrors = append(schemaValidationErrors, violation)
+				schemaValidationErrors = append(schemaValidationErrors, errors.ExpandSchemaValidationFailure(violation, er, decodedSchema)...)
 			}
 		}
 
+		// composed schemas can report the same failure from more than one branch.
+		schemaValidationErrors = errors.DeduplicateSchemaValidationFailures(schemaValidationErrors)
+
 		line := 1
 		col := 0
-		if schema.GoLow().Type.KeyNode != nil {
+		if schema.GoLow() != nil && schema.GoLow().Type.KeyNode != nil {
 			line = schema.GoLow().Type.KeyNode.Line
 			col = schema.GoLow().Type.KeyNode.Column
 		}
@@ -206,6 +256,8 @@ func ValidateRequestSchema(
 			ValidationSubType: helpers.Schema,
 			Message: fmt.Sprintf("%s request body for '%s' failed to validate schema",
 				request.Method, request.URL.Path),
+			MessageKey:  errors.MessageKeyRequestBodySchemaInvalid,
+			MessageArgs: map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: "The request body is defined as an object. " +
 				"However, it does not meet the schema requirements of the specification",
 			SpecLine:               line,
@@ -3,6 +3,7 @@ package requests
 import (
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestValidateRequestSchema(t *testing.T) {
 		expectedErrorsCount        int
 	}{
 		"FailOnBooleanExclusiveMinimum": {
-			request: postRequestWithBody(`{"exclusiveNumber": 13}`),
+			request: postRequestWithBody(`{"exclusiveNumber": 10}`),
 			schema: &base.Schema{
 				Type: []string{"object"},
 			},
@@ -81,6 +82,7 @@ properties:
 func postRequestWithBody(payload string) *http.Request {
 	return &http.Request{
 		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/burgers"},
 		Body:   io.NopCloser(strings.NewReader(payload)),
 	}
 }
@@ -96,7 +98,7 @@ properties:
 
 	jsonSchema := []byte(`{"properties":{"exclusiveNumber":{"description":"This number starts its journey where most numbers are too scared to begin!","exclusiveMinimum":true,"minimum":10,"type":"number"}},"type":"object"}`)
 
-	valid, errors := ValidateRequestSchema(postRequestWithBody(`{"exclusiveNumber": 13}`), &base.Schema{
+	valid, errors := ValidateRequestSchema(postRequestWithBody(`{"exclusiveNumber": 10}`), &base.Schema{
 		Type: []string{"object"},
 	}, renderedSchema, jsonSchema)
 
@@ -26,7 +26,7 @@ func (v *responseBodyValidator) ValidateResponseBody(
 	request *http.Request,
 	response *http.Response,
 ) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	pathItem, errs, foundPath := paths.FindPathWithOptions(request, v.document, v.options)
 	if len(errs) > 0 {
 		return false, errs
 	}
@@ -39,6 +39,8 @@ func (v *responseBodyValidator) ValidateResponseBodyWithPathItem(request *http.R
 			ValidationType:    helpers.ParameterValidationPath,
 			ValidationSubType: "missing",
 			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			MessageKey:        errors.MessageKeyPathNotFound,
+			MessageArgs:       map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
 				"however that path, or the %s method for that path does not exist in the specification",
 				request.Method, request.URL.Path, request.Method),
@@ -57,62 +59,34 @@ func (v *responseBodyValidator) ValidateResponseBodyWithPathItem(request *http.R
 	contentType := response.Header.Get(helpers.ContentTypeHeader)
 	codeStr := strconv.Itoa(httpCode)
 
-	// extract the media type from the content type header.
-	mediaTypeSting, _, _ := helpers.ExtractContentType(contentType)
-
-	// check if the response code is in the contract
-	foundResponse := operation.Responses.Codes.GetOrZero(codeStr)
-	if foundResponse == nil {
-		// check range definition for response codes
-		foundResponse = operation.Responses.Codes.GetOrZero(fmt.Sprintf("%dXX", httpCode/100))
-		if foundResponse != nil {
-			codeStr = fmt.Sprintf("%dXX", httpCode/100)
-		}
+	// find the response in the contract, by the exact code first, then its range (e.g. '2XX'), then 'default'.
+	foundResponse, responseCode := helpers.FindResponse(operation, httpCode)
+	isDefault := responseCode == "default"
+	if !isDefault && responseCode != "" {
+		codeStr = responseCode
 	}
-
-	if foundResponse != nil {
-		if foundResponse.Content != nil { // only validate if we have content types.
-			// check content type has been defined in the contract
-			if mediaType, ok := foundResponse.Content.Get(mediaTypeSting); ok {
-				validationErrors = append(validationErrors,
-					v.checkResponseSchema(request, response, mediaTypeSting, mediaType)...)
-			} else {
-				// check that the operation *actually* returns a body. (i.e. a 204 response)
-				if foundResponse.Content != nil && orderedmap.Len(foundResponse.Content) > 0 {
-					// content type not found in the contract
-					validationErrors = append(validationErrors,
-						errors.ResponseContentTypeNotFound(operation, request, response, codeStr, false))
-				}
-			}
-		}
-	} else {
-		// no code match, check for default response
-		if operation.Responses.Default != nil && operation.Responses.Default.Content != nil {
-			// check content type has been defined in the contract
-			if mediaType, ok := operation.Responses.Default.Content.Get(mediaTypeSting); ok {
-				foundResponse = operation.Responses.Default
-				validationErrors = append(validationErrors,
-					v.checkResponseSchema(request, response, contentType, mediaType)...)
-			} else {
-				// check that the operation *actually* returns a body. (i.e. a 204 response)
-				if operation.Responses.Default.Content != nil && orderedmap.Len(operation.Responses.Default.Content) > 0 {
-					// content type not found in the contract
-					validationErrors = append(validationErrors,
-						errors.ResponseContentTypeNotFound(operation, request, response, codeStr, true))
-				}
-			}
-		} else {
-			// TODO: add support for '2XX' and '3XX' responses in the contract
-			// no default, no code match, nothing!
+	if foundResponse == nil {
+		// no default, no code match, nothing!
+		validationErrors = append(validationErrors,
+			errors.ResponseCodeNotFound(operation, request, httpCode))
+	} else if foundResponse.Content != nil { // only validate if we have content types.
+		// check content type has been defined in the contract
+		if negotiated, mediaType, ok := findResponseMediaType(request, foundResponse.Content, contentType); ok {
+			validationErrors = append(validationErrors,
+				v.checkResponseSchema(request, response, negotiated, mediaType)...)
+		} else if orderedmap.Len(foundResponse.Content) > 0 {
+			// check that the operation *actually* returns a body. (i.e. a 204 response)
+			// content type not found in the contract
 			validationErrors = append(validationErrors,
-				errors.ResponseCodeNotFound(operation, request, httpCode))
+				errors.ResponseContentTypeNotFound(operation, request, response, codeStr, isDefault))
 		}
 	}
 
 	if foundResponse != nil {
 		// check for headers in the response
 		if foundResponse.Headers != nil {
-			if ok, herrs := ValidateResponseHeaders(request, response, foundResponse.Headers); !ok {
+			if ok, herrs := ValidateResponseHeaders(request, response, foundResponse.Headers,
+				config.WithExistingOpts(v.options)); !ok {
 				validationErrors = append(validationErrors, herrs...)
 			}
 		}
@@ -126,6 +100,58 @@ func (v *responseBodyValidator) ValidateResponseBodyWithPathItem(request *http.R
 	return true, nil
 }
 
+// findResponseMediaType locates the media type in the contract that describes the response. The content type of the
+// response is matched exactly first (case-insensitively, honoring a declared charset), then against any media ranges
+// (like 'application/*') declared in the contract.
+// When more than one media range matches, or the response has no content type at all, the 'Accept' header of the
+// request is used to pick the media type the client negotiated for. The returned string is the content type that
+// should be used to decode the response.
+func findResponseMediaType(
+	request *http.Request,
+	content *orderedmap.Map[string, *v3.MediaType],
+	contentType string,
+) (string, *v3.MediaType, bool) {
+	// extract the media type from the content type header.
+	mediaTypeString, _, _ := helpers.ExtractContentType(contentType)
+	if declared, ok := helpers.MatchMediaType(content.KeysFromOldest(), contentType); ok &&
+		!strings.Contains(declared, helpers.Asterisk) {
+		return mediaTypeString, content.GetOrZero(declared), true
+	}
+
+	// collect every declared media type that could describe the response.
+	var candidates []string
+	for declared := range content.KeysFromOldest() {
+		if mediaTypeString == "" || helpers.MediaTypeMatches(declared, contentType) {
+			candidates = append(candidates, declared)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil, false
+	}
+
+	resolve := func(declared string) (string, *v3.MediaType, bool) {
+		if mediaTypeString != "" {
+			return mediaTypeString, content.GetOrZero(declared), true
+		}
+		return declared, content.GetOrZero(declared), true
+	}
+
+	// prefer the media type the client asked for, in the order of preference the client stated.
+	for _, accepted := range helpers.ExtractAcceptedMediaTypes(request.Header.Get(helpers.AcceptHeader)) {
+		for _, declared := range candidates {
+			if helpers.MediaTypeMatchesRange(accepted, declared) || helpers.MediaTypeMatchesRange(declared, accepted) {
+				return resolve(declared)
+			}
+		}
+	}
+
+	// without a content type on the response, there is nothing left to negotiate with.
+	if mediaTypeString == "" {
+		return "", nil, false
+	}
+	return resolve(candidates[0])
+}
+
 func (v *responseBodyValidator) checkResponseSchema(
 	request *http.Request,
 	response *http.Response,
@@ -134,10 +160,10 @@ func (v *responseBodyValidator) checkResponseSchema(
 ) []*errors.ValidationError {
 	var validationErrors []*errors.ValidationError
 
-	// currently, we can only validate JSON based responses, so check for the presence
-	// of 'json' in the content type (what ever it may be) so we can perform a schema check on it.
+	// currently, we can only validate JSON based responses, so check the content type is 'json'
+	// or has a '+json' suffix (like 'application/vnd.api+json') so we can perform a schema check on it.
 	// anything other than JSON, will be ignored.
-	if strings.Contains(strings.ToLower(contentType), helpers.JSONType) {
+	if helpers.IsJSONMediaType(contentType) {
 		// extract schema from media type
 		if mediaType.Schema != nil {
 
@@ -164,6 +190,8 @@ func (v *responseBodyValidator) checkResponseSchema(
 					validationErrors = append(validationErrors, &errors.ValidationError{
 						Reason:            mErr.Error(),
 						Message:           fmt.Sprintf("unable to marshal schema for %s", contentType),
+						MessageKey:        errors.MessageKeyResponseSchemaRenderFailed,
+						MessageArgs:       map[string]any{"contentType": contentType},
 						ValidationType:    helpers.ResponseBodyValidation,
 						ValidationSubType: helpers.Schema,
 						SpecLine:          mediaType.Schema.GetSchemaKeyNode().Line,
@@ -174,6 +202,9 @@ func (v *responseBodyValidator) checkResponseSchema(
 					})
 				} else {
 					schema = schemaP.Schema()
+					if node, ok := marshalled.(*yaml.Node); ok {
+						helpers.RestoreSchemaKeywords(schema, node)
+					}
 					renderedInline, _ = yaml.Marshal(marshalled)
 					renderedJSON, _ = utils.ConvertYAMLtoJSON(renderedInline)
 					v.schemaCache.Store(hash, &schemaCache{
@@ -186,7 +217,8 @@ func (v *responseBodyValidator) checkResponseSchema(
 
 			if len(renderedInline) > 0 && len(renderedJSON) > 0 && schema != nil {
 				// render the schema, to be used for validation
-				valid, vErrs := ValidateResponseSchema(request, response, schema, renderedInline, renderedJSON, config.WithRegexEngine(v.options.RegexEngine))
+				valid, vErrs := ValidateResponseSchema(request, response, schema, renderedInline, renderedJSON,
+					config.WithExistingOpts(v.options))
 				if !valid {
 					validationErrors = append(validationErrors, vErrs...)
 				}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -15,7 +16,9 @@ import (
 
 	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 )
@@ -83,6 +86,69 @@ paths:
 	assert.Equal(t, "/burgers/createBurger", errors[0].SpecPath)
 }
 
+func TestValidateBody_RangeResponseCode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        2XX:
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+        default:
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [error]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+
+	// a 200 is validated against '2XX', not 'default'.
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{helpers.ContentTypeHeader: {helpers.JSONContentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"name":"Big Mac"}`)),
+	}
+	valid, errors := v.ValidateResponseBody(request, response)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	response = &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{helpers.ContentTypeHeader: {helpers.JSONContentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"patties":2}`)),
+	}
+	valid, errors = v.ValidateResponseBody(request, response)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+
+	// anything outside of the range falls back to 'default'.
+	response = &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Header:     http.Header{helpers.ContentTypeHeader: {helpers.JSONContentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"name":"Big Mac"}`)),
+	}
+	valid, errors = v.ValidateResponseBody(request, response)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'error'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
 func TestValidateBody_MissingContentType4XX(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -1425,3 +1491,346 @@ func (er *errorReader) Read(p []byte) (n int, err error) {
 func (er *errorReader) Close() error {
 	return nil
 }
+
+func TestValidateBody_NegotiatedContentType_FromAccept(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/xml:
+              schema:
+                type: string
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+                  patties:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{
+		"name":    "Big Mac",
+		"patties": false,
+	})
+
+	// build a request
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+	request.Header.Set(helpers.AcceptHeader, "text/html;q=0.9, application/json")
+
+	// simulate a request/response
+	res := httptest.NewRecorder()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(bodyBytes)
+	}
+
+	// fire the request
+	handler(res, request)
+
+	// record response, the server did not declare a content type.
+	response := res.Result()
+	response.Header.Del(helpers.ContentTypeHeader)
+
+	// validate!
+	valid, errors := v.ValidateResponseBody(request, response)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "200 response body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+}
+
+func TestValidateBody_NegotiatedContentType_NoAccept(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	// build a request
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+
+	// simulate a request/response
+	res := httptest.NewRecorder()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}
+
+	// fire the request
+	handler(res, request)
+
+	// record response, the server did not declare a content type.
+	response := res.Result()
+	response.Header.Del(helpers.ContentTypeHeader)
+
+	// validate!
+	valid, errors := v.ValidateResponseBody(request, response)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "POST / 200 operation response content type '' does not exist", errors[0].Message)
+}
+
+func TestValidateBody_NegotiatedContentType_MediaRange(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            text/*:
+              schema:
+                type: string
+            application/*:
+              schema:
+                type: object
+                properties:
+                  patties:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	// build a request
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+
+	// simulate a request/response
+	res := httptest.NewRecorder()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(helpers.ContentTypeHeader, "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"patties": "two"}`))
+	}
+
+	// fire the request
+	handler(res, request)
+
+	// record response
+	response := res.Result()
+
+	// validate!
+	valid, errors := v.ValidateResponseBody(request, response)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "200 response body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+}
+
+func TestValidateBody_ContentTypeCaseInsensitive(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/*:
+              schema:
+                type: string
+            application/json:
+              schema:
+                type: object
+                properties:
+                  patties:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{helpers.ContentTypeHeader: []string{"Application/JSON; charset=UTF-8"}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"patties": "two"}`)),
+	}
+
+	// the exact media type is used rather than the media range, despite the case of the content type.
+	valid, errors := v.ValidateResponseBody(request, response)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got string, want integer", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+type failingReader struct {
+	data []byte
+	read bool
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.read {
+		return 0, errors.New("connection reset")
+	}
+	f.read = true
+	return copy(p, f.data), nil
+}
+
+func (f *failingReader) Close() error {
+	return nil
+}
+
+func TestValidateBody_ResponseBodyCanBeReadAfterValidation(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        default:
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+
+	payload := `{"name": "Big Mac"}`
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{helpers.ContentTypeHeader: []string{helpers.JSONContentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(payload)),
+	}
+
+	valid, errs := v.ValidateResponseBody(request, response)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	read, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, string(read))
+
+	// a body that fails part way through is still restored with whatever could be read.
+	response.Body = &failingReader{data: []byte(payload)}
+	valid, errs = v.ValidateResponseBody(request, response)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+
+	read, err = io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, string(read))
+
+	// a nil body is reported as missing, rather than causing a panic.
+	response.Body = nil
+	valid, errs = v.ValidateResponseBody(request, response)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+}
+
+func TestValidateBody_IgnoreAdditionalProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties: false
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	validate := func(v ResponseBodyValidator) (bool, int) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+		res := httptest.NewRecorder()
+		res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.WriteString(`{"name": "Big Mac", "calories": 550}`)
+		valid, errs := v.ValidateResponseBody(request, res.Result())
+		return valid, len(errs)
+	}
+
+	valid, count := validate(NewResponseBodyValidator(&m.Model))
+	assert.False(t, valid)
+	assert.Equal(t, 1, count)
+
+	valid, count = validate(NewResponseBodyValidator(&m.Model, config.WithIgnoreAdditionalProperties(true)))
+	assert.True(t, valid)
+	assert.Equal(t, 0, count)
+}
+
+func TestValidateBody_MissingRequiredNestedFields(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name, patties]
+                properties:
+                  name:
+                    type: string
+                  patties:
+                    type: array
+                    items:
+                      type: object
+                      required: [weight]
+                      properties:
+                        weight:
+                          type: number
+                          minimum: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.WriteString(`{"patties": [{"weight": 0}, {}]}`)
+
+	valid, errors := v.ValidateResponseBody(request, res.Result())
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	failures := map[string]string{}
+	for _, failure := range errors[0].SchemaValidationErrors {
+		failures[failure.FieldPath] = failure.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"$.name":              "missing property 'name'",
+		"$.patties[0].weight": "minimum: got 0, want 1",
+		"$.patties[1].weight": "missing property 'weight'",
+	}, failures)
+}
@@ -6,10 +6,13 @@ package responses
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/parameters"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -18,7 +21,9 @@ import (
 	"github.com/pb33f/libopenapi-validator/errors"
 )
 
-// ValidateResponseHeaders validates the response headers against the OpenAPI spec.
+// ValidateResponseHeaders validates the response headers against the OpenAPI spec. Declared headers are looked up in
+// the trailers of the response as well (as sent by streaming and gRPC endpoints), trailers are only received once the
+// body has been read, so the body must be read (or validated) first.
 func ValidateResponseHeaders(
 	request *http.Request,
 	response *http.Response,
@@ -35,15 +40,22 @@ func ValidateResponseHeaders(
 	}
 	locatedHeaders := make(map[string]headerPair)
 	var validationErrors []*errors.ValidationError
-	// iterate through the response headers
-	for name, v := range response.Header {
-		// check if the model is in the spec
-		for k, header := range headers.FromOldest() {
-			if strings.EqualFold(k, name) {
-				locatedHeaders[strings.ToLower(name)] = headerPair{
-					name:  k,
-					value: v,
-					model: header,
+	// iterate through the response headers, and then the trailers.
+	for _, fields := range []http.Header{response.Header, response.Trailer} {
+		for name, v := range fields {
+			// a trailer that was announced, but has not been received.
+			if len(v) == 0 {
+				continue
+			}
+			// check if the model is in the spec
+			for k, header := range headers.FromOldest() {
+				if strings.EqualFold(k, name) {
+					located := locatedHeaders[strings.ToLower(name)]
+					locatedHeaders[strings.ToLower(name)] = headerPair{
+						name:  k,
+						value: slices.Concat(located.value, v),
+						model: header,
+					}
 				}
 			}
 		}
@@ -57,6 +69,8 @@ func ValidateResponseHeaders(
 					ValidationType:    helpers.ResponseBodyValidation,
 					ValidationSubType: helpers.ParameterValidationHeader,
 					Message:           "Missing required header",
+					MessageKey:        errors.MessageKeyResponseHeaderMissing,
+					MessageArgs:       map[string]any{"name": name},
 					Reason:            fmt.Sprintf("Required header '%s' was not found in response", name),
 					SpecLine:          header.GoLow().KeyNode.Line,
 					SpecCol:           header.GoLow().KeyNode.Column,
@@ -68,15 +82,15 @@ func ValidateResponseHeaders(
 		}
 	}
 
-	// validate the model schemas if they are set.
-	for h, header := range locatedHeaders {
+	// validate the value of every header that is present against its schema (if set), required or not.
+	for _, header := range locatedHeaders {
 		if header.model.Schema != nil {
 			schema := header.model.Schema.Schema()
-			if schema != nil && header.model.Required {
+			if schema != nil {
 				for _, headerValue := range header.value {
 					validationErrors = append(validationErrors,
-						parameters.ValidateParameterSchema(schema, nil, headerValue, "header",
-							"response header", h, helpers.ResponseBodyValidation, lowv3.HeadersLabel, options)...)
+						parameters.ValidateSingleParameterSchema(schema, decodeHeaderValue(schema, headerValue), "header",
+							"response header", header.name, helpers.ResponseBodyValidation, lowv3.HeadersLabel, options)...)
 				}
 			}
 		}
@@ -86,3 +100,34 @@ func ValidateResponseHeaders(
 	}
 	return false, validationErrors
 }
+
+// decodeHeaderValue decodes the value of a header into the type declared by its schema, headers use the 'simple'
+// style, so an array is a comma separated list of values. If the value cannot be decoded (or the schema does not
+// declare a type), the value is left as a string, so the schema reports it.
+func decodeHeaderValue(schema *base.Schema, value string) any {
+	if schema == nil || len(schema.Type) == 0 || slices.Contains(schema.Type, helpers.String) {
+		return value
+	}
+	switch {
+	case slices.Contains(schema.Type, helpers.Integer), slices.Contains(schema.Type, helpers.Number):
+		if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return n
+		}
+	case slices.Contains(schema.Type, helpers.Boolean):
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return b
+		}
+	case slices.Contains(schema.Type, helpers.Array):
+		var itemSchema *base.Schema
+		if schema.Items != nil && schema.Items.IsA() {
+			itemSchema = schema.Items.A.Schema()
+		}
+		items := strings.Split(value, helpers.Comma)
+		decoded := make([]any, len(items))
+		for i, item := range items {
+			decoded[i] = decodeHeaderValue(itemSchema, strings.TrimSpace(item))
+		}
+		return decoded
+	}
+	return value
+}
@@ -4,12 +4,17 @@
 package responses
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
 )
 
 func TestValidateResponseHeaders(t *testing.T) {
@@ -130,3 +135,172 @@ paths:
 	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 }
+
+func TestValidateResponseHeaders_OptionalHeaders(t *testing.T) {
+	spec := `openapi: "3.1.0"
+info:
+  title: Burgers
+  version: '0.1.0'
+paths:
+  /burgers:
+    get:
+      responses:
+        '206':
+          description: some of the burgers
+          headers:
+            Content-Range:
+              schema:
+                type: string
+                pattern: '^items \d+-\d+/\d+$'
+            X-Request-Id:
+              schema:
+                type: string
+            X-Rate-Limit:
+              schema:
+                type: integer
+                maximum: 100
+            X-Toppings:
+              schema:
+                type: array
+                items:
+                  type: string
+                  enum: [cheese, pickles]
+            X-Cached:
+              schema:
+                type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	headers := m.Model.Paths.PathItems.GetOrZero("/burgers").Get.Responses.Codes.GetOrZero("206").Headers
+
+	response := &http.Response{StatusCode: http.StatusPartialContent, Header: http.Header{}}
+	response.Header.Set("Content-Range", "items 0-9/100")
+	response.Header.Set("X-Request-Id", "12345")
+	response.Header.Set("X-Rate-Limit", "50")
+	response.Header.Set("X-Toppings", "cheese, pickles")
+	response.Header.Set("X-Cached", "true")
+
+	valid, errors := ValidateResponseHeaders(request, response, headers)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// none of the headers are required, but they are still validated when present.
+	response.Header.Set("Content-Range", "bytes 0-9/100")
+	response.Header.Set("X-Rate-Limit", "500")
+	response.Header.Set("X-Toppings", "cheese,onions")
+	response.Header.Set("X-Cached", "maybe")
+
+	valid, errors = ValidateResponseHeaders(request, response, headers)
+	assert.False(t, valid)
+	require.Len(t, errors, 4)
+
+	var reasons []string
+	for _, e := range errors {
+		for _, sve := range e.SchemaValidationErrors {
+			reasons = append(reasons, sve.Reason)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		`value does not match pattern '^items \d+-\d+/\d+$'`,
+		"maximum: got 500, want 100",
+		"value must be one of 'cheese', 'pickles'",
+		"got string, want boolean",
+	}, reasons)
+}
+
+func TestValidateResponseBody_Headers(t *testing.T) {
+	spec := `openapi: "3.1.0"
+info:
+  title: Burgers
+  version: '0.1.0'
+paths:
+  /burgers:
+    get:
+      responses:
+        default:
+          description: any response
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+	}
+	response.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	response.Header.Set("X-Rate-Limit", "lots")
+
+	valid, errors := v.ValidateResponseBody(request, response)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "header 'X-Rate-Limit' failed to validate", errors[0].Message)
+}
+
+func TestValidateResponseBody_Trailers(t *testing.T) {
+	spec := `openapi: "3.1.0"
+info:
+  title: Burgers
+  version: '0.1.0'
+paths:
+  /burgers/stream:
+    get:
+      responses:
+        '200':
+          description: a stream of burgers
+          headers:
+            Grpc-Status:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/stream", nil)
+	stream := func(status string) *http.Response {
+		res := httptest.NewRecorder()
+		res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		res.Header().Set("Trailer", "Grpc-Status")
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.WriteString(`{}`)
+		if status != "" {
+			res.Header().Set("Grpc-Status", status)
+		}
+		return res.Result()
+	}
+
+	// the required header is sent as a trailer.
+	valid, errors := v.ValidateResponseBody(request, stream("0"))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// trailers are validated against their schema.
+	valid, errors = v.ValidateResponseBody(request, stream("ok"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "header 'Grpc-Status' failed to validate", errors[0].Message)
+
+	// a trailer that was announced but never sent is missing.
+	valid, errors = v.ValidateResponseBody(request, stream(""))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Missing required header", errors[0].Message)
+}
@@ -15,8 +15,6 @@ import (
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"gopkg.in/yaml.v3"
 
 	"github.com/pb33f/libopenapi-validator/config"
@@ -44,7 +42,7 @@ func ValidateResponseSchema(
 
 	var validationErrors []*errors.ValidationError
 
-	if response == nil || response.Body == http.NoBody {
+	if response == nil || response.Body == nil || response.Body == http.NoBody {
 		// cannot decode the response body, so it's not valid
 		violation := &errors.SchemaValidationFailure{
 			Reason:          "response is empty",
@@ -56,6 +54,8 @@ func ValidateResponseSchema(
 			ValidationSubType: "object",
 			Message: fmt.Sprintf("%s response object is missing for '%s'",
 				request.Method, request.URL.Path),
+			MessageKey:             errors.MessageKeyResponseMissing,
+			MessageArgs:            map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason:                 "The response object is completely missing",
 			SpecLine:               1,
 			SpecCol:                0,
@@ -67,6 +67,11 @@ func ValidateResponseSchema(
 	}
 
 	responseBody, ioErr := io.ReadAll(response.Body)
+
+	// close the response body, and replace it with what was read, so it can be re-read later by another player in the chain
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
 	if ioErr != nil {
 		// cannot decode the response body, so it's not valid
 		violation := &errors.SchemaValidationFailure{
@@ -80,6 +85,8 @@ func ValidateResponseSchema(
 			ValidationSubType: helpers.Schema,
 			Message: fmt.Sprintf("%s response body for '%s' cannot be read, it's empty or malformed",
 				request.Method, request.URL.Path),
+			MessageKey:             errors.MessageKeyResponseBodyUnreadable,
+			MessageArgs:            map[string]any{"method": request.Method, "path": request.URL.Path},
 			Reason:                 fmt.Sprintf("The response body cannot be decoded: %s", ioErr.Error()),
 			SpecLine:               1,
 			SpecCol:                0,
@@ -90,10 +97,6 @@ func ValidateResponseSchema(
 		return false, validationErrors
 	}
 
-	// close the request body, so it can be re-read later by another player in the chain
-	_ = response.Body.Close()
-	response.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-
 	var decodedObj interface{}
 
 	if len(responseBody) > 0 {
@@ -111,6 +114,8 @@ func ValidateResponseSchema(
 				ValidationSubType: helpers.Schema,
 				Message: fmt.Sprintf("%s response body for '%s' failed to validate schema",
 					request.Method, request.URL.Path),
+				MessageKey:             errors.MessageKeyResponseBodySchemaInvalid,
+				MessageArgs:            map[string]any{"method": request.Method, "code": response.StatusCode, "path": request.URL.Path},
 				Reason:                 fmt.Sprintf("The response body cannot be decoded: %s", err.Error()),
 				SpecLine:               1,
 				SpecCol:                0,
@@ -128,7 +133,12 @@ func ValidateResponseSchema(
 	}
 
 	// create a new jsonschema compiler and add in the rendered JSON schema.
-	jsch, _ := helpers.NewCompiledSchema(helpers.ResponseBodyValidation, jsonSchema, options)
+	jsch, err := helpers.NewCompiledBodySchema(helpers.ResponseBodyValidation, jsonSchema, options)
+	if err != nil {
+		validationErrors = append(validationErrors,
+			errors.SchemaCompilationFailed(schema, helpers.ResponseBodyValidation, jsonSchema, err))
+		return false, validationErrors
+	}
 
 	// validate the object against the schema
 	scErrs := jsch.Validate(decodedObj)
@@ -136,12 +146,16 @@ func ValidateResponseSchema(
 		jk := scErrs.(*jsonschema.ValidationError)
 
 		// flatten the validationErrors
-		schFlatErrs := jk.BasicOutput().Errors
+		schFlatErrs := helpers.FlattenSchemaErrors(jk, decodedObj)
 		var schemaValidationErrors []*errors.SchemaValidationFailure
+
+		// decode the schema, so the fields that failed can be located.
+		var decodedSchema any
+		_ = json.Unmarshal(jsonSchema, &decodedSchema)
 		for q := range schFlatErrs {
 			er := schFlatErrs[q]
 
-			errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+			errMsg := helpers.SchemaErrorMessage(er.Error.Kind)
 			if er.KeywordLocation == "" || helpers.IgnoreRegex.MatchString(errMsg) {
 				continue // ignore this error, it's useless tbh, utter noise.
 			}
@@ -171,7 +185,7 @@ func ValidateResponseSchema(
 				}
 
 				violation := &errors.SchemaValidationFailure{
-					Reason:          errMsg,
+					Reason:          helpers.ConditionalReason(er.KeywordLocation, errMsg),
 					Location:        er.KeywordLocation,
 					ReferenceSchema: string(renderedSchema),
 					ReferenceObject: referenceObject,
@@ -193,10 +207,13 @@ func ValidateResponseSchema(
 					violation.Line = line
 					violation.Column = located.Column
 				}
-				schemaValidationErrors = append(schemaValidationErrors, violation)
+				schemaValidationErrors = append(schemaValidationErrors, errors.ExpandSchemaValidationFailure(violation, er, decodedSchema)...)
 			}
 		}
 
+		// composed schemas can report the same failure from more than one branch.
+		schemaValidationErrors = errors.DeduplicateSchemaValidationFailures(schemaValidationErrors)
+
 		line := 1
 		col := 0
 		if schema.GoLow().Type.KeyNode != nil {
@@ -210,6 +227,8 @@ func ValidateResponseSchema(
 			ValidationSubType: helpers.Schema,
 			Message: fmt.Sprintf("%d response body for '%s' failed to validate schema",
 				response.StatusCode, request.URL.Path),
+			MessageKey:  errors.MessageKeyResponseBodySchemaInvalid,
+			MessageArgs: map[string]any{"method": request.Method, "code": response.StatusCode, "path": request.URL.Path},
 			Reason: fmt.Sprintf("The response body for status code '%d' is defined as an object. "+
 				"However, it does not meet the schema requirements of the specification", response.StatusCode),
 			SpecLine:               line,
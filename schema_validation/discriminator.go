@@ -0,0 +1,233 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// DiscriminatorSelection describes the polymorphic branch (oneOf / anyOf) of a schema that was selected using
+// the schema's discriminator, and the value of the discriminator property that was seen in the object.
+type DiscriminatorSelection struct {
+	// PropertyName is the name of the discriminator property.
+	PropertyName string
+
+	// Value is the value of the discriminator property, as seen in the object.
+	Value string
+
+	// Keyword is the polymorphic keyword the branch belongs to, either 'oneOf' or 'anyOf'.
+	Keyword string
+
+	// Index is the position of the selected branch, or -1 if the value does not map to a known schema.
+	Index int
+
+	// KeywordLocation is the location (a JSON pointer) of the polymorphic schema within the schema that was
+	// validated against, it's empty for the root schema.
+	KeywordLocation string
+
+	// InstanceLocation is the location (a JSON pointer) of the object within the validated value, it's empty for
+	// the root of the value (e.g. '/pets/1' for the second item of a 'pets' array).
+	InstanceLocation string
+}
+
+// SelectDiscriminatorBranch will use the discriminator of a polymorphic schema to select the branch (schema) the
+// decoded object is intended to match. The discriminator mapping is checked first, if the value is not mapped
+// then the value is matched against the name of each referenced schema. If the schema has no discriminator,
+// is not polymorphic, or the object does not carry the discriminator property, nil is returned.
+func SelectDiscriminatorBranch(schema *base.Schema, decodedObject any) *DiscriminatorSelection {
+	if schema == nil || schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+		return nil
+	}
+	keyword, branches := "oneOf", schema.OneOf
+	if len(branches) == 0 {
+		keyword, branches = "anyOf", schema.AnyOf
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+	obj, ok := decodedObject.(map[string]any)
+	if !ok {
+		return nil
+	}
+	raw, ok := obj[schema.Discriminator.PropertyName]
+	if !ok {
+		return nil
+	}
+	value := fmt.Sprint(raw)
+	selection := &DiscriminatorSelection{
+		PropertyName: schema.Discriminator.PropertyName,
+		Value:        value,
+		Keyword:      keyword,
+		Index:        -1,
+	}
+
+	target := value
+	if schema.Discriminator.Mapping != nil {
+		if mapped, found := schema.Discriminator.Mapping.Get(value); found {
+			target = mapped
+		}
+	}
+	for i, branch := range branches {
+		if branch == nil || !branch.IsReference() {
+			continue
+		}
+		ref := branch.GetReference()
+		if ref == target || ref[strings.LastIndex(ref, "/")+1:] == target[strings.LastIndex(target, "/")+1:] {
+			selection.Index = i
+			break
+		}
+	}
+	return selection
+}
+
+// SelectDiscriminatorBranches works the same as SelectDiscriminatorBranch, for every polymorphic schema with a
+// discriminator that applies to the decoded object, not just the root schema. Schemas are followed through
+// 'properties', 'items', 'prefixItems' and the selected branches, so each item of a heterogeneous array has a
+// selection of its own. Selections are returned outermost first.
+func SelectDiscriminatorBranches(schema *base.Schema, decodedObject any) []*DiscriminatorSelection {
+	var selections []*DiscriminatorSelection
+	selectDiscriminatorBranches(schema, decodedObject, "", "", &selections)
+	return selections
+}
+
+func selectDiscriminatorBranches(schema *base.Schema, value any, keywordLocation, instanceLocation string,
+	selections *[]*DiscriminatorSelection,
+) {
+	if schema == nil {
+		return
+	}
+	if selection := SelectDiscriminatorBranch(schema, value); selection != nil {
+		selection.KeywordLocation = keywordLocation
+		selection.InstanceLocation = instanceLocation
+		*selections = append(*selections, selection)
+		if selection.Mapped() {
+			branches := schema.OneOf
+			if selection.Keyword == "anyOf" {
+				branches = schema.AnyOf
+			}
+			selectDiscriminatorBranches(branches[selection.Index].Schema(), value,
+				fmt.Sprintf("%s/%s/%d", keywordLocation, selection.Keyword, selection.Index), instanceLocation, selections)
+		}
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		if schema.Properties == nil {
+			return
+		}
+		for name, property := range schema.Properties.FromOldest() {
+			if child, ok := v[name]; ok {
+				selectDiscriminatorBranches(property.Schema(), child, keywordLocation+"/properties/"+escapePointer(name),
+					instanceLocation+"/"+escapePointer(name), selections)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			itemLocation := fmt.Sprintf("%s/%d", instanceLocation, i)
+			if i < len(schema.PrefixItems) {
+				selectDiscriminatorBranches(schema.PrefixItems[i].Schema(), item,
+					fmt.Sprintf("%s/prefixItems/%d", keywordLocation, i), itemLocation, selections)
+			} else if schema.Items != nil && schema.Items.IsA() {
+				selectDiscriminatorBranches(schema.Items.A.Schema(), item, keywordLocation+"/items", itemLocation, selections)
+			}
+		}
+	}
+}
+
+// InstanceSegments returns the segments of the instance location of the object (e.g. 'pets', '1').
+func (d *DiscriminatorSelection) InstanceSegments() []string {
+	if d.InstanceLocation == "" {
+		return nil
+	}
+	segments := strings.Split(d.InstanceLocation[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+// Applies returns true if an error was reported by the polymorphic keyword of the selection (or one of its
+// branches) for the object of the selection, or something within it.
+func (d *DiscriminatorSelection) Applies(unit jsonschema.OutputUnit) bool {
+	return withinLocation(unit.InstanceLocation, d.InstanceLocation) &&
+		withinLocation(unit.KeywordLocation, d.KeywordLocation+"/"+d.Keyword)
+}
+
+// Selects returns true if an error was reported by the selected branch, for the object of the selection (or
+// something within it).
+func (d *DiscriminatorSelection) Selects(unit jsonschema.OutputUnit) bool {
+	return withinLocation(unit.InstanceLocation, d.InstanceLocation) &&
+		withinLocation(unit.KeywordLocation, fmt.Sprintf("%s/%s/%d", d.KeywordLocation, d.Keyword, d.Index))
+}
+
+// withinLocation returns true if a JSON pointer is the same as, or is below, another.
+func withinLocation(location, parent string) bool {
+	return location == parent || strings.HasPrefix(location, parent+"/")
+}
+
+// DiscardErrors removes every error reported by the polymorphic keyword of the selection (and its branches) for
+// the object of the selection, as used when the discriminator value does not map to a known schema.
+func (d *DiscriminatorSelection) DiscardErrors(units []jsonschema.OutputUnit) []jsonschema.OutputUnit {
+	var remaining []jsonschema.OutputUnit
+	for _, unit := range units {
+		if !d.Applies(unit) {
+			remaining = append(remaining, unit)
+		}
+	}
+	return remaining
+}
+
+// SelectionFor returns the innermost of the selections whose selected branch reported an error, or nil if the error
+// was not reported by a selected branch.
+func SelectionFor(selections []*DiscriminatorSelection, unit jsonschema.OutputUnit) *DiscriminatorSelection {
+	var found *DiscriminatorSelection
+	for _, selection := range selections {
+		if selection.Mapped() && selection.Selects(unit) {
+			found = selection
+		}
+	}
+	return found
+}
+
+// Mapped returns true if the discriminator value maps to a known schema.
+func (d *DiscriminatorSelection) Mapped() bool {
+	return d.Index >= 0
+}
+
+// UnmappedReason returns the reason used when the discriminator value does not map to a known schema.
+func (d *DiscriminatorSelection) UnmappedReason() string {
+	return fmt.Sprintf("discriminator value '%s' does not map to a known schema", d.Value)
+}
+
+// FilterErrors removes the errors reported by every branch other than the selected one (along with the
+// summary error reported by the polymorphic keyword itself), for the object of the selection. Errors that do not
+// belong to any branch, or that were reported for other objects (like other items of an array), are kept.
+// If the selected branch reported no errors of its own, the original errors are returned untouched.
+func (d *DiscriminatorSelection) FilterErrors(units []jsonschema.OutputUnit) []jsonschema.OutputUnit {
+	var filtered []jsonschema.OutputUnit
+	branchErrors := 0
+	for _, unit := range units {
+		switch {
+		case d.Selects(unit):
+			branchErrors++
+			filtered = append(filtered, unit)
+		case d.Applies(unit):
+			continue
+		default:
+			filtered = append(filtered, unit)
+		}
+	}
+	if branchErrors == 0 {
+		return units
+	}
+	return filtered
+}
+
+// PrefixReason prefixes a failure reason with the discriminator value that was seen.
+func (d *DiscriminatorSelection) PrefixReason(reason string) string {
+	return fmt.Sprintf("discriminator '%s' is '%s': %s", d.PropertyName, d.Value, reason)
+}
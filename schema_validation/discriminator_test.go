@@ -0,0 +1,148 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectDiscriminatorBranch(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      anyOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          woof: Dog
+    Cat:
+      type: object
+    Dog:
+      type: object
+    Plain:
+      type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	pet := m.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	plain := m.Model.Components.Schemas.GetOrZero("Plain").Schema()
+
+	assert.Nil(t, SelectDiscriminatorBranch(plain, map[string]any{"petType": "Cat"}))
+	assert.Nil(t, SelectDiscriminatorBranch(pet, []any{}))
+	assert.Nil(t, SelectDiscriminatorBranch(pet, map[string]any{"name": "fluffy"}))
+
+	selection := SelectDiscriminatorBranch(pet, map[string]any{"petType": "Cat"})
+	require.NotNil(t, selection)
+	assert.Equal(t, "anyOf", selection.Keyword)
+	assert.Equal(t, 0, selection.Index)
+
+	selection = SelectDiscriminatorBranch(pet, map[string]any{"petType": "woof"})
+	require.NotNil(t, selection)
+	assert.Equal(t, 1, selection.Index)
+
+	selection = SelectDiscriminatorBranch(pet, map[string]any{"petType": "Lizard"})
+	require.NotNil(t, selection)
+	assert.False(t, selection.Mapped())
+	assert.Equal(t, "discriminator value 'Lizard' does not map to a known schema", selection.UnmappedReason())
+}
+
+func TestSelectDiscriminatorBranches(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Shelter:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+    Cat:
+      type: object
+    Dog:
+      type: object
+      properties:
+        puppy:
+          $ref: '#/components/schemas/Pet'`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	shelter := m.Model.Components.Schemas.GetOrZero("Shelter").Schema()
+
+	selections := SelectDiscriminatorBranches(shelter, map[string]any{"pets": []any{
+		map[string]any{"petType": "Cat"},
+		map[string]any{"name": "no type"},
+		map[string]any{"petType": "Dog", "puppy": map[string]any{"petType": "Lizard"}},
+	}})
+	require.Len(t, selections, 3)
+	assert.Equal(t, "/properties/pets/items", selections[0].KeywordLocation)
+	assert.Equal(t, "/pets/0", selections[0].InstanceLocation)
+	assert.Equal(t, []string{"pets", "0"}, selections[0].InstanceSegments())
+	assert.Equal(t, 0, selections[0].Index)
+
+	assert.Equal(t, "/pets/2", selections[1].InstanceLocation)
+	assert.Equal(t, 1, selections[1].Index)
+
+	// the branch selected for the dog is followed.
+	assert.Equal(t, "/properties/pets/items/oneOf/1/properties/puppy", selections[2].KeywordLocation)
+	assert.Equal(t, "/pets/2/puppy", selections[2].InstanceLocation)
+	assert.False(t, selections[2].Mapped())
+
+	assert.Empty(t, SelectDiscriminatorBranches(shelter, map[string]any{"pets": "none"}))
+}
+
+func TestDiscriminatorSelection_FilterErrors_Items(t *testing.T) {
+	selection := &DiscriminatorSelection{PropertyName: "petType", Value: "Dog", Keyword: "oneOf", Index: 1,
+		KeywordLocation: "/items", InstanceLocation: "/1"}
+	units := []jsonschema.OutputUnit{
+		{KeywordLocation: "/items/oneOf", InstanceLocation: "/1"},
+		{KeywordLocation: "/items/oneOf/0/required", InstanceLocation: "/1"},
+		{KeywordLocation: "/items/oneOf/1/properties/bark/type", InstanceLocation: "/1/bark"},
+		{KeywordLocation: "/items/oneOf/0/required", InstanceLocation: "/10"},
+	}
+	filtered := selection.FilterErrors(units)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "/1/bark", filtered[0].InstanceLocation)
+	assert.Equal(t, "/10", filtered[1].InstanceLocation)
+
+	assert.Same(t, selection, SelectionFor([]*DiscriminatorSelection{selection}, units[2]))
+	assert.Nil(t, SelectionFor([]*DiscriminatorSelection{selection}, units[3]))
+
+	remaining := selection.DiscardErrors(units)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "/10", remaining[0].InstanceLocation)
+}
+
+func TestDiscriminatorSelection_FilterErrors(t *testing.T) {
+	selection := &DiscriminatorSelection{PropertyName: "petType", Value: "Dog", Keyword: "oneOf", Index: 1}
+	units := []jsonschema.OutputUnit{
+		{KeywordLocation: "/oneOf"},
+		{KeywordLocation: "/oneOf/0/required"},
+		{KeywordLocation: "/oneOf/1/properties/bark/type"},
+		{KeywordLocation: "/oneOf/10/required"},
+		{KeywordLocation: "/properties/name/type"},
+	}
+	filtered := selection.FilterErrors(units)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "/oneOf/1/properties/bark/type", filtered[0].KeywordLocation)
+	assert.Equal(t, "/properties/name/type", filtered[1].KeywordLocation)
+
+	// no errors in the selected branch, nothing is filtered.
+	selection.Index = 2
+	assert.Len(t, selection.FilterErrors(units), len(units))
+}
@@ -0,0 +1,30 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"gopkg.in/yaml.v3"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+)
+
+// ValidateDefaults walks the schemas of an OpenAPI 3+ document (including the schemas of parameters, headers and
+// media types) and checks every 'default' value validates against the schema it belongs to. For example, a
+// 'default: ten' on a schema of 'type: integer' is reported, as it would be invalid if it was ever applied.
+func ValidateDefaults(document *v3.Document, opts ...config.Option) (bool, []*liberrors.ValidationError) {
+	walker := &valueWalker{
+		validator:     NewSchemaValidator(opts...),
+		seen:          make(map[*yaml.Node]bool),
+		checkDefaults: true,
+	}
+	walker.walk(document)
+
+	if len(walker.validationErrors) > 0 {
+		return false, walker.validationErrors
+	}
+	return true, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func TestValidateDefaults(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: ten
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: [asc, desc]
+            default: asc
+components:
+  schemas:
+    Burger:
+      type: object
+      properties:
+        patties:
+          type: integer
+          minimum: 1
+          default: 0
+        name:
+          type: string
+          default: big mac`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateDefaults(&m.Model)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+
+	assert.Equal(t, helpers.DocumentValidation, errors[0].ValidationType)
+	assert.Equal(t, helpers.DocumentDefault, errors[0].ValidationSubType)
+	assert.Equal(t, "Default value '#/paths/~1burgers/get/parameters/0/schema/default' does not match its schema",
+		errors[0].Message)
+	assert.Equal(t, "/burgers", errors[0].SpecPath)
+	assert.Equal(t, 10, errors[0].SpecLine)
+
+	assert.Equal(t, "#/components/schemas/Burger/properties/patties/default", errors[1].MessageArgs["location"])
+	assert.Equal(t, 25, errors[1].SpecLine)
+}
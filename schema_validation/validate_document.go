@@ -9,8 +9,6 @@ import (
 
 	"github.com/pb33f/libopenapi"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"gopkg.in/yaml.v3"
 
 	"github.com/pb33f/libopenapi-validator/config"
@@ -23,6 +21,9 @@ import (
 func ValidateOpenAPIDocument(doc libopenapi.Document, opts ...config.Option) (bool, []*liberrors.ValidationError) {
 	options := config.NewValidationOptions(opts...)
 
+	// ignored keywords apply to the schemas in the document, not to the OpenAPI schema it is checked against.
+	options.IgnoredKeywords = nil
+
 	info := doc.GetSpecInfo()
 	loadedSchema := info.APISchema
 	var validationErrors []*liberrors.ValidationError
@@ -42,12 +43,12 @@ func ValidateOpenAPIDocument(doc libopenapi.Document, opts ...config.Option) (bo
 		if errors.As(scErrs, &jk) {
 
 			// flatten the validationErrors
-			schFlatErrs := jk.BasicOutput().Errors
+			schFlatErrs := helpers.FlattenSchemaErrors(jk, decodedDocument)
 
 			for q := range schFlatErrs {
 				er := schFlatErrs[q]
 
-				errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+				errMsg := helpers.SchemaErrorMessage(er.Error.Kind)
 				if er.KeywordLocation == "" || helpers.IgnorePolyRegex.MatchString(errMsg) {
 					continue // ignore this error, it's useless tbh, utter noise.
 				}
@@ -87,8 +88,11 @@ func ValidateOpenAPIDocument(doc libopenapi.Document, opts ...config.Option) (bo
 		validationErrors = append(validationErrors, &liberrors.ValidationError{
 			ValidationType: helpers.Schema,
 			Message:        "Document does not pass validation",
+			MessageKey:     liberrors.MessageKeyDocumentInvalid,
 			Reason: fmt.Sprintf("OpenAPI document is not valid according "+
 				"to the %s specification", info.Version),
+			SpecLine:               1,
+			SpecCol:                0,
 			SchemaValidationErrors: schemaValidationErrors,
 			HowToFix:               liberrors.HowToFixInvalidSchema,
 		})
@@ -0,0 +1,244 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// ValidateExamples walks the schemas, parameters, headers and media types of an OpenAPI 3+ document (paths,
+// operations and components) and checks every 'example' and 'examples' value validates against the schema it
+// belongs to. Each invalid example is reported with a JSON pointer to its location in the document. Like
+// ValidateParameterStyles, this checks the specification, not a request, so it only needs to run once.
+func ValidateExamples(document *v3.Document, opts ...config.Option) (bool, []*liberrors.ValidationError) {
+	walker := &valueWalker{
+		validator:     NewSchemaValidator(opts...),
+		seen:          make(map[*yaml.Node]bool),
+		checkExamples: true,
+	}
+	walker.walk(document)
+
+	if len(walker.validationErrors) > 0 {
+		return false, walker.validationErrors
+	}
+	return true, nil
+}
+
+// walk visits the parameters, headers, media types and schemas of the paths, operations and components of a document.
+func (w *valueWalker) walk(document *v3.Document) {
+	if document.Paths != nil {
+		for pair := orderedmap.First(document.Paths.PathItems); pair != nil; pair = pair.Next() {
+			pathPointer := "#/paths/" + escapePointer(pair.Key())
+			w.parameters(pair.Value().Parameters, pathPointer+"/parameters", pair.Key())
+			for op := orderedmap.First(pair.Value().GetOperations()); op != nil; op = op.Next() {
+				opPointer := pathPointer + "/" + op.Key()
+				w.parameters(op.Value().Parameters, opPointer+"/parameters", pair.Key())
+				if op.Value().RequestBody != nil {
+					w.content(op.Value().RequestBody.Content, opPointer+"/requestBody/content", pair.Key())
+				}
+				if op.Value().Responses != nil {
+					for code := orderedmap.First(op.Value().Responses.Codes); code != nil; code = code.Next() {
+						w.response(code.Value(), opPointer+"/responses/"+code.Key(), pair.Key())
+					}
+					w.response(op.Value().Responses.Default, opPointer+"/responses/default", pair.Key())
+				}
+			}
+		}
+	}
+
+	if document.Components != nil {
+		for pair := orderedmap.First(document.Components.Schemas); pair != nil; pair = pair.Next() {
+			w.schema(pair.Value(), "#/components/schemas/"+escapePointer(pair.Key()), "")
+		}
+		for pair := orderedmap.First(document.Components.Parameters); pair != nil; pair = pair.Next() {
+			param := pair.Value()
+			w.examples(param.Schema, param.Example, param.Examples, param.Content,
+				"#/components/parameters/"+escapePointer(pair.Key()), "")
+		}
+		for pair := orderedmap.First(document.Components.Headers); pair != nil; pair = pair.Next() {
+			header := pair.Value()
+			w.examples(header.Schema, header.Example, header.Examples, header.Content,
+				"#/components/headers/"+escapePointer(pair.Key()), "")
+		}
+		for pair := orderedmap.First(document.Components.RequestBodies); pair != nil; pair = pair.Next() {
+			w.content(pair.Value().Content,
+				"#/components/requestBodies/"+escapePointer(pair.Key())+"/content", "")
+		}
+		for pair := orderedmap.First(document.Components.Responses); pair != nil; pair = pair.Next() {
+			w.response(pair.Value(), "#/components/responses/"+escapePointer(pair.Key()), "")
+		}
+	}
+}
+
+// valueWalker collects the errors for invalid examples, or invalid default values, as it walks a document. Values
+// that are referenced more than once are only validated (and reported) once.
+type valueWalker struct {
+	validator        SchemaValidator
+	seen             map[*yaml.Node]bool
+	checkExamples    bool
+	checkDefaults    bool
+	validationErrors []*liberrors.ValidationError
+}
+
+func (w *valueWalker) parameters(params []*v3.Parameter, pointer, specPath string) {
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+		w.examples(param.Schema, param.Example, param.Examples, param.Content,
+			pointer+"/"+strconv.Itoa(i), specPath)
+	}
+}
+
+func (w *valueWalker) response(response *v3.Response, pointer, specPath string) {
+	if response == nil {
+		return
+	}
+	for pair := orderedmap.First(response.Headers); pair != nil; pair = pair.Next() {
+		header := pair.Value()
+		w.examples(header.Schema, header.Example, header.Examples, header.Content,
+			pointer+"/headers/"+escapePointer(pair.Key()), specPath)
+	}
+	w.content(response.Content, pointer+"/content", specPath)
+}
+
+func (w *valueWalker) content(content *orderedmap.Map[string, *v3.MediaType], pointer, specPath string) {
+	for pair := orderedmap.First(content); pair != nil; pair = pair.Next() {
+		mediaType := pair.Value()
+		w.examples(mediaType.Schema, mediaType.Example, mediaType.Examples, nil,
+			pointer+"/"+escapePointer(pair.Key()), specPath)
+	}
+}
+
+// examples validates the 'example' and 'examples' of a parameter, header or media type against its schema. A
+// parameter or header that uses 'content' instead of a schema, has the examples of the media type checked.
+func (w *valueWalker) examples(proxy *base.SchemaProxy, example *yaml.Node,
+	examples *orderedmap.Map[string, *base.Example], content *orderedmap.Map[string, *v3.MediaType],
+	pointer, specPath string,
+) {
+	w.content(content, pointer+"/content", specPath)
+	if proxy == nil {
+		return
+	}
+	if w.checkExamples {
+		schema := proxy.Schema()
+		w.validate(schema, example, pointer+"/example", specPath, liberrors.ExampleInvalid)
+		for pair := orderedmap.First(examples); pair != nil; pair = pair.Next() {
+			if pair.Value() != nil {
+				w.validate(schema, pair.Value().Value, pointer+"/examples/"+escapePointer(pair.Key())+"/value",
+					specPath, liberrors.ExampleInvalid)
+			}
+		}
+	}
+	// a referenced schema is checked where it's defined.
+	if !proxy.IsReference() {
+		w.schema(proxy, pointer+"/schema", specPath)
+	}
+}
+
+// schema validates the 'example', 'examples' or 'default' of a schema, and of the inline schemas of its properties,
+// items and composition keywords (allOf, anyOf, oneOf, not and so on). Each entry of the (3.1) 'examples' array is
+// validated on its own, the index of an invalid entry is part of its location, and of the message arguments.
+func (w *valueWalker) schema(proxy *base.SchemaProxy, pointer, specPath string) {
+	schema := proxy.Schema()
+	if schema == nil {
+		return
+	}
+	if w.checkExamples {
+		w.validate(schema, schema.Example, pointer+"/example", specPath, liberrors.ExampleInvalid)
+		for i, example := range schema.Examples {
+			if invalid := w.validate(schema, example, pointer+"/examples/"+strconv.Itoa(i), specPath,
+				liberrors.ExampleInvalid); invalid != nil {
+				invalid.MessageArgs["index"] = i
+			}
+		}
+	}
+	if w.checkDefaults {
+		w.validate(schema, schema.Default, pointer+"/default", specPath, liberrors.DefaultInvalid)
+	}
+	for pair := orderedmap.First(schema.Properties); pair != nil; pair = pair.Next() {
+		w.inlineSchema(pair.Value(), pointer+"/properties/"+escapePointer(pair.Key()), specPath)
+	}
+	for pair := orderedmap.First(schema.PatternProperties); pair != nil; pair = pair.Next() {
+		w.inlineSchema(pair.Value(), pointer+"/patternProperties/"+escapePointer(pair.Key()), specPath)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+		w.inlineSchema(schema.AdditionalProperties.A, pointer+"/additionalProperties", specPath)
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		w.inlineSchema(schema.Items.A, pointer+"/items", specPath)
+	}
+	w.inlineSchemas(schema.PrefixItems, pointer+"/prefixItems", specPath)
+	w.inlineSchemas(schema.AllOf, pointer+"/allOf", specPath)
+	w.inlineSchemas(schema.AnyOf, pointer+"/anyOf", specPath)
+	w.inlineSchemas(schema.OneOf, pointer+"/oneOf", specPath)
+	w.inlineSchema(schema.Not, pointer+"/not", specPath)
+}
+
+// inlineSchema walks a schema that is declared inline, a referenced schema is checked where it's defined.
+func (w *valueWalker) inlineSchema(proxy *base.SchemaProxy, pointer, specPath string) {
+	if proxy != nil && !proxy.IsReference() {
+		w.schema(proxy, pointer, specPath)
+	}
+}
+
+func (w *valueWalker) inlineSchemas(proxies []*base.SchemaProxy, pointer, specPath string) {
+	for i, proxy := range proxies {
+		w.inlineSchema(proxy, pointer+"/"+strconv.Itoa(i), specPath)
+	}
+}
+
+// validate checks a value against a schema, if it's invalid, the error made by invalid is collected and returned.
+func (w *valueWalker) validate(schema *base.Schema, value *yaml.Node, pointer, specPath string,
+	invalid func(location, specPath string, value *yaml.Node, schemaErrors []*liberrors.SchemaValidationFailure) *liberrors.ValidationError,
+) *liberrors.ValidationError {
+	if schema == nil || value == nil || w.seen[value] {
+		return nil
+	}
+	w.seen[value] = true
+
+	var decoded any
+	if value.Decode(&decoded) != nil {
+		return nil
+	}
+	// the value is converted to JSON, so it's decoded into the same types as a request or response body.
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return nil
+	}
+	valid, schemaErrors := w.validator.ValidateSchemaBytes(schema, encoded)
+	if valid {
+		return nil
+	}
+	var failures []*liberrors.SchemaValidationFailure
+	for _, schemaError := range schemaErrors {
+		// a schema that cannot be compiled is a problem with the schema, not with the value.
+		if schemaError.ValidationType == helpers.Schema {
+			failures = append(failures, schemaError.SchemaValidationErrors...)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	validationError := invalid(pointer, specPath, value, failures)
+	w.validationErrors = append(w.validationErrors, validationError)
+	return validationError
+}
+
+// escapePointer escapes a reference token of a JSON pointer, as defined by RFC 6901.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,143 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func TestValidateExamples(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          example: big-mac
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Burger'
+              examples:
+                good:
+                  value:
+                    name: whopper
+                    patties: 2
+                bad:
+                  value:
+                    patties: two
+components:
+  schemas:
+    Burger:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          examples: [big mac, 3]
+        patties:
+          type: integer
+      example:
+        name: quarter pounder
+        patties: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateExamples(&m.Model)
+	assert.False(t, valid)
+	require.Len(t, errors, 3)
+
+	assert.Equal(t, helpers.DocumentValidation, errors[0].ValidationType)
+	assert.Equal(t, helpers.DocumentExample, errors[0].ValidationSubType)
+	assert.Equal(t, "Example '#/paths/~1burgers~1{burgerId}/get/parameters/0/example' does not match its schema",
+		errors[0].Message)
+	assert.Equal(t, "/burgers/{burgerId}", errors[0].SpecPath)
+	assert.Equal(t, 9, errors[0].SpecLine)
+
+	assert.Equal(t, "#/paths/~1burgers~1{burgerId}/get/responses/200/content/application~1json/examples/bad/value",
+		errors[1].MessageArgs["location"])
+	require.Len(t, errors[1].SchemaValidationErrors, 2)
+
+	assert.Equal(t, "#/components/schemas/Burger/properties/name/examples/1", errors[2].MessageArgs["location"])
+	assert.Empty(t, errors[2].SpecPath)
+
+	// a document with valid examples passes.
+	doc, _ = libopenapi.NewDocument([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      type: object
+      properties:
+        name:
+          type: string
+          example: big mac`))
+	m, _ = doc.BuildV3Model()
+
+	valid, errors = ValidateExamples(&m.Model)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateExamples_SchemaExamplesArray(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+      examples:
+        - name: fido
+        - name: 3
+        - age: 2
+    Order:
+      allOf:
+        - type: object
+          properties:
+            quantity:
+              type: integer
+              minimum: 1
+              examples: [1, 0]
+      additionalProperties:
+        type: string
+        examples: [ok, false]
+    Tags:
+      type: array
+      prefixItems:
+        - type: string
+          examples: [first, 1]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateExamples(&m.Model)
+	assert.False(t, valid)
+	require.Len(t, errors, 5)
+
+	assert.Equal(t, "Example '#/components/schemas/Pet/examples/1' does not match its schema", errors[0].Message)
+	assert.Equal(t, 1, errors[0].MessageArgs["index"])
+	assert.Equal(t, 12, errors[0].SpecLine)
+	assert.Equal(t, "#/components/schemas/Pet/examples/2", errors[1].MessageArgs["location"])
+	assert.Equal(t, 2, errors[1].MessageArgs["index"])
+	assert.Equal(t, "#/components/schemas/Order/additionalProperties/examples/1", errors[2].MessageArgs["location"])
+	assert.Equal(t, "#/components/schemas/Order/allOf/0/properties/quantity/examples/1", errors[3].MessageArgs["location"])
+	assert.Equal(t, 1, errors[3].MessageArgs["index"])
+	assert.Equal(t, "#/components/schemas/Tags/prefixItems/0/examples/1", errors[4].MessageArgs["location"])
+}
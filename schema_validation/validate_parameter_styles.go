@@ -0,0 +1,73 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// ValidateParameterStyles walks every parameter defined in an OpenAPI 3+ document (paths, operations and
+// components) and checks the 'style' is legal for the location ('in') of the parameter. For example, 'deepObject'
+// can only be used with 'in: query' and 'matrix' can only be used with 'in: path'. Unlike request validation,
+// this only needs to run once, as it checks the specification, not a request. A style that is not defined by the
+// specification at all (e.g. 'style: weird') is reported as unsupported.
+func ValidateParameterStyles(document *v3.Document) (bool, []*liberrors.ValidationError) {
+	var validationErrors []*liberrors.ValidationError
+	// parameters that are referenced more than once are only reported once.
+	seen := make(map[*yaml.Node]bool)
+
+	check := func(params []*v3.Parameter, specPath string) {
+		for _, param := range params {
+			if param == nil || param.Style == "" || seen[param.GoLow().Style.KeyNode] {
+				continue
+			}
+			seen[param.GoLow().Style.KeyNode] = true
+			allowed := helpers.AllowedParameterStyles(param.In)
+			if allowed == nil || containsStyle(allowed, param.Style) {
+				continue
+			}
+			if !helpers.IsDefinedStyle(param.Style) {
+				validationErrors = append(validationErrors, liberrors.ParameterStyleUnsupported(param, specPath, allowed))
+				continue
+			}
+			validationErrors = append(validationErrors, liberrors.ParameterStyleNotAllowed(param, specPath, allowed))
+		}
+	}
+
+	if document.Paths != nil {
+		for pair := orderedmap.First(document.Paths.PathItems); pair != nil; pair = pair.Next() {
+			check(pair.Value().Parameters, pair.Key())
+			for op := orderedmap.First(pair.Value().GetOperations()); op != nil; op = op.Next() {
+				check(op.Value().Parameters, pair.Key())
+			}
+		}
+	}
+	if document.Components != nil {
+		var params []*v3.Parameter
+		for pair := orderedmap.First(document.Components.Parameters); pair != nil; pair = pair.Next() {
+			params = append(params, pair.Value())
+		}
+		check(params, "")
+	}
+
+	if len(validationErrors) > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
+
+func containsStyle(styles []string, style string) bool {
+	for _, s := range styles {
+		if s == style {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func TestValidateParameterStyles(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        style: deepObject
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: filter
+          in: query
+          style: matrix
+          schema:
+            type: string
+        - name: sort
+          in: query
+          style: deepObject
+          schema:
+            type: object
+        - $ref: '#/components/parameters/Ids'
+    post:
+      parameters:
+        - $ref: '#/components/parameters/Ids'
+components:
+  parameters:
+    Ids:
+      name: X-Ids
+      in: header
+      style: form
+      schema:
+        type: array
+        items:
+          type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateParameterStyles(&m.Model)
+	assert.False(t, valid)
+	require.Len(t, errors, 3)
+
+	assert.Equal(t, helpers.DocumentValidation, errors[0].ValidationType)
+	assert.Equal(t, helpers.DocumentParameterStyle, errors[0].ValidationSubType)
+	assert.Equal(t, "Parameter 'burgerId' uses the 'deepObject' style, which is not allowed for 'path' parameters",
+		errors[0].Message)
+	assert.Equal(t, "/burgers/{burgerId}", errors[0].SpecPath)
+	assert.Equal(t, 8, errors[0].SpecLine)
+	assert.Equal(t, 9, errors[0].SpecCol)
+
+	assert.Equal(t, "Parameter 'filter' uses the 'matrix' style, which is not allowed for 'query' parameters",
+		errors[1].Message)
+	assert.Contains(t, errors[1].Reason, "'form', 'spaceDelimited', 'pipeDelimited', 'deepObject'")
+
+	assert.Equal(t, "Parameter 'X-Ids' uses the 'form' style, which is not allowed for 'header' parameters",
+		errors[2].Message)
+}
+
+func TestValidateParameterStyles_Unsupported(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: x
+          in: query
+          style: weird
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateParameterStyles(&m.Model)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.DocumentParameterStyle, errors[0].ValidationSubType)
+	assert.Equal(t, "Unsupported style 'weird' for query parameter 'x'", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, "not a style defined by the OpenAPI specification")
+	assert.Equal(t, "/burgers", errors[0].SpecPath)
+	assert.Equal(t, 8, errors[0].SpecLine)
+}
+
+func TestValidateParameterStyles_Valid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          style: label
+          schema:
+            type: string
+        - name: sort
+          in: query
+          style: deepObject
+          schema:
+            type: object
+        - name: session
+          in: cookie
+          style: form
+          schema:
+            type: string
+        - name: X-Ids
+          in: header
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateParameterStyles(&m.Model)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
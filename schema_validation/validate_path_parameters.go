@@ -0,0 +1,82 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// ValidatePathParameters cross-references the path parameters declared in an OpenAPI 3+ document with the
+// placeholders (e.g. '{burgerId}') of the paths. A path parameter without a placeholder, or a placeholder that is not
+// declared by an operation (or the path it belongs to) is reported, as is a path parameter that is not marked as
+// 'required: true'. Like ValidateParameterStyles, this checks the specification, so it only needs to run once.
+func ValidatePathParameters(document *v3.Document) (bool, []*liberrors.ValidationError) {
+	var validationErrors []*liberrors.ValidationError
+	// parameters that are referenced more than once are only reported as not required once.
+	seen := make(map[*yaml.Node]bool)
+
+	// check reports the path parameters that are not required, or have no placeholder, and returns the names of the
+	// path parameters that were declared.
+	check := func(params []*v3.Parameter, placeholders map[string]bool, specPath string) map[string]bool {
+		declared := make(map[string]bool)
+		for _, param := range params {
+			if param == nil || param.In != helpers.Path {
+				continue
+			}
+			declared[param.Name] = true
+			if placeholders != nil && !placeholders[param.Name] {
+				validationErrors = append(validationErrors, liberrors.PathParameterNotInTemplate(param, specPath))
+			}
+			if (param.Required == nil || !*param.Required) && !seen[param.GoLow().Name.KeyNode] {
+				seen[param.GoLow().Name.KeyNode] = true
+				validationErrors = append(validationErrors, liberrors.PathParameterNotRequired(param, specPath))
+			}
+		}
+		return declared
+	}
+
+	if document.Paths != nil {
+		for pair := orderedmap.First(document.Paths.PathItems); pair != nil; pair = pair.Next() {
+			names, err := helpers.PathTemplateParams(pair.Key())
+			if err != nil {
+				// a path with unbalanced braces cannot be matched at all, the document validation reports it.
+				continue
+			}
+			placeholders := make(map[string]bool, len(names))
+			for _, name := range names {
+				placeholders[name] = true
+			}
+
+			pathDeclared := check(pair.Value().Parameters, placeholders, pair.Key())
+			for op := orderedmap.First(pair.Value().GetOperations()); op != nil; op = op.Next() {
+				declared := check(op.Value().Parameters, placeholders, pair.Key())
+				for _, name := range names {
+					if !declared[name] && !pathDeclared[name] {
+						validationErrors = append(validationErrors,
+							liberrors.PathParameterNotDeclared(name, op.Key(), pair.Key(), op.Value()))
+					}
+				}
+			}
+		}
+	}
+	if document.Components != nil {
+		var params []*v3.Parameter
+		for pair := orderedmap.First(document.Components.Parameters); pair != nil; pair = pair.Next() {
+			params = append(params, pair.Value())
+		}
+		// components are not part of a path, so only 'required' can be checked.
+		check(params, nil, "")
+	}
+
+	if len(validationErrors) > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+func TestValidatePathParameters(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: dressingId
+          in: path
+          required: true
+          schema:
+            type: string
+    put:
+      parameters:
+        - $ref: '#/components/parameters/BurgerId'
+  /burgers/{burgerId}/dressings/{dressingId}:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/BurgerId'
+        - name: dressingId
+          in: query
+          schema:
+            type: string
+components:
+  parameters:
+    BurgerId:
+      name: burgerId
+      in: path
+      schema:
+        type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidatePathParameters(&m.Model)
+	assert.False(t, valid)
+	require.Len(t, errors, 3)
+
+	assert.Equal(t, helpers.DocumentValidation, errors[0].ValidationType)
+	assert.Equal(t, helpers.DocumentPathParameter, errors[0].ValidationSubType)
+	assert.Equal(t, "Path parameter 'dressingId' is not part of the path '/burgers/{burgerId}'", errors[0].Message)
+	assert.Equal(t, "/burgers/{burgerId}", errors[0].SpecPath)
+	assert.Equal(t, 12, errors[0].SpecLine)
+
+	// the referenced parameter is only reported as not required once.
+	assert.Equal(t, "Path parameter 'burgerId' must be required", errors[1].Message)
+	assert.Equal(t, 31, errors[1].SpecLine)
+
+	assert.Equal(t, "Path parameter 'dressingId' of '/burgers/{burgerId}/dressings/{dressingId}' is not "+
+		"declared by the GET operation", errors[2].Message)
+	assert.Equal(t, "/burgers/{burgerId}/dressings/{dressingId}", errors[2].SpecPath)
+	assert.Equal(t, 21, errors[2].SpecLine)
+
+	// a document with matching path parameters passes.
+	doc, _ = libopenapi.NewDocument([]byte(`openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: string`))
+	m, _ = doc.BuildV3Model()
+
+	valid, errors = ValidatePathParameters(&m.Model)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
@@ -17,8 +17,6 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"gopkg.in/yaml.v3"
 
 	_ "embed"
@@ -97,7 +95,7 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 	// render the schema, to be used for validation, stop this from running concurrently, mutations are made to state
 	// and, it will cause async issues.
 	s.lock.Lock()
-	renderedSchema, _ = schema.RenderInline()
+	renderedSchema, _ = helpers.RenderSchemaInline(schema)
 	s.lock.Unlock()
 
 	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
@@ -116,6 +114,7 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 				ValidationType:         helpers.RequestBodyValidation,
 				ValidationSubType:      helpers.Schema,
 				Message:                "schema does not pass validation",
+				MessageKey:             liberrors.MessageKeySchemaInvalid,
 				Reason:                 fmt.Sprintf("The schema cannot be decoded: %s", err.Error()),
 				SpecLine:               1,
 				SpecCol:                0,
@@ -135,34 +134,9 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 
 	// is the schema even valid? did it compile?
 	if err != nil {
-		var ve *jsonschema.SchemaValidationError
-		if errors.As(err, &ve) {
-			if ve != nil {
-
-				// no, this won't work, so we need to extract the errors and return them.
-				// basicErrors := ve.BasicOutput().Errors
-				// schemaValidationErrors = extractBasicErrors(basicErrors, renderedSchema, decodedObject, payload, ve, schemaValidationErrors)
-				// cannot compile schema, so it's not valid
-				violation := &liberrors.SchemaValidationFailure{
-					Reason:          err.Error(),
-					Location:        "unavailable",
-					ReferenceSchema: string(renderedSchema),
-					ReferenceObject: string(payload),
-				}
-				validationErrors = append(validationErrors, &liberrors.ValidationError{
-					ValidationType:         helpers.RequestBodyValidation,
-					ValidationSubType:      helpers.Schema,
-					Message:                "schema does not pass validation",
-					Reason:                 fmt.Sprintf("The schema cannot be decoded: %s", err.Error()),
-					SpecLine:               1,
-					SpecCol:                0,
-					SchemaValidationErrors: []*liberrors.SchemaValidationFailure{violation},
-					HowToFix:               liberrors.HowToFixInvalidSchema,
-					Context:                string(renderedSchema), // attach the rendered schema to the error
-				})
-				return false, validationErrors
-			}
-		}
+		validationErrors = append(validationErrors,
+			liberrors.SchemaCompilationFailed(schema, helpers.Schema, renderedSchema, err))
+		return false, validationErrors
 	}
 
 	// 4. validate the object against the schema
@@ -174,7 +148,7 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 			if errors.As(scErrs, &jk) {
 
 				// flatten the validationErrors
-				schFlatErr := jk.BasicOutput().Errors
+				schFlatErr := helpers.FlattenSchemaErrors(jk, decodedObject)
 				schemaValidationErrors = extractBasicErrors(schFlatErr, renderedSchema,
 					decodedObject, payload, jk, schemaValidationErrors)
 			}
@@ -189,6 +163,7 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 			validationErrors = append(validationErrors, &liberrors.ValidationError{
 				ValidationType:         helpers.Schema,
 				Message:                "schema does not pass validation",
+				MessageKey:             liberrors.MessageKeySchemaInvalid,
 				Reason:                 "Schema failed to validate against the contract requirements",
 				SpecLine:               line,
 				SpecCol:                col,
@@ -209,10 +184,14 @@ func extractBasicErrors(schFlatErrs []jsonschema.OutputUnit,
 	payload []byte, jk *jsonschema.ValidationError,
 	schemaValidationErrors []*liberrors.SchemaValidationFailure,
 ) []*liberrors.SchemaValidationFailure {
+	// decode the schema, so the fields that failed can be located.
+	var decodedSchema any
+	_ = yaml.Unmarshal(renderedSchema, &decodedSchema)
+
 	for q := range schFlatErrs {
 		er := schFlatErrs[q]
 
-		errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+		errMsg := helpers.SchemaErrorMessage(er.Error.Kind)
 		if helpers.IgnoreRegex.MatchString(errMsg) {
 			continue // ignore this error, it's useless tbh, utter noise.
 		}
@@ -242,7 +221,7 @@ func extractBasicErrors(schFlatErrs []jsonschema.OutputUnit,
 			}
 
 			violation := &liberrors.SchemaValidationFailure{
-				Reason:           errMsg,
+				Reason:           helpers.ConditionalReason(er.KeywordLocation, errMsg),
 				Location:         er.InstanceLocation,
 				DeepLocation:     er.KeywordLocation,
 				AbsoluteLocation: er.AbsoluteKeywordLocation,
@@ -265,7 +244,7 @@ func extractBasicErrors(schFlatErrs []jsonschema.OutputUnit,
 				violation.Line = line
 				violation.Column = located.Column
 			}
-			schemaValidationErrors = append(schemaValidationErrors, violation)
+			schemaValidationErrors = append(schemaValidationErrors, liberrors.ExpandSchemaValidationFailure(violation, er, decodedSchema)...)
 		}
 	}
 	return schemaValidationErrors
@@ -9,7 +9,11 @@ import (
 
 	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
 )
 
 func TestLocateSchemaPropertyNodeByJSONPath(t *testing.T) {
@@ -528,43 +532,48 @@ paths:
 	assert.Equal(t, "invalid character '}' looking for beginning of object key string", errors[0].SchemaValidationErrors[0].Reason)
 }
 
-//// https://github.com/pb33f/libopenapi-validator/issues/26
-//func TestValidateSchema_v3_0_BooleanExclusiveMinimum(t *testing.T) {
-//
-//	spec := `openapi: 3.0.0
-//paths:
-//  /burgers/createBurger:
-//    post:
-//      requestBody:
-//        content:
-//          application/json:
-//            schema:
-//              type: object
-//              properties:
-//                amount:
-//                  type: number
-//                  minimum: 0
-//                  exclusiveMinimum: true`
-//
-//	doc, _ := libopenapi.NewDocument([]byte(spec))
-//
-//	m, _ := doc.BuildV3Model()
-//
-//	body := map[string]interface{}{"amount": 3}
-//
-//	bodyBytes, _ := json.Marshal(body)
-//	sch := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger"].Post.RequestBody.Content.GetOrZero("application/json"].Schema
-//
-//	// create a schema validator
-//	v := NewSchemaValidator()
-//
-//	// validate!
-//	valid, errors := v.ValidateSchemaString(sch.Schema(), string(bodyBytes))
-//
-//	assert.True(t, valid)
-//	assert.Empty(t, errors)
-//
-//}
+// https://github.com/pb33f/libopenapi-validator/issues/26
+func TestValidateSchema_v3_0_BooleanExclusiveMinimum(t *testing.T) {
+	spec := `openapi: 3.0.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                amount:
+                  type: number
+                  minimum: 0
+                  exclusiveMinimum: true`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	body := map[string]interface{}{"amount": 3}
+
+	bodyBytes, _ := json.Marshal(body)
+	sch := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger").Post.RequestBody.Content.GetOrZero("application/json").Schema
+
+	// create a schema validator
+	v := NewSchemaValidator()
+
+	// validate!
+	valid, errors := v.ValidateSchemaString(sch.Schema(), string(bodyBytes))
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the minimum is exclusive, so the boundary itself is not valid.
+	valid, errors = v.ValidateSchemaString(sch.Schema(), `{"amount": 0}`)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "value must be greater than 0", errors[0].SchemaValidationErrors[0].Reason)
+}
 
 // https://github.com/pb33f/libopenapi-validator/issues/26
 func TestValidateSchema_v3_0_NumericExclusiveMinimum(t *testing.T) {
@@ -597,8 +606,21 @@ paths:
 	// validate!
 	valid, errors := v.ValidateSchemaString(sch.Schema(), string(bodyBytes))
 
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the numeric form is honoured in 3.0 too, so the boundary itself is not valid.
+	valid, errors = v.ValidateSchemaString(sch.Schema(), `{"amount": 0}`)
+
 	assert.False(t, valid)
-	assert.NotEmpty(t, errors)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "value must be greater than 0", errors[0].SchemaValidationErrors[0].Reason)
+
+	valid, errors = v.ValidateSchemaString(sch.Schema(), `{"amount": -5}`)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "value must be greater than 0", errors[0].SchemaValidationErrors[0].Reason)
 }
 
 // https://github.com/pb33f/libopenapi-validator/issues/26
@@ -780,3 +802,29 @@ paths:
 	assert.False(t, valid)
 	assert.NotEmpty(t, errors)
 }
+
+func TestValidateSchema_CompilationFailed(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      type: object
+      properties:
+        name:
+          type: string
+          pattern: "^(?!spam).*$"`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	sch := m.Model.Components.Schemas.GetOrZero("Burger")
+
+	v := NewSchemaValidator()
+	valid, errors := v.ValidateSchemaString(sch.Schema(), `{"name": "bacon"}`)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.True(t, errors[0].IsSchemaCompilationError())
+	assert.Equal(t, helpers.Schema, errors[0].ValidationSubType)
+	assert.Equal(t, liberrors.MessageKeySchemaCompilationFailed, errors[0].MessageKey)
+}
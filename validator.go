@@ -6,13 +6,18 @@ package validator
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/utils"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 
 	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/parameters"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/pb33f/libopenapi-validator/requests"
@@ -42,17 +47,71 @@ type Validator interface {
 	// The path, query, cookie and header parameters and request body are validated.
 	ValidateHttpRequestSyncWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 
+	// ValidateHttpRequestWithWarnings will validate an *http.Request object against an OpenAPI 3+ document, the same
+	// way as ValidateHttpRequestSync does, and returns the warnings separately from the errors. Warnings are things
+	// worth knowing about that do not make the request invalid, like a request to a deprecated operation, a
+	// deprecated parameter being supplied, a query parameter that is not declared (and was ignored), or a pattern
+	// that was skipped (see config.WithSkipBadPatterns). The boolean only reflects the errors.
+	ValidateHttpRequestWithWarnings(request *http.Request) (bool, []*errors.ValidationError, []*errors.ValidationError)
+
+	// ValidateHttpRequestStructured will validate an *http.Request object against an OpenAPI 3+ document, the same
+	// way as ValidateHttpRequestWithWarnings does, and returns a report with the errors grouped by where they
+	// occurred (routing, each location of parameters, security and the body). The schema violations of the body
+	// are also arranged into a tree, by the JSON path of the field they occurred at.
+	ValidateHttpRequestStructured(request *http.Request) *RequestValidationReport
+
+	// ValidateRequests will validate a batch of *http.Request objects (for example, recorded traffic) synchronously.
+	// The path resolved for a method and path is re-used across the batch, as are the compiled schemas. A result is
+	// returned for every request, in the same order as the requests.
+	ValidateRequests(requests []*http.Request) []RequestValidationResult
+
 	// ValidateHttpResponse will an *http.Response object against an OpenAPI 3+ document.
 	// The response body is validated. The request is only used to extract the correct response from the spec.
 	ValidateHttpResponse(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
 
+	// ValidateResponseCode will check that a status code is declared by the responses of the operation matched by
+	// the *http.Request. The code itself is looked up first, then its range (e.g. '2XX'), and then 'default'.
+	ValidateResponseCode(request *http.Request, statusCode int) (bool, []*errors.ValidationError)
+
 	// ValidateHttpRequestResponse will validate both the *http.Request and *http.Response objects against an OpenAPI 3+ document.
 	// The path, query, cookie and header parameters and request and response body are validated.
 	ValidateHttpRequestResponse(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
 
+	// ValidateWebhookRequest will validate an *http.Request object received for a webhook, declared in the (3.1)
+	// 'webhooks' of the document. The webhook is looked up by name, and the operation by the request method.
+	// The query, cookie and header parameters and request body are validated.
+	ValidateWebhookRequest(name string, request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateCallbackRequest will validate an *http.Request object received for a callback, declared on an operation.
+	// The operation is looked up by operationId, the callback by name, and the path item by the runtime expression
+	// the callback is keyed by (e.g. '{$request.body#/callbackUrl}'), the expression can be left empty if the
+	// callback only declares one. The query, cookie and header parameters and request body are validated.
+	ValidateCallbackRequest(operationId, callbackName, expression string, request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateSchema will validate a JSON payload against a schema declared in the 'components.schemas' of the
+	// document, looked up by name. It's independent of any operation, so the shape of a payload can be tested in
+	// isolation. If there is no schema with the name, a single 'schema not found' error is returned.
+	ValidateSchema(schemaName string, data []byte) (bool, []*errors.ValidationError)
+
 	// ValidateDocument will validate an OpenAPI 3+ document against the 3.0 or 3.1 OpenAPI 3+ specification
 	ValidateDocument() (bool, []*errors.ValidationError)
 
+	// ValidateExamples will check every 'example' and 'examples' value declared in the OpenAPI 3+ document validates
+	// against its own schema, so the documentation stays honest. This is a design-time check of the specification.
+	ValidateExamples() []*errors.ValidationError
+
+	// FindPath performs just the path resolution step of validation, and returns the path item and the path
+	// template (e.g. '/users/{id}') that the request matches. found is only true if the path, and an operation
+	// for the request method were found. If the path was found but the method was not, the path item and
+	// template are still returned, so the mismatch can be debugged.
+	FindPath(request *http.Request) (pathItem *v3.PathItem, pathValue string, found bool)
+
+	// UndeclaredParameters returns the names of the headers and query parameters contained within *http.Request that
+	// are not declared by the operation it matches, without failing validation (see
+	// parameters.ParameterValidator.UndeclaredParameters). It's intended for debugging clients, alongside the strict
+	// modes.
+	UndeclaredParameters(request *http.Request) (headers []string, query []string)
+
 	// GetParameterValidator will return a parameters.ParameterValidator instance used to validate parameters
 	GetParameterValidator() parameters.ParameterValidator
 
@@ -64,6 +123,33 @@ type Validator interface {
 
 	// SetDocument will set the OpenAPI 3+ document to be validated
 	SetDocument(document libopenapi.Document)
+
+	// Reload will swap in a new OpenAPI 3+ model (for example, after the specification was changed on disk), and
+	// rebuild the parameter, request and response validators (and their caches) for it. The swap is atomic and it's
+	// safe to call while other goroutines are validating, a validation in flight finishes with the model it started
+	// with. The options of the validator are kept. The document used by ValidateDocument belongs to the previous
+	// model, so it's cleared, set the new one with SetDocument.
+	Reload(model *v3.Document)
+}
+
+// The sentinel errors of the errors package, re-exported so the category of a validation error can be checked
+// without importing it, for example errors.Is(err, validator.ErrPathNotFound).
+var (
+	ErrPathNotFound      = errors.ErrPathNotFound
+	ErrOperationNotFound = errors.ErrOperationNotFound
+	ErrParameter         = errors.ErrParameter
+	ErrRequestBody       = errors.ErrRequestBody
+	ErrResponseBody      = errors.ErrResponseBody
+	ErrSecurity          = errors.ErrSecurity
+	ErrSchema            = errors.ErrSchema
+	ErrDocument          = errors.ErrDocument
+)
+
+// RequestValidationResult is the outcome of validating one of the requests of a batch.
+type RequestValidationResult struct {
+	Request *http.Request
+	Valid   bool
+	Errors  []*errors.ValidationError
 }
 
 // NewValidator will create a new Validator from an OpenAPI 3+ document
@@ -73,126 +159,250 @@ func NewValidator(document libopenapi.Document, opts ...config.Option) (Validato
 		return nil, errs
 	}
 	v := NewValidatorFromV3Model(&m.Model, opts...)
-	v.(*validator).document = document
+	v.SetDocument(document)
 	return v, nil
 }
 
 // NewValidatorFromV3Model will create a new Validator from an OpenAPI Model
 func NewValidatorFromV3Model(m *v3.Document, opts ...config.Option) Validator {
-	options := config.NewValidationOptions(opts...)
-
-	v := &validator{options: options, v3Model: m}
-
-	// create a new parameter validator
-	v.paramValidator = parameters.NewParameterValidator(m, opts...)
-
-	// create aq new request body validator
-	v.requestValidator = requests.NewRequestBodyValidator(m, opts...)
+	return NewValidatorWithOptions(m, opts...)
+}
 
-	// create a response body validator
-	v.responseValidator = responses.NewResponseBodyValidator(m, opts...)
+// NewValidatorWithOptions will create a new Validator from an OpenAPI Model, configured using functional options
+// from the config package, for example:
+//
+//	v := validator.NewValidatorWithOptions(&m.Model,
+//		config.WithStrictQueryParams(),
+//		config.WithMaxBodyBytes(1<<20),
+//		config.WithBasePath("/api/v1"),
+//		config.WithFormatValidator("sku", validateSku),
+//		config.WithRegexEngine(engine))
+//
+// Without any options, the validator uses the defaults (the same as NewValidatorFromV3Model and NewValidator).
+func NewValidatorWithOptions(m *v3.Document, opts ...config.Option) Validator {
+	options := config.NewValidationOptions(opts...)
 
+	v := &validator{options: options}
+	v.state.Store(v.newState(m))
 	return v
 }
 
+// newState creates the validators for a model. The validators share the options, so state held by the options
+// (like skipped patterns) is shared too.
+func (v *validator) newState(m *v3.Document) *validatorState {
+	shared := config.WithExistingOpts(v.options)
+	return &validatorState{
+		v3Model:           m,
+		paramValidator:    parameters.NewParameterValidator(m, shared),
+		requestValidator:  requests.NewRequestBodyValidator(m, shared),
+		responseValidator: responses.NewResponseBodyValidator(m, shared),
+		pathCache:         paths.NewPathCache(m),
+		skippedPatterns:   &sync.Map{},
+	}
+}
+
 func (v *validator) SetDocument(document libopenapi.Document) {
-	v.document = document
+	v.swapLock.Lock()
+	defer v.swapLock.Unlock()
+	state := *v.state.Load()
+	state.document = document
+	v.state.Store(&state)
+}
+
+func (v *validator) Reload(model *v3.Document) {
+	// the validators are created before the lock is taken, so validation is never held up.
+	state := v.newState(model)
+	v.swapLock.Lock()
+	defer v.swapLock.Unlock()
+	v.state.Store(state)
 }
 
 func (v *validator) GetParameterValidator() parameters.ParameterValidator {
-	return v.paramValidator
+	return v.state.Load().paramValidator
 }
 
 func (v *validator) GetRequestBodyValidator() requests.RequestBodyValidator {
-	return v.requestValidator
+	return v.state.Load().requestValidator
 }
 
 func (v *validator) GetResponseBodyValidator() responses.ResponseBodyValidator {
-	return v.responseValidator
+	return v.state.Load().responseValidator
+}
+
+func (v *validator) FindPath(request *http.Request) (*v3.PathItem, string, bool) {
+	s := v.state.Load()
+	pathItem, errs, pathValue := v.findPath(s, request)
+	return pathItem, pathValue, pathItem != nil && len(errs) == 0
+}
+
+func (v *validator) UndeclaredParameters(request *http.Request) ([]string, []string) {
+	s := v.state.Load()
+	pathItem, errs, _ := v.findPath(s, request)
+	if len(errs) > 0 {
+		return nil, nil
+	}
+	return s.paramValidator.UndeclaredParametersWithPathItem(request, pathItem)
+}
+
+func (v *validator) ValidateSchema(schemaName string, data []byte) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
+	var schema *base.Schema
+	if s.v3Model != nil && s.v3Model.Components != nil && s.v3Model.Components.Schemas != nil {
+		if schemaProxy := s.v3Model.Components.Schemas.GetOrZero(schemaName); schemaProxy != nil {
+			schema = schemaProxy.Schema()
+		}
+	}
+	if schema == nil {
+		return v.translate(false, []*errors.ValidationError{errors.SchemaNotFound(schemaName)})
+	}
+	// the schema is validated with the same options (formats, regex engine, etc.) as requests and responses.
+	schemaValidator := schema_validation.NewSchemaValidator(config.WithExistingOpts(v.options))
+	return v.translate(schemaValidator.ValidateSchemaBytes(schema, data))
 }
 
 func (v *validator) ValidateDocument() (bool, []*errors.ValidationError) {
-	if v.document == nil {
-		return false, []*errors.ValidationError{{
-			ValidationType:    "document",
+	s := v.state.Load()
+	if s.document == nil {
+		return v.translate(false, []*errors.ValidationError{{
+			ValidationType:    helpers.DocumentValidation,
 			ValidationSubType: "missing",
 			Message:           "Document is not set",
+			MessageKey:        errors.MessageKeyDocumentNotSet,
 			Reason:            "The document cannot be validated as it is not set",
 			SpecLine:          1,
 			SpecCol:           1,
 			HowToFix:          "Set the document via `SetDocument` before validating",
-		}}
+		}})
 	}
 	var validationOpts []config.Option
 	if v.options != nil {
 		validationOpts = append(validationOpts, config.WithRegexEngine(v.options.RegexEngine))
 	}
-	return schema_validation.ValidateOpenAPIDocument(v.document, validationOpts...)
+	valid, validationErrors := schema_validation.ValidateOpenAPIDocument(s.document, validationOpts...)
+
+	// check the parameters of the specification use legal style / location combinations.
+	if validStyles, styleErrors := schema_validation.ValidateParameterStyles(s.v3Model); !validStyles {
+		valid = false
+		validationErrors = append(validationErrors, styleErrors...)
+	}
+
+	// check the path parameters of the specification match the placeholders of the paths.
+	if validPathParams, pathParamErrors := schema_validation.ValidatePathParameters(s.v3Model); !validPathParams {
+		valid = false
+		validationErrors = append(validationErrors, pathParamErrors...)
+	}
+
+	// check the default values of the specification are valid according to their own schemas, with the same
+	// options (formats, regex engine, etc.) as requests and responses.
+	var defaultOpts []config.Option
+	if v.options != nil {
+		defaultOpts = append(defaultOpts, config.WithExistingOpts(v.options))
+	}
+	if validDefaults, defaultErrors := schema_validation.ValidateDefaults(s.v3Model, defaultOpts...); !validDefaults {
+		valid = false
+		validationErrors = append(validationErrors, defaultErrors...)
+	}
+	return v.translate(valid, validationErrors)
+}
+
+func (v *validator) ValidateExamples() []*errors.ValidationError {
+	s := v.state.Load()
+	if s.v3Model == nil {
+		return nil
+	}
+	// the examples are validated with the same options (formats, regex engine, etc.) as requests and responses.
+	var validationOpts []config.Option
+	if v.options != nil {
+		validationOpts = append(validationOpts, config.WithExistingOpts(v.options))
+	}
+	valid, validationErrors := schema_validation.ValidateExamples(s.v3Model, validationOpts...)
+	_, validationErrors = v.translate(valid, validationErrors)
+	return validationErrors
 }
 
 func (v *validator) ValidateHttpResponse(
 	request *http.Request,
 	response *http.Response,
 ) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
 	var pathItem *v3.PathItem
 	var pathValue string
 	var errs []*errors.ValidationError
 
-	pathItem, errs, pathValue = paths.FindPath(request, v.v3Model)
+	pathItem, errs, pathValue = v.findPath(s, request)
 	if pathItem == nil || errs != nil {
-		return false, errs
+		return v.translate(false, errs)
 	}
 
-	responseBodyValidator := v.responseValidator
+	responseBodyValidator := s.responseValidator
 
 	// validate response
-	_, responseErrors := responseBodyValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	start := v.startPhase()
+	valid, responseErrors := responseBodyValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	v.endPhase(config.PhaseResponseBody, start, request, pathValue, valid, responseErrors)
+	return v.translate(valid, responseErrors)
+}
 
-	if len(responseErrors) > 0 {
-		return false, responseErrors
+func (v *validator) ValidateResponseCode(request *http.Request, statusCode int) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
+	pathItem, errs, pathValue := v.findPath(s, request)
+	if pathItem == nil || errs != nil {
+		return v.translate(false, errs)
+	}
+	operation := helpers.ExtractOperation(request, pathItem)
+	if operation == nil {
+		return v.translate(false, []*errors.ValidationError{
+			errors.OperationNotFound(pathItem, request, request.Method, pathValue),
+		})
+	}
+	if response, _ := helpers.FindResponse(operation, statusCode); response == nil {
+		validationErrors := []*errors.ValidationError{errors.ResponseCodeNotDefined(operation, request, statusCode)}
+		errors.PopulateValidationErrors(validationErrors, request, pathValue)
+		return v.translate(false, validationErrors)
 	}
-	return true, nil
+	return v.translate(true, nil)
 }
 
 func (v *validator) ValidateHttpRequestResponse(
 	request *http.Request,
 	response *http.Response,
 ) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
 	var pathItem *v3.PathItem
 	var pathValue string
 	var errs []*errors.ValidationError
 
-	pathItem, errs, pathValue = paths.FindPath(request, v.v3Model)
+	pathItem, errs, pathValue = v.findPath(s, request)
 	if pathItem == nil || errs != nil {
-		return false, errs
+		return v.translate(false, errs)
 	}
 
-	responseBodyValidator := v.responseValidator
+	responseBodyValidator := s.responseValidator
 
 	// validate request and response
-	_, requestErrors := v.ValidateHttpRequestWithPathItem(request, pathItem, pathValue)
-	_, responseErrors := responseBodyValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
-
-	if len(requestErrors) > 0 || len(responseErrors) > 0 {
-		return false, append(requestErrors, responseErrors...)
-	}
-	return true, nil
+	requestValid, requestErrors := v.ValidateHttpRequestWithPathItem(request, pathItem, pathValue)
+	start := v.startPhase()
+	responseValid, responseErrors := responseBodyValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	v.endPhase(config.PhaseResponseBody, start, request, pathValue, responseValid, responseErrors)
+	return v.translate(requestValid && responseValid, append(requestErrors, responseErrors...))
 }
 
 func (v *validator) ValidateHttpRequest(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.v3Model)
+	s := v.state.Load()
+	pathItem, errs, foundPath := v.findPath(s, request)
 	if len(errs) > 0 {
-		return false, errs
+		return v.translate(false, v.collectUnmatchedErrors(s, request, pathItem, foundPath, errs))
 	}
 	return v.ValidateHttpRequestWithPathItem(request, pathItem, foundPath)
 }
 
 func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
 	// create a new parameter validator
-	paramValidator := v.paramValidator
+	paramValidator := s.paramValidator
 
 	// create a new request body validator
-	reqBodyValidator := v.requestValidator
+	reqBodyValidator := s.requestValidator
 
 	// create some channels to handle async validation
 	doneChan := make(chan struct{})
@@ -201,6 +411,7 @@ func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathI
 
 	// async param validation function.
 	parameterValidationFunc := func(control chan struct{}, errorChan chan []*errors.ValidationError) {
+		start := v.startPhase()
 		paramErrs := make(chan []*errors.ValidationError)
 		paramControlChan := make(chan struct{})
 		paramFunctionControlChan := make(chan struct{})
@@ -249,6 +460,7 @@ func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathI
 
 		// wait for all the validations to complete
 		<-paramFunctionControlChan
+		v.endPhase(config.PhaseParameters, start, request, pathValue, len(paramValidationErrors) == 0, paramValidationErrors)
 		if len(paramValidationErrors) > 0 {
 			errorChan <- paramValidationErrors
 		}
@@ -258,7 +470,9 @@ func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathI
 	}
 
 	requestBodyValidationFunc := func(control chan struct{}, errorChan chan []*errors.ValidationError) {
+		start := v.startPhase()
 		valid, pErrs := reqBodyValidator.ValidateRequestBodyWithPathItem(request, pathItem, pathValue)
+		v.endPhase(config.PhaseRequestBody, start, request, pathValue, valid, pErrs)
 		if !valid {
 			errorChan <- pErrs
 		}
@@ -283,26 +497,104 @@ func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathI
 
 	// wait for all the validations to complete
 	<-doneChan
-	return !(len(validationErrors) > 0), validationErrors
+	return v.translate(!(len(validationErrors) > 0), validationErrors)
 }
 
 func (v *validator) ValidateHttpRequestSync(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.v3Model)
+	s := v.state.Load()
+	pathItem, errs, foundPath := v.findPath(s, request)
 	if len(errs) > 0 {
-		return false, errs
+		return v.translate(false, v.collectUnmatchedErrors(s, request, pathItem, foundPath, errs))
 	}
 	return v.ValidateHttpRequestSyncWithPathItem(request, pathItem, foundPath)
 }
 
+func (v *validator) ValidateHttpRequestWithWarnings(request *http.Request) (bool, []*errors.ValidationError, []*errors.ValidationError) {
+	s := v.state.Load()
+	pathItem, errs, foundPath := v.findPath(s, request)
+	if len(errs) > 0 {
+		valid, validationErrors := v.translate(false, v.collectUnmatchedErrors(s, request, pathItem, foundPath, errs))
+		return valid, validationErrors, nil
+	}
+	valid, validationErrors := v.ValidateHttpRequestSyncWithPathItem(request, pathItem, foundPath)
+
+	var warnings []*errors.ValidationError
+	operation := helpers.ExtractOperation(request, pathItem)
+	if operation != nil {
+		for _, skipped := range v.skippedPatterns(s, request, pathItem, operation) {
+			warnings = append(warnings, errors.PatternNotCompiled(skipped.Pattern, skipped.Err))
+		}
+		if operation.Deprecated != nil && *operation.Deprecated {
+			warnings = append(warnings, errors.OperationDeprecated(operation, request, foundPath))
+		}
+	}
+	warnings = append(warnings, s.paramValidator.FindWarningsWithPathItem(request, pathItem, foundPath)...)
+	if v.options != nil {
+		errors.TranslateValidationErrors(warnings, v.options.MessageTranslator)
+	}
+	return valid, validationErrors, warnings
+}
+
+// skippedPatterns returns the patterns of the parameter and request body schemas of an operation that could not be
+// compiled, and were skipped (see config.WithSkipBadPatterns). They are found once per operation.
+func (v *validator) skippedPatterns(s *validatorState, request *http.Request, pathItem *v3.PathItem,
+	operation *v3.Operation,
+) []helpers.SkippedPattern {
+	if v.options == nil || !v.options.SkipBadPatterns {
+		return nil
+	}
+	if cached, ok := s.skippedPatterns.Load(operation); ok {
+		return cached.([]helpers.SkippedPattern)
+	}
+
+	var proxies []*base.SchemaProxy
+	for _, param := range helpers.ExtractParamsForOperation(request, pathItem) {
+		if param.Schema != nil {
+			proxies = append(proxies, param.Schema)
+		}
+		if param.Content != nil {
+			for _, mediaType := range param.Content.FromOldest() {
+				if mediaType.Schema != nil {
+					proxies = append(proxies, mediaType.Schema)
+				}
+			}
+		}
+	}
+	if operation.RequestBody != nil && operation.RequestBody.Content != nil {
+		for _, mediaType := range operation.RequestBody.Content.FromOldest() {
+			if mediaType.Schema != nil {
+				proxies = append(proxies, mediaType.Schema)
+			}
+		}
+	}
+
+	var skipped []helpers.SkippedPattern
+	seen := make(map[string]bool)
+	for _, proxy := range proxies {
+		renderedInline, _ := helpers.RenderSchemaInline(proxy.Schema())
+		renderedJSON, _ := utils.ConvertYAMLtoJSON(renderedInline)
+		for _, pattern := range helpers.FindSkippedPatterns("schema", renderedJSON, v.options) {
+			if !seen[pattern.Pattern] {
+				seen[pattern.Pattern] = true
+				skipped = append(skipped, pattern)
+			}
+		}
+	}
+	s.skippedPatterns.Store(operation, skipped)
+	return skipped
+}
+
 func (v *validator) ValidateHttpRequestSyncWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
 	// create a new parameter validator
-	paramValidator := v.paramValidator
+	paramValidator := s.paramValidator
 
 	// create a new request body validator
-	reqBodyValidator := v.requestValidator
+	reqBodyValidator := s.requestValidator
 
 	validationErrors := make([]*errors.ValidationError, 0)
 
+	start := v.startPhase()
 	paramValidationErrors := make([]*errors.ValidationError, 0)
 	for _, validateFunc := range []validationFunction{
 		paramValidator.ValidatePathParamsWithPathItem,
@@ -317,22 +609,189 @@ func (v *validator) ValidateHttpRequestSyncWithPathItem(request *http.Request, p
 		}
 	}
 
+	v.endPhase(config.PhaseParameters, start, request, pathValue, len(paramValidationErrors) == 0, paramValidationErrors)
+
+	start = v.startPhase()
 	valid, pErrs := reqBodyValidator.ValidateRequestBodyWithPathItem(request, pathItem, pathValue)
+	v.endPhase(config.PhaseRequestBody, start, request, pathValue, valid, pErrs)
 	if !valid {
 		paramValidationErrors = append(paramValidationErrors, pErrs...)
 	}
 
 	validationErrors = append(validationErrors, paramValidationErrors...)
+	return v.translate(!(len(validationErrors) > 0), validationErrors)
+}
+
+func (v *validator) ValidateRequests(requests []*http.Request) []RequestValidationResult {
+	s := v.state.Load()
+	results := make([]RequestValidationResult, len(requests))
+	for i, request := range requests {
+		// the path cache re-uses the path resolved for a route, the base paths (and forwarded prefix) of each request
+		// are honored before it's looked up.
+		pathItem, errs, pathValue := v.findPath(s, request)
+		if len(errs) > 0 {
+			valid, validationErrors := v.translate(false, v.collectUnmatchedErrors(s, request, pathItem, pathValue, errs))
+			results[i] = RequestValidationResult{Request: request, Valid: valid, Errors: validationErrors}
+			continue
+		}
+		valid, validationErrors := v.ValidateHttpRequestSyncWithPathItem(request, pathItem, pathValue)
+		results[i] = RequestValidationResult{Request: request, Valid: valid, Errors: validationErrors}
+	}
+	return results
+}
+
+func (v *validator) ValidateWebhookRequest(name string, request *http.Request) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
+	var pathItem *v3.PathItem
+	if s.v3Model != nil && s.v3Model.Webhooks != nil {
+		pathItem = s.v3Model.Webhooks.GetOrZero(name)
+	}
+	if pathItem == nil {
+		return v.translate(false, []*errors.ValidationError{errors.WebhookNotFound(name, request)})
+	}
+	if helpers.ExtractOperation(request, pathItem) == nil {
+		return v.translate(false, []*errors.ValidationError{errors.WebhookOperationNotFound(name, pathItem, request)})
+	}
+
+	return v.translate(v.validateUnroutedRequest(s, request, pathItem, name))
+}
+
+func (v *validator) ValidateCallbackRequest(
+	operationId, callbackName, expression string,
+	request *http.Request,
+) (bool, []*errors.ValidationError) {
+	s := v.state.Load()
+	_, _, _, operation := helpers.FindOperationByOperationId(s.v3Model, operationId)
+	if operation == nil {
+		return v.translate(false, []*errors.ValidationError{errors.OperationIdNotFound(operationId)})
+	}
+	pathItem := helpers.FindCallbackPathItem(operation, callbackName, expression)
+	if pathItem == nil {
+		return v.translate(false, []*errors.ValidationError{
+			errors.CallbackNotFound(operationId, callbackName, expression, request),
+		})
+	}
+	if helpers.ExtractOperation(request, pathItem) == nil {
+		return v.translate(false, []*errors.ValidationError{
+			errors.OperationNotFound(pathItem, request, request.Method, expression),
+		})
+	}
+	return v.translate(v.validateUnroutedRequest(s, request, pathItem, expression))
+}
+
+// validateUnroutedRequest validates the query, cookie and header parameters, security and body of a request that
+// was not routed by path (such as a webhook or a callback), so there are no path parameters to validate.
+func (v *validator) validateUnroutedRequest(s *validatorState, request *http.Request, pathItem *v3.PathItem, specPath string) (bool, []*errors.ValidationError) {
+	validationErrors := make([]*errors.ValidationError, 0)
+	for _, validateFunc := range []validationFunction{
+		s.paramValidator.ValidateCookieParamsWithPathItem,
+		s.paramValidator.ValidateHeaderParamsWithPathItem,
+		s.paramValidator.ValidateQueryParamsWithPathItem,
+		s.paramValidator.ValidateSecurityWithPathItem,
+		s.requestValidator.ValidateRequestBodyWithPathItem,
+	} {
+		if valid, errs := validateFunc(request, pathItem, specPath); !valid {
+			validationErrors = append(validationErrors, errs...)
+		}
+	}
 	return !(len(validationErrors) > 0), validationErrors
 }
 
+// collectUnmatchedErrors adds everything that can still be checked for a request that could not be matched to an
+// operation, to the routing errors, when all errors are being collected. If the path was found (but the method was
+// not), the parameters declared by the path item are validated. The content type of the request is checked
+// against every request body in the specification.
+func (v *validator) collectUnmatchedErrors(s *validatorState, request *http.Request, pathItem *v3.PathItem, pathValue string,
+	routingErrors []*errors.ValidationError,
+) []*errors.ValidationError {
+	if v.options == nil || !v.options.CollectAllErrors {
+		return routingErrors
+	}
+	validationErrors := routingErrors
+	if pathItem != nil {
+		for _, validateFunc := range []validationFunction{
+			s.paramValidator.ValidatePathParamsWithPathItem,
+			s.paramValidator.ValidateCookieParamsWithPathItem,
+			s.paramValidator.ValidateHeaderParamsWithPathItem,
+			s.paramValidator.ValidateQueryParamsWithPathItem,
+		} {
+			if valid, errs := validateFunc(request, pathItem, pathValue); !valid {
+				validationErrors = append(validationErrors, errs...)
+			}
+		}
+	}
+	if contentType := request.Header.Get(helpers.ContentTypeHeader); contentType != "" && s.v3Model != nil {
+		var pathItems []*v3.PathItem
+		if s.v3Model.Paths != nil && s.v3Model.Paths.PathItems != nil {
+			for item := range s.v3Model.Paths.PathItems.ValuesFromOldest() {
+				pathItems = append(pathItems, item)
+			}
+		}
+		if !helpers.RequestContentTypeDeclared(pathItems, contentType) {
+			validationErrors = append(validationErrors, errors.ContentTypeNotDeclared(request, contentType))
+		}
+	}
+	return validationErrors
+}
+
+// findPath resolves the path item for a request, this is the routing phase of validation.
+func (v *validator) findPath(s *validatorState, request *http.Request) (*v3.PathItem, []*errors.ValidationError, string) {
+	start := v.startPhase()
+	pathItem, errs, pathValue := paths.FindPathWithCache(request, s.pathCache, v.options)
+	v.endPhase(config.PhaseRouting, start, request, pathValue, pathItem != nil && len(errs) == 0, errs)
+	return pathItem, errs, pathValue
+}
+
+// startPhase returns the time a phase of validation started. Without an observer the zero time is returned, so
+// validation does not pay for timing when it's not observed.
+func (v *validator) startPhase() time.Time {
+	if v.options == nil || v.options.Observer == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// endPhase reports the timing and outcome of a phase of validation to the observer (if there is one).
+func (v *validator) endPhase(phase string, start time.Time, request *http.Request, pathValue string,
+	valid bool, validationErrors []*errors.ValidationError,
+) {
+	if v.options == nil || v.options.Observer == nil {
+		return
+	}
+	v.options.Observer.ObservePhase(config.PhaseEvent{
+		Phase:      phase,
+		Request:    request,
+		PathValue:  pathValue,
+		Duration:   time.Since(start),
+		Valid:      valid,
+		ErrorCount: len(validationErrors),
+	})
+}
+
+// translate renders the messages of the validation errors with the configured message translator (if any).
+func (v *validator) translate(valid bool, validationErrors []*errors.ValidationError) (bool, []*errors.ValidationError) {
+	if v.options != nil {
+		errors.TranslateValidationErrors(validationErrors, v.options.MessageTranslator)
+	}
+	return valid, validationErrors
+}
+
 type validator struct {
-	options           *config.ValidationOptions
+	options  *config.ValidationOptions
+	state    atomic.Pointer[validatorState]
+	swapLock sync.Mutex // serializes the changes made by SetDocument and Reload
+}
+
+// validatorState is everything the validator holds for a model. It's replaced as a whole when the model (or the
+// document) changes, so each validation uses a consistent snapshot without locking.
+type validatorState struct {
 	v3Model           *v3.Document
 	document          libopenapi.Document
 	paramValidator    parameters.ParameterValidator
 	requestValidator  requests.RequestBodyValidator
 	responseValidator responses.ResponseBodyValidator
+	pathCache         *paths.PathCache
+	skippedPatterns   *sync.Map // the patterns skipped by the schemas of each operation
 }
 
 func runValidation(control, doneChan chan struct{},
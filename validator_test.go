@@ -25,6 +25,7 @@ import (
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 
 	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 )
 
@@ -54,93 +55,1596 @@ paths:
 	assert.NotNil(t, v.GetRequestBodyValidator())
 }
 
+func TestNewValidatorWithOptions(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: chef
+          in: query
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                sku:
+                  type: string
+                  format: sku`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model,
+		config.WithStrictQueryParams(),
+		config.WithMaxBodyBytes(1024),
+		config.WithBasePath("/api/v1"),
+		config.WithFormatValidator("sku", func(v any) error {
+			if s, ok := v.(string); ok && !strings.HasPrefix(s, "SKU-") {
+				return fmt.Errorf("'%s' is not a sku", s)
+			}
+			return nil
+		}))
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/api/v1/burgers?chef=ronald",
+		bytes.NewBufferString(`{"sku": "SKU-1234"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/api/v1/burgers?chef=ronald&fries=large",
+		bytes.NewBufferString(`{"sku": "1234"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+
+	messages := []string{errors[0].Message, errors[1].Message}
+	assert.Contains(t, messages, "Query parameter 'fries' is not defined")
+	assert.Contains(t, messages, "POST request body for '/api/v1/burgers' failed to validate schema")
+}
+
+func TestNewValidator_WithMessageTranslator(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: bash
+          in: header
+          required: true
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	translations := map[string]string{
+		liberrors.MessageKeyHeaderParameterMissing: "Falta el parámetro de cabecera '%s'",
+	}
+	v := NewValidatorWithOptions(&m.Model, config.WithMessageTranslator(func(key string, args map[string]any) string {
+		if tmpl, ok := translations[key]; ok {
+			return fmt.Sprintf(tmpl, args["name"])
+		}
+		return ""
+	}))
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Falta el parámetro de cabecera 'bash'", errors[0].Message)
+	assert.Equal(t, liberrors.MessageKeyHeaderParameterMissing, errors[0].MessageKey)
+
+	valid, errors = v.ValidateHttpRequestSync(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Falta el parámetro de cabecera 'bash'", errors[0].Message)
+
+	// without a translation, the default message is kept.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/fries", nil)
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "GET Path '/fries' not found", errors[0].Message)
+	assert.Equal(t, liberrors.MessageKeyPathNotFound, errors[0].MessageKey)
+
+	// without a translator, the default messages are used.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	valid, errors = NewValidatorWithOptions(&m.Model).ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'bash' is missing", errors[0].Message)
+}
+
+func TestNewValidator_DateTimeFormatAssertions(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: when
+          in: query
+          schema:
+            type: string
+            format: date-time
+        - name: day
+          in: header
+          schema:
+            type: string
+            format: date
+        - name: opens
+          in: cookie
+          schema:
+            type: string
+            format: time
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                at:
+                  type: string
+                  format: time`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(when, day, opens, at string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers?when="+url.QueryEscape(when),
+			bytes.NewBufferString(fmt.Sprintf(`{"at": "%s"}`, at)))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		request.Header.Set("day", day)
+		request.AddCookie(&http.Cookie{Name: "opens", Value: opens})
+		return request
+	}
+
+	// formats are annotations by default.
+	v := NewValidatorWithOptions(&m.Model)
+	valid, errors := v.ValidateHttpRequest(newRequest("2023-13-99", "2023-02-30", "25:99:00", "nope"))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	v = NewValidatorWithOptions(&m.Model, config.WithFormatAssertions())
+	valid, errors = v.ValidateHttpRequest(newRequest("2023-10-05T12:30:00Z", "2023-02-28", "08:00:00Z", "23:59:59+01:00"))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	valid, errors = v.ValidateHttpRequest(newRequest("2023-13-99", "2023-02-30", "25:99:00", "nope"))
+	assert.False(t, valid)
+	require.Len(t, errors, 4)
+
+	reasons := make(map[string]string)
+	for _, e := range errors {
+		require.Len(t, e.SchemaValidationErrors, 1)
+		reasons[e.Message] = e.SchemaValidationErrors[0].Reason
+	}
+	assert.Equal(t, map[string]string{
+		"Query parameter 'when' failed to validate":                  "value is not a valid date-time",
+		"Header parameter 'day' failed to validate":                  "value is not a valid date",
+		"Cookie parameter 'opens' failed to validate":                "value is not a valid time",
+		"POST request body for '/burgers' failed to validate schema": "value is not a valid time",
+	}, reasons)
+}
+
+func TestNewValidator_NetworkFormatAssertions(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /things/{id}:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                website:
+                  type: string
+                  format: uri
+                avatar:
+                  type: string
+                  format: uri-reference
+                ipv4:
+                  type: string
+                  format: ipv4
+                ipv6:
+                  type: string
+                  format: ipv6
+                host:
+                  type: string
+                  format: hostname`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model, config.WithFormatAssertions())
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/things/0b5c4f0e-8a2f-4c67-9d4e-2f1b3c5d7e9a",
+		bytes.NewBufferString(`{"website": "https://pb33f.io", "avatar": "/img/me.png", "ipv4": "10.0.0.1", `+
+			`"ipv6": "::1", "host": "pb33f.io"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/things/not-a-uuid",
+		bytes.NewBufferString(`{"website": "pb33f", "avatar": "\\\\img", "ipv4": "10.0.1", `+
+			`"ipv6": "::g", "host": "-pb33f-"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+
+	reasons := make(map[string]string)
+	for _, e := range errors {
+		for _, sve := range e.SchemaValidationErrors {
+			reasons[e.Message+" "+sve.FieldPath] = sve.Reason
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"Path parameter 'id' failed to validate ":                                        "value is not a valid uuid",
+		"POST request body for '/things/not-a-uuid' failed to validate schema $.website": "value is not a valid uri",
+		"POST request body for '/things/not-a-uuid' failed to validate schema $.avatar":  "value is not a valid uri-reference",
+		"POST request body for '/things/not-a-uuid' failed to validate schema $.ipv4":    "value is not a valid ipv4",
+		"POST request body for '/things/not-a-uuid' failed to validate schema $.ipv6":    "value is not a valid ipv6",
+		"POST request body for '/things/not-a-uuid' failed to validate schema $.host":    "value is not a valid hostname",
+	}, reasons)
+}
+
+func TestNewValidator_ValidateWebhookRequest(t *testing.T) {
+	spec := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      parameters:
+        - name: X-Signature
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://my-service.com/hooks/burgers",
+		bytes.NewBufferString(`{"name": "big mac", "patties": 2}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Signature", "abc123")
+
+	valid, errors := v.ValidateWebhookRequest("newBurger", request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the parameters and body are validated like those of an operation in 'paths'.
+	request, _ = http.NewRequest(http.MethodPost, "https://my-service.com/hooks/burgers",
+		bytes.NewBufferString(`{"patties": "two"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors = v.ValidateWebhookRequest("newBurger", request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Header parameter 'X-Signature' is missing", errors[0].Message)
+	assert.Equal(t, "POST request body for '/hooks/burgers' failed to validate schema", errors[1].Message)
+	assert.Len(t, errors[1].SchemaValidationErrors, 2)
+
+	// unknown webhook.
+	valid, errors = v.ValidateWebhookRequest("oldBurger", request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Webhook 'oldBurger' not found", errors[0].Message)
+	assert.Equal(t, helpers.WebhookValidation, errors[0].ValidationType)
+
+	// undeclared method.
+	request, _ = http.NewRequest(http.MethodPut, "https://my-service.com/hooks/burgers", nil)
+	valid, errors = v.ValidateWebhookRequest("newBurger", request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "PUT operation for webhook 'newBurger' not found", errors[0].Message)
+	assert.Equal(t, helpers.RequestMissingOperation, errors[0].ValidationSubType)
+	assert.Equal(t, 3, errors[0].SpecLine)
+
+	// a document without webhooks.
+	doc, _ = libopenapi.NewDocument([]byte("openapi: 3.1.0\npaths: {}"))
+	m, _ = doc.BuildV3Model()
+	valid, errors = NewValidatorWithOptions(&m.Model).ValidateWebhookRequest("newBurger", request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Webhook 'newBurger' not found", errors[0].Message)
+}
+
+func TestNewValidator_ValidateCallbackRequest(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      callbacks:
+        onDelivery:
+          '{$request.body#/callbackUrl}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      type: object
+                      required: [orderId, status]
+                      properties:
+                        orderId:
+                          type: integer
+                        status:
+                          type: string
+                          enum: [delivered, failed]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+	newRequest := func(method, body string) *http.Request {
+		request, _ := http.NewRequest(method, "https://my-service.com/callbacks/delivery", bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	valid, errors := v.ValidateCallbackRequest("createOrder", "onDelivery", "{$request.body#/callbackUrl}",
+		newRequest(http.MethodPost, `{"orderId": 1, "status": "delivered"}`))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the expression can be left out, there is only one.
+	valid, errors = v.ValidateCallbackRequest("createOrder", "onDelivery", "",
+		newRequest(http.MethodPost, `{"orderId": "one", "status": "lost"}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, errors[0].ValidationType)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	valid, errors = v.ValidateCallbackRequest("cancelOrder", "onDelivery", "",
+		newRequest(http.MethodPost, `{}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Operation with operationId 'cancelOrder' not found", errors[0].Message)
+
+	valid, errors = v.ValidateCallbackRequest("createOrder", "onRefund", "",
+		newRequest(http.MethodPost, `{}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Callback 'onRefund' not found for operation 'createOrder'", errors[0].Message)
+	assert.Equal(t, helpers.CallbackValidation, errors[0].ValidationType)
+
+	valid, errors = v.ValidateCallbackRequest("createOrder", "onDelivery", "",
+		newRequest(http.MethodPut, `{}`))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestMissingOperation, errors[0].ValidationSubType)
+}
+
+func TestNewValidator_WithCollectAllErrors(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        schema:
+          type: integer
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// the path is not found, and the content type is not accepted by anything.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/fries", bytes.NewBufferString(`<fries/>`))
+	request.Header.Set(helpers.ContentTypeHeader, "application/xml")
+
+	valid, errors := NewValidatorWithOptions(&m.Model).ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+
+	v := NewValidatorWithOptions(&m.Model, config.WithCollectAllErrors())
+	for _, validate := range []func(*http.Request) (bool, []*liberrors.ValidationError){
+		v.ValidateHttpRequest, v.ValidateHttpRequestSync,
+	} {
+		valid, errors = validate(request)
+		assert.False(t, valid)
+		require.Len(t, errors, 2)
+		assert.Equal(t, "POST Path '/fries' not found", errors[0].Message)
+		assert.Equal(t, "Content type 'application/xml' is not supported by any operation", errors[1].Message)
+	}
+
+	// the path is found but the method is not, the parameters of the path are still validated.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/cheese",
+		bytes.NewBufferString(`{"name": "cheese"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Method POST not allowed on path '/burgers/cheese'", errors[0].Message)
+	assert.True(t, errors[0].IsMethodNotAllowedError())
+	assert.Equal(t, []string{http.MethodPut}, errors[0].AllowedMethods())
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[1].Message)
+}
+
+func TestNewValidator_MinMaxProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: toppings
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            maxProperties: 2
+        - name: X-Meta
+          in: header
+          schema:
+            type: object
+            minProperties: 2
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                metadata:
+                  type: object
+                  minProperties: 1
+                  maxProperties: 10`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost,
+		"https://things.com/burgers?toppings[cheese]=1&toppings[onion]=2",
+		bytes.NewBufferString(`{"metadata": {"source": "app"}}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Meta", "source,app,region,eu")
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodPost,
+		"https://things.com/burgers?toppings[cheese]=1&toppings[onion]=2&toppings[pickle]=3",
+		bytes.NewBufferString(`{"metadata": {}}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Meta", "source,app")
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 3)
+
+	var reasons []string
+	for _, e := range errors {
+		for _, sve := range e.SchemaValidationErrors {
+			reasons = append(reasons, sve.Reason)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"object must have at most 2 properties, but has 3",
+		"object must have at least 2 properties, but has 1",
+		"object must have at least 1 property, but has 0",
+	}, reasons)
+}
+
+func TestNewValidator_StrictIntegers(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    post:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+            format: int64
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Store-Id
+          in: header
+          schema:
+            type: integer
+            format: int32
+        - name: session
+          in: cookie
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                patties:
+                  type: integer
+                calories:
+                  type: integer
+                  format: int32`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/9223372036854775807?limit=10",
+		bytes.NewBufferString(`{"patties": 2, "calories": 2147483647}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Store-Id", "-2147483648")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "12"})
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/1?limit=2.5",
+		bytes.NewBufferString(`{"patties": 1.5, "calories": 2147483648}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Store-Id", "2147483648")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "3.25"})
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+
+	var reasons []string
+	for _, e := range errors {
+		for _, sve := range e.SchemaValidationErrors {
+			reasons = append(reasons, sve.Reason)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"value 2.5 is not an integer",
+		"value exceeds int32 range",
+		"value 3.25 is not an integer",
+		"value 1.5 is not an integer",
+		"value exceeds int32 range",
+	}, reasons)
+}
+
+func TestNewValidator_MultipleOf(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /orders:
+    post:
+      parameters:
+        - name: tip
+          in: query
+          schema:
+            type: number
+            multipleOf: 0.01
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                quantity:
+                  type: integer
+                  multipleOf: 5
+                price:
+                  type: number
+                  multipleOf: 0.01
+                weight:
+                  type: number
+                  multipleOf: 0.1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	// values that are not exact in binary floating point (e.g. 19.99, 0.3) are still multiples.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/orders?tip=1.15",
+		bytes.NewBufferString(`{"quantity": 15, "price": 19.99, "weight": 0.3}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/orders?tip=1.155",
+		bytes.NewBufferString(`{"quantity": 7, "price": 19.999, "weight": 0.35}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+
+	var reasons []string
+	for _, e := range errors {
+		for _, sve := range e.SchemaValidationErrors {
+			reasons = append(reasons, sve.Reason)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"value 1.155 is not a multiple of 0.01",
+		"value 7 is not a multiple of 5",
+		"value 19.999 is not a multiple of 0.01",
+		"value 0.35 is not a multiple of 0.1",
+	}, reasons)
+}
+
+func TestNewValidator_ValidateRequests(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    post:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	newRequest := func(path, body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com"+path, bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+	requests := []*http.Request{
+		newRequest("/burgers/1", `{"name": "big mac"}`),
+		newRequest("/burgers/1", `{"patties": 2}`),
+		newRequest("/pizza/1", `{"name": "margherita"}`),
+		newRequest("/burgers/pickles", `{"name": "whopper"}`),
+		newRequest("/burgers/1", `{"name": "quarter pounder"}`),
+	}
+
+	results := v.ValidateRequests(requests)
+	require.Len(t, results, len(requests))
+	for i, result := range results {
+		assert.Same(t, requests[i], result.Request)
+	}
+
+	assert.True(t, results[0].Valid)
+	assert.Empty(t, results[0].Errors)
+
+	assert.False(t, results[1].Valid)
+	require.Len(t, results[1].Errors, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, results[1].Errors[0].ValidationType)
+
+	assert.False(t, results[2].Valid)
+	require.Len(t, results[2].Errors, 1)
+	assert.Equal(t, "POST Path '/pizza/1' not found", results[2].Errors[0].Message)
+
+	assert.False(t, results[3].Valid)
+	require.Len(t, results[3].Errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", results[3].Errors[0].Message)
+
+	assert.True(t, results[4].Valid)
+	assert.Empty(t, results[4].Errors)
+
+	assert.Empty(t, v.ValidateRequests(nil))
+
+	// the same path behind a different forwarded prefix is resolved for each request.
+	v = NewValidatorWithOptions(&m.Model, config.WithForwardedPrefix())
+	prefixed := newRequest("/kitchen/burgers/1", `{"name": "big mac"}`)
+	prefixed.Header.Set(helpers.ForwardedPrefixHeader, "/kitchen")
+	unprefixed := newRequest("/kitchen/burgers/1", `{"name": "big mac"}`)
+
+	results = v.ValidateRequests([]*http.Request{prefixed, unprefixed})
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Valid)
+	assert.Empty(t, results[0].Errors)
+	assert.False(t, results[1].Valid)
+	require.Len(t, results[1].Errors, 1)
+	assert.Equal(t, "POST Path '/kitchen/burgers/1' not found", results[1].Errors[0].Message)
+}
+
+func TestNewValidator_SentinelErrors(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/pizza", nil)
+	_, validationErrors := v.ValidateHttpRequest(request)
+	err := liberrors.JoinValidationErrors(validationErrors)
+	assert.ErrorIs(t, err, ErrPathNotFound)
+	assert.NotErrorIs(t, err, ErrParameter)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	_, validationErrors = v.ValidateHttpRequest(request)
+	err = liberrors.JoinValidationErrors(validationErrors)
+	assert.ErrorIs(t, err, ErrParameter)
+	assert.NotErrorIs(t, err, ErrPathNotFound)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers?limit=10", nil)
+	_, validationErrors = v.ValidateHttpRequest(request)
+	assert.NoError(t, liberrors.JoinValidationErrors(validationErrors))
+}
+
+func TestNewValidator_ForwardedPrefix(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model, config.WithForwardedPrefix())
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/service-a/burgers/1234", nil)
+	request.Header.Set(helpers.ForwardedPrefixHeader, "/service-a")
+
+	valid, errors := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/service-a/burgers/big-mac", nil)
+	request.Header.Set(helpers.ForwardedPrefixHeader, "/service-a")
+
+	valid, errors = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
+}
+
 func TestNewValidator_concurrent(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
-  /burgers/createBurger:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+                vegetarian:
+                  type: boolean
+  /burgers/createBurger/{burgerId}:
+    post:
+      parameters:
+        - in: path
+          name: burgerId
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+                vegetarian:
+                  type: boolean`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+
+	assert.Empty(t, err)
+
+	v, _ := NewValidator(doc)
+
+	body := map[string]interface{}{
+		"name":       "Big Mac",
+		"patties":    2,
+		"vegetarian": true,
+	}
+
+	bodyBytes, _ := json.Marshal(body)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBuffer(bodyBytes))
+		request.Header.Set("Content-Type", "application/json")
+
+		valid, errors := v.ValidateHttpRequest(request)
+
+		assert.True(t, valid)
+		assert.Len(t, errors, 0)
+	}()
+
+	go func() {
+		defer wg.Done()
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger/toto",
+			bytes.NewBuffer(bodyBytes))
+		request.Header.Set("Content-Type", "application/json")
+
+		valid, errors := v.ValidateHttpRequest(request)
+
+		assert.True(t, valid)
+		assert.Len(t, errors, 0)
+	}()
+
+	wg.Wait()
+}
+
+func TestNewValidator_FindPath(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+  /users/me:
+    get:
+      operationId: getMe`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/1234", nil)
+	pathItem, pathValue, found := v.FindPath(request)
+	assert.True(t, found)
+	assert.Equal(t, "/users/{id}", pathValue)
+	assert.Equal(t, "getUser", pathItem.Get.OperationId)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/me", nil)
+	pathItem, pathValue, found = v.FindPath(request)
+	assert.True(t, found)
+	assert.Equal(t, "/users/me", pathValue)
+	assert.Equal(t, "getMe", pathItem.Get.OperationId)
+
+	// the path exists, but the method does not.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/users/me", nil)
+	pathItem, pathValue, found = v.FindPath(request)
+	assert.False(t, found)
+	assert.NotNil(t, pathItem)
+	assert.Equal(t, "/users/me", pathValue)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/pizza", nil)
+	pathItem, pathValue, found = v.FindPath(request)
+	assert.False(t, found)
+	assert.Nil(t, pathItem)
+	assert.Empty(t, pathValue)
+}
+
+func TestNewValidator_ValidateDocument(t *testing.T) {
+	doc, _ := libopenapi.NewDocument(petstoreBytes)
+	v, _ := NewValidator(doc)
+	valid, errs := v.ValidateDocument()
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+}
+
+func TestNewValidator_Reload(t *testing.T) {
+	buildDocument := func(path string) libopenapi.Document {
+		spec := fmt.Sprintf(`openapi: 3.1.0
+paths:
+  %s:
+    get:
+      parameters:
+        - name: size
+          in: query
+          required: true
+          schema:
+            type: integer`, path)
+		doc, _ := libopenapi.NewDocument([]byte(spec))
+		return doc
+	}
+
+	v, _ := NewValidator(buildDocument("/burgers"), config.WithStrictQueryParams())
+
+	burgers, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?size=2", nil)
+	pizzas, _ := http.NewRequest(http.MethodGet, "https://things.com/pizzas?size=2", nil)
+
+	valid, _ := v.ValidateHttpRequest(burgers)
+	assert.True(t, valid)
+	valid, _ = v.ValidateHttpRequest(pizzas)
+	assert.False(t, valid)
+
+	// validation keeps running while the model is swapped.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?size=2", nil)
+				v.ValidateHttpRequest(request)
+			}
+		}()
+	}
+	m, _ := buildDocument("/pizzas").BuildV3Model()
+	v.Reload(&m.Model)
+	wg.Wait()
+
+	valid, _ = v.ValidateHttpRequest(pizzas)
+	assert.True(t, valid)
+	valid, errs := v.ValidateHttpRequest(burgers)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.True(t, errs[0].IsPathMissingError())
+
+	// the options are kept, and the validators are rebuilt for the new model.
+	pizzas, _ = http.NewRequest(http.MethodGet, "https://things.com/pizzas?size=2&crust=thin", nil)
+	valid, _ = v.GetParameterValidator().ValidateQueryParams(pizzas)
+	assert.False(t, valid)
+
+	// the document belongs to the previous model, so it's cleared.
+	valid, errs = v.ValidateDocument()
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Document is not set", errs[0].Message)
+}
+
+// phaseRecorder is an Observer that records the events it's notified of.
+type phaseRecorder struct {
+	lock   sync.Mutex
+	events map[string]config.PhaseEvent
+}
+
+func (r *phaseRecorder) ObservePhase(event config.PhaseEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events[event.Phase] = event
+}
+
+func TestNewValidator_Observer(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{id}:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	recorder := &phaseRecorder{events: make(map[string]config.PhaseEvent)}
+	v := NewValidatorFromV3Model(&m.Model, config.WithObserver(recorder))
+
+	for _, validate := range []func(*http.Request) (bool, []*liberrors.ValidationError){
+		v.ValidateHttpRequest, v.ValidateHttpRequestSync,
+	} {
+		recorder.events = make(map[string]config.PhaseEvent)
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/1", bytes.NewBufferString(`{}`))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		valid, _ := validate(request)
+		assert.False(t, valid)
+
+		require.Len(t, recorder.events, 3)
+		routing := recorder.events[config.PhaseRouting]
+		assert.True(t, routing.Valid)
+		assert.Equal(t, "/burgers/{id}", routing.PathValue)
+		assert.Equal(t, request, routing.Request)
+		assert.True(t, recorder.events[config.PhaseParameters].Valid)
+		requestBody := recorder.events[config.PhaseRequestBody]
+		assert.False(t, requestBody.Valid)
+		assert.Equal(t, 1, requestBody.ErrorCount)
+		assert.Positive(t, requestBody.Duration)
+	}
+
+	recorder.events = make(map[string]config.PhaseEvent)
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/1", nil)
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{helpers.ContentTypeHeader: []string{helpers.JSONContentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(`"cheeseburger"`)),
+	}
+	valid, _ := v.ValidateHttpResponse(request, response)
+	assert.True(t, valid)
+	require.Len(t, recorder.events, 2)
+	assert.True(t, recorder.events[config.PhaseResponseBody].Valid)
+
+	// a request that cannot be routed only reports the routing phase.
+	recorder.events = make(map[string]config.PhaseEvent)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/pizzas", nil)
+	valid, _ = v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, recorder.events, 1)
+	assert.False(t, recorder.events[config.PhaseRouting].Valid)
+	assert.Equal(t, 1, recorder.events[config.PhaseRouting].ErrorCount)
+}
+
+func TestNewValidator_SkipBadPatterns(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                password:
+                  type: string
+                  pattern: "^(?=.*[0-9]).{8,}$"
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: string
+                pattern: "^(?!admin).*$"`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func() *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/users",
+			bytes.NewBufferString(`{"password": "secret"}`))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+	newResponse := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{helpers.ContentTypeHeader: []string{helpers.JSONContentType}},
+			Body:       io.NopCloser(bytes.NewBufferString(`"admin"`)),
+		}
+	}
+
+	// without the option, the schemas cannot be compiled, but validation does not crash.
+	v := NewValidatorFromV3Model(&m.Model)
+	valid, errs := v.ValidateHttpRequest(newRequest())
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, liberrors.MessageKeySchemaCompilationFailed, errs[0].MessageKey)
+	assert.True(t, errs[0].IsSchemaCompilationError())
+	assert.Equal(t, helpers.RequestBodyValidation, errs[0].ValidationSubType)
+
+	valid, errs = v.ValidateHttpResponse(newRequest(), newResponse())
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, liberrors.MessageKeySchemaCompilationFailed, errs[0].MessageKey)
+	assert.ErrorIs(t, errs[0], liberrors.ErrSchemaCompilation)
+	assert.Equal(t, helpers.ResponseBodyValidation, errs[0].ValidationSubType)
+
+	// with the option, the bad patterns are skipped, they are warnings, never errors.
+	v = NewValidatorFromV3Model(&m.Model, config.WithSkipBadPatterns())
+	valid, errs = v.ValidateHttpRequest(newRequest())
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// each validation of the operation reports the skipped pattern of its schemas.
+	for range 2 {
+		var warnings []*liberrors.ValidationError
+		valid, errs, warnings = v.ValidateHttpRequestWithWarnings(newRequest())
+		assert.True(t, valid)
+		assert.Empty(t, errs)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, helpers.SchemaPattern, warnings[0].ValidationSubType)
+		assert.Equal(t, "Pattern '^(?=.*[0-9]).{8,}$' cannot be compiled by the regex engine, and was skipped", warnings[0].Message)
+	}
+
+	valid, errs = v.ValidateHttpResponse(newRequest(), newResponse())
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+}
+
+func TestNewValidator_ValidateHttpRequestWithWarnings(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      deprecated: true
+      parameters:
+        - name: limit
+          in: query
+          deprecated: true
+          schema:
+            type: integer
+        - name: code
+          in: query
+          schema:
+            type: string
+            pattern: "^(?=.*[0-9]).+$"
+      responses:
+        '200':
+          description: OK`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorFromV3Model(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?limit=5&fries=true", nil)
+	valid, errs, warnings := v.ValidateHttpRequestWithWarnings(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+	require.Len(t, warnings, 3)
+	assert.Equal(t, liberrors.MessageKeyOperationDeprecated, warnings[0].MessageKey)
+	assert.Equal(t, "GET operation for '/burgers' is deprecated", warnings[0].Message)
+	assert.Equal(t, liberrors.MessageKeyParameterDeprecated, warnings[1].MessageKey)
+	assert.Equal(t, "The query parameter 'limit' is deprecated", warnings[1].Message)
+	assert.Equal(t, liberrors.MessageKeyQueryParameterIgnored, warnings[2].MessageKey)
+	assert.Equal(t, "Query parameter 'fries' is not defined, and was ignored", warnings[2].Message)
+
+	// deprecation is never a failure.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers?limit=5", nil)
+	valid, errs = v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// an invalid parameter is an error, the warnings are still reported.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers?limit=many", nil)
+	valid, errs, warnings = v.ValidateHttpRequestWithWarnings(request)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.ParameterValidationQuery, errs[0].ValidationSubType)
+	assert.Len(t, warnings, 2)
+
+	// skipped patterns are warnings, not errors.
+	v = NewValidatorFromV3Model(&m.Model, config.WithSkipBadPatterns())
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers?code=abc1", nil)
+	valid, errs, warnings = v.ValidateHttpRequestWithWarnings(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, liberrors.MessageKeyPatternNotCompiled, warnings[0].MessageKey)
+	assert.Equal(t, liberrors.MessageKeyOperationDeprecated, warnings[1].MessageKey)
+}
+
+func TestNewValidator_UndeclaredParameters(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Order
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorFromV3Model(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers?limit=5&limt=5", nil)
+	request.Header.Set("X-Ordr", "1")
+	request.Header.Set("X-Order", "1")
+	headers, query := v.UndeclaredParameters(request)
+	assert.Equal(t, []string{"X-Ordr"}, headers)
+	assert.Equal(t, []string{"limt"}, query)
+
+	// undeclared parameters do not fail validation.
+	valid, errs := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+}
+
+func TestNewValidator_ValidateHttpRequestStructured(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers/{burgerId}:
     post:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Order
+          in: header
+          required: true
+          schema:
+            type: string
       requestBody:
         content:
           application/json:
             schema:
               type: object
+              required: [name]
               properties:
                 name:
                   type: string
-                patties:
-                  type: integer
-                vegetarian:
-                  type: boolean
-  /burgers/createBurger/{burgerId}:
+                toppings:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                      extra:
+                        type: boolean
+      responses:
+        '200':
+          description: OK`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorFromV3Model(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/big?limit=many",
+		bytes.NewBufferString(`{"toppings": [{"name": "cheese", "extra": "yes"}, {"name": 2}]}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	report := v.ValidateHttpRequestStructured(request)
+	assert.False(t, report.Valid)
+	assert.Empty(t, report.Routing)
+	assert.Len(t, report.PathParameters, 1)
+	assert.Len(t, report.QueryParameters, 1)
+	assert.Len(t, report.HeaderParameters, 1)
+	assert.Empty(t, report.CookieParameters)
+	require.Len(t, report.Body, 1)
+
+	// the schema violations of the body are arranged by the field they occurred at.
+	child := func(node *BodyErrorNode, name string) *BodyErrorNode {
+		for _, c := range node.Children {
+			if c.Name == name {
+				return c
+			}
+		}
+		require.Failf(t, "missing child", "%s has no child '%s'", node.Path, name)
+		return nil
+	}
+	root := report.BodyTree
+	require.NotNil(t, root)
+	assert.Equal(t, "$", root.Path)
+	assert.Empty(t, root.Failures)
+	require.Len(t, root.Children, 2)
+
+	name := child(root, "name")
+	require.Len(t, name.Failures, 1)
+	assert.Equal(t, "missing property 'name'", name.Failures[0].Reason)
+
+	toppings := child(root, "toppings")
+	assert.Empty(t, toppings.Failures)
+	require.Len(t, toppings.Children, 2)
+
+	extra := child(child(toppings, "0"), "extra")
+	assert.Equal(t, "$.toppings[0].extra", extra.Path)
+	assert.Len(t, extra.Failures, 1)
+	assert.Equal(t, "$.toppings[1].name", child(child(toppings, "1"), "name").Path)
+
+	// a request that could not be routed only has routing errors.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/fries", nil)
+	report = v.ValidateHttpRequestStructured(request)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Routing, 1)
+	assert.Nil(t, report.BodyTree)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/1",
+		bytes.NewBufferString(`{"name": "Big Mac"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Order", "1")
+	report = v.ValidateHttpRequestStructured(request)
+	assert.True(t, report.Valid)
+	assert.Empty(t, report.Body)
+	assert.Nil(t, report.BodyTree)
+}
+
+func TestNewValidator_ValidateSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+components:
+  schemas:
+    Burger:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        patties:
+          type: integer
+          minimum: 1
+        bun:
+          $ref: '#/components/schemas/Bun'
+    Bun:
+      type: string
+      enum: [brioche, sesame]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorFromV3Model(&m.Model)
+
+	valid, errs := v.ValidateSchema("Burger", []byte(`{"name": "classic", "patties": 2, "bun": "brioche"}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateSchema("Burger", []byte(`{"patties": 0, "bun": "bagel"}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 3)
+
+	valid, errs = v.ValidateSchema("Bun", []byte(`"sesame"`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// a schema that is not declared in the components is reported, rather than validating anything.
+	valid, errs = v.ValidateSchema("Fries", []byte(`{}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Schema 'Fries' not found", errs[0].Message)
+	assert.Equal(t, liberrors.MessageKeySchemaNotFound, errs[0].MessageKey)
+}
+
+func TestNewValidator_ValidateExamples(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers:
     post:
-      parameters:
-        - in: path
-          name: burgerId
-          required: true
-          schema:
-            type: string
       requestBody:
         content:
           application/json:
             schema:
               type: object
               properties:
-                name:
-                  type: string
                 patties:
                   type: integer
-                vegetarian:
-                  type: boolean`
+            example:
+              patties: lots
+      responses:
+        '200':
+          description: OK`
 
-	doc, err := libopenapi.NewDocument([]byte(spec))
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
 
-	assert.Empty(t, err)
+	errs := v.ValidateExamples()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Example '#/paths/~1burgers/post/requestBody/content/application~1json/example' does not match its schema",
+		errs[0].Message)
+	assert.Equal(t, 17, errs[0].SpecLine)
+
+	doc, _ = libopenapi.NewDocument(petstoreBytes)
+	v, _ = NewValidator(doc)
+	assert.Empty(t, v.ValidateExamples())
+}
+
+func TestNewValidator_ValidateDocument_Defaults(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: ten
+      responses:
+        '200':
+          description: OK`
 
+	doc, _ := libopenapi.NewDocument([]byte(spec))
 	v, _ := NewValidator(doc)
 
-	body := map[string]interface{}{
-		"name":       "Big Mac",
-		"patties":    2,
-		"vegetarian": true,
-	}
+	valid, errs := v.ValidateDocument()
+	assert.False(t, valid)
+	require.NotEmpty(t, errs)
 
-	bodyBytes, _ := json.Marshal(body)
+	last := errs[len(errs)-1]
+	assert.Equal(t, helpers.DocumentDefault, last.ValidationSubType)
+	assert.Equal(t, "Default value '#/paths/~1burgers/get/parameters/0/schema/default' does not match its schema",
+		last.Message)
+	assert.Equal(t, 13, last.SpecLine)
+}
 
-	var wg sync.WaitGroup
-	wg.Add(2)
-	go func() {
-		defer wg.Done()
-		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
-			bytes.NewBuffer(bodyBytes))
-		request.Header.Set("Content-Type", "application/json")
+func TestNewValidator_ValidateDocument_DefaultsWithOptions(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: code
+          in: query
+          schema:
+            type: string
+            format: burger-code
+            pattern: "^(?=.*[0-9]).+$"
+            default: BC-1
+      responses:
+        '200':
+          description: OK`
 
-		valid, errors := v.ValidateHttpRequest(request)
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	burgerCode := func(v any) error {
+		if s, ok := v.(string); ok && !strings.HasPrefix(s, "BC-") {
+			return fmt.Errorf("'%s' is not a burger code", s)
+		}
+		return nil
+	}
 
-		assert.True(t, valid)
-		assert.Len(t, errors, 0)
-	}()
+	// the defaults are checked with the same options as requests and responses.
+	v, _ := NewValidator(doc, config.WithSkipBadPatterns(), config.WithFormatAssertions(),
+		config.WithFormatValidator("burger-code", burgerCode))
+	valid, errs := v.ValidateDocument()
+	assert.True(t, valid)
+	assert.Empty(t, errs)
 
-	go func() {
-		defer wg.Done()
-		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger/toto",
-			bytes.NewBuffer(bodyBytes))
-		request.Header.Set("Content-Type", "application/json")
+	v, _ = NewValidator(doc, config.WithSkipBadPatterns(), config.WithFormatAssertions(),
+		config.WithFormatValidator("burger-code", func(any) error { return fmt.Errorf("nope") }))
+	valid, errs = v.ValidateDocument()
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.DocumentDefault, errs[0].ValidationSubType)
+}
 
-		valid, errors := v.ValidateHttpRequest(request)
+func TestNewValidator_ValidateDocument_ParameterStyles(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: filter
+          in: query
+          style: matrix
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK`
 
-		assert.True(t, valid)
-		assert.Len(t, errors, 0)
-	}()
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
 
-	wg.Wait()
+	valid, errs := v.ValidateDocument()
+	assert.False(t, valid)
+	require.NotEmpty(t, errs)
+
+	last := errs[len(errs)-1]
+	assert.Equal(t, helpers.DocumentValidation, last.ValidationType)
+	assert.Equal(t, "Parameter 'filter' uses the 'matrix' style, which is not allowed for 'query' parameters", last.Message)
+	assert.Equal(t, 16, last.SpecLine)
 }
 
-func TestNewValidator_ValidateDocument(t *testing.T) {
-	doc, _ := libopenapi.NewDocument(petstoreBytes)
+func TestNewValidator_ValidateDocument_PathParameters(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
 	v, _ := NewValidator(doc)
+
 	valid, errs := v.ValidateDocument()
-	assert.True(t, valid)
-	assert.Len(t, errs, 0)
+	assert.False(t, valid)
+	require.Len(t, errs, 2)
+	assert.Equal(t, liberrors.MessageKeyPathParamNotInTemplate, errs[0].MessageKey)
+	assert.Equal(t, liberrors.MessageKeyPathParamNotDeclared, errs[1].MessageKey)
+	assert.Equal(t, "Path parameter 'burgerId' of '/burgers/{burgerId}' is not declared by the GET operation",
+		errs[1].Message)
 }
 
 type dlclarkRegexp regexp2.Regexp
@@ -1147,7 +2651,7 @@ func TestNewValidator_PetStore_PetGet200_PathNotFound(t *testing.T) {
 	assert.False(t, valid)
 	assert.Len(t, errors, 2)
 	assert.Equal(t, "API Key api_key not found in header", errors[0].Message)
-	assert.Equal(t, "Path parameter 'petId' is not a valid number", errors[1].Message)
+	assert.Equal(t, "Path parameter 'petId' is not a valid integer", errors[1].Message)
 }
 
 func TestNewValidator_PetStore_PetGet200(t *testing.T) {
@@ -1751,3 +3255,91 @@ components:
 	assert.True(t, valid)
 	assert.Len(t, vErrs, 0)
 }
+
+func TestNewValidator_RequestBodyCanBeReadAfterValidation(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      parameters:
+        - name: chef
+          in: query
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+
+	payload := `{"name": "Big Mac"}`
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger?chef=ronald",
+		strings.NewReader(payload))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errs := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// validating again must see the same body.
+	valid, errs = v.ValidateHttpRequestSync(request)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// and the handler must be able to read the original body.
+	read, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(read))
+}
+
+func TestNewValidator_ValidateResponseCode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      responses:
+        '200':
+          description: ok
+        '2XX':
+          description: success
+        default:
+          description: error
+  /fries:
+    get:
+      responses:
+        '200':
+          description: ok`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorWithOptions(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	for _, code := range []int{http.StatusOK, http.StatusAccepted, http.StatusTeapot} {
+		valid, errs := v.ValidateResponseCode(request, code)
+		assert.True(t, valid)
+		assert.Empty(t, errs)
+	}
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/fries", nil)
+	valid, errs := v.ValidateResponseCode(request, http.StatusTeapot)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "status code 418 is not defined for this operation", errs[0].Message)
+	assert.Equal(t, helpers.ResponseBodyResponseCode, errs[0].ValidationSubType)
+	assert.Equal(t, "/fries", errs[0].SpecPath)
+	assert.Equal(t, 14, errs[0].SpecLine)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/pizza", nil)
+	valid, errs = v.ValidateResponseCode(request, http.StatusOK)
+	assert.False(t, valid)
+	assert.ErrorIs(t, liberrors.JoinValidationErrors(errs), ErrPathNotFound)
+}